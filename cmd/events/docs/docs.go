@@ -15,10 +15,18 @@ const docTemplate = `{
     },
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
-     "paths": {
+    "paths": {
         "/checkout": {
             "post": {
-                "description": "Buy tickets for a specific event",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Buy tickets for a specific event. Requests retried with the same Idempotency-Key return the original response. coupon_codes applies promo codes to every ticket; each ticket shows its final price and coupon discount.",
                 "consumes": [
                     "application/json"
                 ],
@@ -30,6 +38,12 @@ const docTemplate = `{
                 ],
                 "summary": "Buy tickets for an event",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Key that identifies the purchase across retries; scoped to the authenticated user",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    },
                     {
                         "description": "Input data",
                         "name": "input",
@@ -50,13 +64,182 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "502": {
+                        "description": "Bad Gateway",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/coupons": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a promo code for one event (event_id) or for every event of an organization (organization). A percentage coupon takes discount_percent; a fixed coupon takes discount_amount per ticket. Codes are case-insensitive and unique.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coupons"
+                ],
+                "summary": "Create a coupon",
+                "parameters": [
+                    {
+                        "description": "Input data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.CreateCouponInputDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.CouponDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/coupons/{code}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get a coupon and how many purchases have redeemed it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Coupons"
+                ],
+                "summary": "Get coupon by code",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Coupon code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.CouponDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/http.Problem"
                         }
                     }
                 }
@@ -64,6 +247,14 @@ const docTemplate = `{
         },
         "/event": {
             "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
                 "description": "create event",
                 "consumes": [
                     "application/json"
@@ -87,22 +278,34 @@ const docTemplate = `{
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/usecase.CreateEventInputDTO"
+                            "$ref": "#/definitions/usecase.CreateEventOutputDTO"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/http.Problem"
                         }
                     }
                 }
@@ -110,7 +313,7 @@ const docTemplate = `{
         },
         "/events": {
             "get": {
-                "description": "Get all events with their details",
+                "description": "List events one page at a time, optionally filtered and sorted. Pass next_cursor as cursor to get the next page.",
                 "consumes": [
                     "application/json"
                 ],
@@ -120,7 +323,95 @@ const docTemplate = `{
                 "tags": [
                     "Events"
                 ],
-                "summary": "List all events",
+                "summary": "List events",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Events on or after this date (YYYY-MM-DD or RFC 3339)",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Events on or before this date (YYYY-MM-DD or RFC 3339)",
+                        "name": "date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Part of the event location",
+                        "name": "location",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Organization",
+                        "name": "organization",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Rating (L, L10, L12, L14, L16, L18)",
+                        "name": "rating",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Partner ID",
+                        "name": "partner_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISO 4217 currency of the events and of the price range; required with price_min, price_max or sort by price",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated statuses (published, sales_open, sold_out, cancelled, postponed); drafts are never listed",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum price, in major units of currency; compared with the lowest price of the event across its tiers",
+                        "name": "price_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum price, in major units of currency; compared with the lowest price of the event across its tiers",
+                        "name": "price_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only events with spots available for purchase",
+                        "name": "has_availability",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "date",
+                        "description": "date, price (the lowest price across tiers, requires currency) or name; prefix with - for descending order",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -128,10 +419,16 @@ const docTemplate = `{
                             "$ref": "#/definitions/usecase.ListEventsOutputDTO"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/http.Problem"
                         }
                     }
                 }
@@ -166,30 +463,89 @@ const docTemplate = `{
                             "$ref": "#/definitions/usecase.GetEventOutputDTO"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete an event and its spots. Events with sold tickets must be cancelled instead.",
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Delete an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/http.Problem"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/http.Problem"
                         }
                     }
                 }
-            }
-        },
-        "/events/{eventID}/spots": {
-            "get": {
-                "description": "List all spots for a specific event",
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Change only the fields sent. The price cannot change after tickets are sold, and a new date must be in the future.",
                 "consumes": [
                     "application/json"
                 ],
@@ -199,7 +555,7 @@ const docTemplate = `{
                 "tags": [
                     "Events"
                 ],
-                "summary": "List spots for an event",
+                "summary": "Update an event",
                 "parameters": [
                     {
                         "type": "string",
@@ -207,42 +563,1323 @@ const docTemplate = `{
                         "name": "eventID",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.UpdateEventInputDTO"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/usecase.ListSpotsOutputDTO"
+                            "$ref": "#/definitions/usecase.EventDTO"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/http.Problem"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "usecase.BuyTicketsInputDTO": {
-            "type": "object",
-            "properties": {
+        },
+        "/events/{eventID}/cancel": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Cancel an event permanently and flag all of its issued tickets for refund",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Cancel an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.EventLifecycleOutputDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{eventID}/holds": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Hold a set of spots for a session for N minutes; checkout only accepts spots held by the same session of the same authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Hold spots for an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Input data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.HoldSpotsInputDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.HoldSpotsOutputDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{eventID}/open-sales": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Open ticket sales for a published event; holds and checkout are only accepted while sales are open",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Open ticket sales",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.EventLifecycleOutputDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{eventID}/postpone": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Postpone an event, suspending sales until it is published again. The new date is optional.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Postpone an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New date",
+                        "name": "input",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.PostponeEventInputDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.EventLifecycleOutputDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{eventID}/publish": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Publish a draft event, or republish a postponed one. The event date must be in the future.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Publish an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.EventLifecycleOutputDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{eventID}/spots": {
+            "get": {
+                "description": "List all spots for a specific event",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "List spots for an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ListSpotsOutputDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a number of spots for an event",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Create spots for an event",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Input data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.CreateSpotsInputDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.CreateSpotsOutputDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/events/{eventID}/spots/{spotID}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Delete a spot of an event. Sold spots and spots held by a session cannot be deleted.",
+                "tags": [
+                    "Events"
+                ],
+                "summary": "Delete a spot",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Event ID",
+                        "name": "eventID",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Spot ID",
+                        "name": "spotID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/partners/breakers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Get the circuit breaker state (closed, open or half_open) of each configured partner",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Partners"
+                ],
+                "summary": "List partner circuit breakers",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ListPartnerBreakersOutputDTO"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/venues": {
+            "get": {
+                "description": "Get all venues ordered by name, without their seating maps",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Venues"
+                ],
+                "summary": "List venues",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.ListVenuesOutputDTO"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Create a venue with a reusable seating map. Each section lists its rows or generates them from a layout; row labels must be unique across the venue. Events created with the venue_id get one spot per seat.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Venues"
+                ],
+                "summary": "Create a venue",
+                "parameters": [
+                    {
+                        "description": "Input data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usecase.CreateVenueInputDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.VenueDTO"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/venues/{venueID}": {
+            "get": {
+                "description": "Get a venue with its seating map",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Venues"
+                ],
+                "summary": "Get venue by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Venue ID",
+                        "name": "venueID",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usecase.VenueDTO"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/http.Problem"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "domain.FieldError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "invalid_format"
+                },
+                "field": {
+                    "type": "string",
+                    "example": "email"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "email must be a valid address"
+                }
+            }
+        },
+        "http.Problem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "event_not_found"
+                },
+                "detail": {
+                    "type": "string",
+                    "example": "event not found"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/domain.FieldError"
+                    }
+                },
+                "instance": {
+                    "type": "string",
+                    "example": "/events/8beff8fd-39e4-49ea-ae5e-a0ec9af888c5"
+                },
+                "status": {
+                    "type": "integer",
+                    "example": 404
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Not Found"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "urn:events:problem:event_not_found"
+                }
+            }
+        },
+        "usecase.BuyTicketsInputDTO": {
+            "type": "object",
+            "properties": {
                 "card_hash": {
                     "type": "string"
                 },
-                "email": {
-                    "type": "string"
+                "coupon_codes": {
+                    "description": "CouponCodes são os cupons aplicados a todos os ingressos da compra; maiúsculas e minúsculas são equivalentes.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "eligibility_document": {
+                    "description": "EligibilityDocument é o número do comprovante exigido por tipos como meia-entrada de estudante.",
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "string"
+                },
+                "session_id": {
+                    "type": "string"
+                },
+                "spots": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "ticket_kind": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.BuyTicketsOutputDTO": {
+            "type": "object",
+            "properties": {
+                "tickets": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.TicketDTO"
+                    }
+                }
+            }
+        },
+        "usecase.CouponDTO": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "BLACKFRIDAY"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "discount_amount": {
+                    "type": "number",
+                    "example": 15
+                },
+                "discount_percent": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "discount_type": {
+                    "type": "string",
+                    "example": "percentage"
+                },
+                "event_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "max_redemptions": {
+                    "type": "integer"
+                },
+                "max_redemptions_per_email": {
+                    "type": "integer"
+                },
+                "organization": {
+                    "type": "string"
+                },
+                "redemptions": {
+                    "type": "integer"
+                },
+                "stacks_with_coupons": {
+                    "type": "boolean"
+                },
+                "stacks_with_ticket_kinds": {
+                    "type": "boolean"
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_until": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.CreateCouponInputDTO": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "BLACKFRIDAY"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "discount_amount": {
+                    "type": "number",
+                    "example": 15
+                },
+                "discount_percent": {
+                    "type": "integer",
+                    "example": 20
+                },
+                "discount_type": {
+                    "type": "string",
+                    "example": "percentage"
+                },
+                "event_id": {
+                    "type": "string"
+                },
+                "max_redemptions": {
+                    "description": "MaxRedemptions e MaxRedemptionsPerEmail limitam as compras com o cupom; 0 = sem limite.",
+                    "type": "integer"
+                },
+                "max_redemptions_per_email": {
+                    "type": "integer"
+                },
+                "organization": {
+                    "type": "string"
+                },
+                "stacks_with_coupons": {
+                    "description": "StacksWithCoupons permite combinar o cupom com outros cupons; StacksWithTicketKinds, usá-lo\nem tipos de ingresso que já têm desconto (meia-entrada).",
+                    "type": "boolean"
+                },
+                "stacks_with_ticket_kinds": {
+                    "type": "boolean"
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_until": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.CreateEventInputDTO": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "description": "ISO 4217; padrão BRL",
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "organization": {
+                    "type": "string"
+                },
+                "partner_id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 100
+                },
+                "rating": {
+                    "type": "string"
+                },
+                "ticket_kinds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.TicketKindInputDTO"
+                    }
+                },
+                "tiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.PriceTierInputDTO"
+                    }
+                },
+                "venue_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.CreateEventOutputDTO": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "organization": {
+                    "type": "string"
+                },
+                "partner_id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 100
+                },
+                "rating": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "sales_open"
+                },
+                "ticket_kinds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.TicketKindDTO"
+                    }
+                },
+                "tiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.PriceTierDTO"
+                    }
+                },
+                "venue_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.CreateSpotsInputDTO": {
+            "type": "object",
+            "properties": {
+                "event_id": {
+                    "type": "string"
+                },
+                "layout": {
+                    "$ref": "#/definitions/usecase.SpotLayoutDTO"
+                },
+                "number_of_spots": {
+                    "type": "integer"
+                },
+                "tier": {
+                    "type": "string",
+                    "example": "VIP"
+                }
+            }
+        },
+        "usecase.CreateSpotsOutputDTO": {
+            "type": "object",
+            "properties": {
+                "spots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.SpotDTO"
+                    }
+                }
+            }
+        },
+        "usecase.CreateVenueInputDTO": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "sections": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.VenueSectionInputDTO"
+                    }
+                }
+            }
+        },
+        "usecase.EventDTO": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "organization": {
+                    "type": "string"
+                },
+                "partner_id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 100
+                },
+                "rating": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "sales_open"
+                },
+                "venue_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.EventLifecycleOutputDTO": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "refund_pending_tickets": {
+                    "description": "RefundPendingTickets é a quantidade de ingressos marcados para reembolso pelo cancelamento.",
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "published"
+                }
+            }
+        },
+        "usecase.GetEventOutputDTO": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer"
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "location": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "organization": {
+                    "type": "string"
+                },
+                "partner_id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "number",
+                    "example": 100
+                },
+                "rating": {
+                    "type": "string"
+                },
+                "remaining_capacity": {
+                    "description": "RemainingCapacity é quantos ingressos ainda podem ser vendidos: Capacity menos os ingressos vendidos.",
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string",
+                    "example": "sales_open"
+                },
+                "ticket_kinds": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.TicketKindDTO"
+                    }
+                },
+                "tiers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.PriceTierDTO"
+                    }
+                },
+                "venue_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.HoldSpotsInputDTO": {
+            "type": "object",
+            "properties": {
+                "event_id": {
+                    "type": "string"
+                },
+                "minutes": {
+                    "description": "de 1 a 30; 0 usa o padrão de 10 minutos",
+                    "type": "integer"
                 },
-                "event_id": {
+                "session_id": {
                     "type": "string"
                 },
                 "spots": {
@@ -250,100 +1887,315 @@ const docTemplate = `{
                     "items": {
                         "type": "string"
                     }
+                }
+            }
+        },
+        "usecase.HoldSpotsOutputDTO": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
                 },
-                "ticket_kind": {
+                "session_id": {
                     "type": "string"
+                },
+                "spots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.SpotDTO"
+                    }
                 }
             }
         },
-        "usecase.BuyTicketsOutputDTO": {
+        "usecase.ListEventsOutputDTO": {
             "type": "object",
             "properties": {
-                "tickets": {
+                "events": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/usecase.TicketDTO"
+                        "$ref": "#/definitions/usecase.EventDTO"
                     }
+                },
+                "next_cursor": {
+                    "description": "NextCursor deve ser enviado como cursor para obter a próxima página; vazio na última página.",
+                    "type": "string"
                 }
             }
         },
-        "usecase.CreateEventInputDTO": {
+        "usecase.ListPartnerBreakersOutputDTO": {
             "type": "object",
             "properties": {
-                "capacity": {
+                "breakers": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.PartnerBreakerDTO"
+                    }
+                }
+            }
+        },
+        "usecase.ListSpotsOutputDTO": {
+            "type": "object",
+            "properties": {
+                "event": {
+                    "$ref": "#/definitions/usecase.EventDTO"
+                },
+                "spots": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.SpotDTO"
+                    }
+                }
+            }
+        },
+        "usecase.ListVenuesOutputDTO": {
+            "type": "object",
+            "properties": {
+                "venues": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.VenueSummaryDTO"
+                    }
+                }
+            }
+        },
+        "usecase.PartnerBreakerDTO": {
+            "type": "object",
+            "properties": {
+                "consecutive_failures": {
                     "type": "integer"
                 },
-                "date": {
+                "opened_at": {
                     "type": "string"
                 },
-                "image_url": {
-                    "type": "string"
+                "partner_id": {
+                    "type": "integer"
                 },
-                "location": {
+                "retry_at": {
                     "type": "string"
                 },
-                "name": {
+                "state": {
+                    "type": "string"
+                }
+            }
+        },
+        "usecase.PostponeEventInputDTO": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "description": "Date é a nova data do evento; opcional, pode ser definida depois ao republicar.",
                     "type": "string"
+                }
+            }
+        },
+        "usecase.PriceTierDTO": {
+            "type": "object",
+            "properties": {
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
                 },
-                "organization": {
+                "id": {
                     "type": "string"
                 },
-                "partner_id": {
-                    "type": "integer"
+                "name": {
+                    "type": "string",
+                    "example": "VIP"
                 },
                 "price": {
-                    "type": "number"
-                },
-                "rating": {
-                    "type": "string"
+                    "type": "number",
+                    "example": 250
                 }
             }
         },
-        "usecase.EventDTO": {
+        "usecase.PriceTierInputDTO": {
             "type": "object",
             "properties": {
-                "capacity": {
-                    "type": "integer"
+                "name": {
+                    "type": "string",
+                    "example": "VIP"
                 },
-                "date": {
+                "price": {
+                    "type": "number",
+                    "example": 250
+                },
+                "sections": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "usecase.SpotDTO": {
+            "type": "object",
+            "properties": {
+                "Status": {
                     "type": "string"
                 },
-                "id": {
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "event_id": {
                     "type": "string"
                 },
-                "image_url": {
+                "hold_expires_at": {
                     "type": "string"
                 },
-                "location": {
+                "id": {
                     "type": "string"
                 },
                 "name": {
                     "type": "string"
                 },
-                "organization": {
+                "price": {
+                    "type": "number",
+                    "example": 100
+                },
+                "reserved": {
+                    "type": "boolean"
+                },
+                "section_id": {
                     "type": "string"
                 },
-                "partner_id": {
+                "ticket_id": {
+                    "type": "string"
+                },
+                "tier": {
+                    "type": "string",
+                    "example": "VIP"
+                }
+            }
+        },
+        "usecase.SpotLayoutDTO": {
+            "type": "object",
+            "properties": {
+                "label_scheme": {
+                    "description": "letters (padrão) ou numeric",
+                    "type": "string",
+                    "example": "letters"
+                },
+                "row_seats": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "rows": {
                     "type": "integer"
                 },
+                "seats_per_row": {
+                    "type": "integer"
+                },
+                "skip_labels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "usecase.TicketDTO": {
+            "type": "object",
+            "properties": {
+                "coupon_discount": {
+                    "type": "number",
+                    "example": 0
+                },
+                "coupons": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "currency": {
+                    "type": "string",
+                    "example": "BRL"
+                },
+                "id": {
+                    "type": "string"
+                },
                 "price": {
-                    "type": "number"
+                    "type": "number",
+                    "example": 50
                 },
-                "rating": {
+                "spot_id": {
+                    "type": "string"
+                },
+                "ticket_kind": {
                     "type": "string"
                 }
             }
         },
-        "usecase.GetEventOutputDTO": {
+        "usecase.TicketKindDTO": {
+            "type": "object",
+            "properties": {
+                "companion_seats": {
+                    "type": "integer"
+                },
+                "discount_percent": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "document": {
+                    "type": "string",
+                    "example": "student_id"
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "student"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Meia-entrada estudante"
+                },
+                "quota_percent": {
+                    "type": "integer",
+                    "example": 40
+                }
+            }
+        },
+        "usecase.TicketKindInputDTO": {
+            "type": "object",
+            "properties": {
+                "companion_seats": {
+                    "type": "integer"
+                },
+                "discount_percent": {
+                    "type": "integer",
+                    "example": 50
+                },
+                "document": {
+                    "type": "string",
+                    "example": "student_id"
+                },
+                "kind": {
+                    "type": "string",
+                    "example": "student"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Meia-entrada estudante"
+                },
+                "quota_percent": {
+                    "description": "0 = sem cota",
+                    "type": "integer",
+                    "example": 40
+                }
+            }
+        },
+        "usecase.UpdateEventInputDTO": {
             "type": "object",
             "properties": {
                 "capacity": {
                     "type": "integer"
                 },
-                "date": {
-                    "type": "string"
+                "currency": {
+                    "description": "Currency só pode ser alterada junto com Price.",
+                    "type": "string",
+                    "example": "BRL"
                 },
-                "id": {
+                "date": {
                     "type": "string"
                 },
                 "image_url": {
@@ -355,85 +2207,126 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
-                "organization": {
-                    "type": "string"
-                },
-                "partner_id": {
-                    "type": "integer"
-                },
                 "price": {
-                    "type": "number"
+                    "type": "number",
+                    "example": 100
                 },
                 "rating": {
                     "type": "string"
                 }
             }
         },
-        "usecase.ListEventsOutputDTO": {
+        "usecase.VenueDTO": {
             "type": "object",
             "properties": {
-                "events": {
+                "address": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "seat_count": {
+                    "type": "integer"
+                },
+                "sections": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/usecase.EventDTO"
+                        "$ref": "#/definitions/usecase.VenueSectionDTO"
                     }
                 }
             }
         },
-        "usecase.ListSpotsOutputDTO": {
+        "usecase.VenueRowDTO": {
             "type": "object",
             "properties": {
-                "event": {
-                    "$ref": "#/definitions/usecase.EventDTO"
+                "label": {
+                    "type": "string",
+                    "example": "A"
                 },
-                "spots": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/usecase.SpotDTO"
-                    }
+                "seats": {
+                    "type": "integer"
                 }
             }
         },
-        "usecase.SpotDTO": {
+        "usecase.VenueRowInputDTO": {
             "type": "object",
             "properties": {
-                "Status": {
-                    "type": "string"
-                },
-                "event_id": {
-                    "type": "string"
+                "label": {
+                    "type": "string",
+                    "example": "A"
                 },
+                "seats": {
+                    "type": "integer"
+                }
+            }
+        },
+        "usecase.VenueSectionDTO": {
+            "type": "object",
+            "properties": {
                 "id": {
                     "type": "string"
                 },
                 "name": {
                     "type": "string"
                 },
-                "reserved": {
-                    "type": "boolean"
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.VenueRowDTO"
+                    }
                 },
-                "ticket_id": {
-                    "type": "string"
+                "seat_count": {
+                    "type": "integer"
                 }
             }
         },
-        "usecase.TicketDTO": {
+        "usecase.VenueSectionInputDTO": {
             "type": "object",
             "properties": {
-                "Price": {
-                    "type": "number"
+                "layout": {
+                    "$ref": "#/definitions/usecase.SpotLayoutDTO"
                 },
-                "id": {
+                "name": {
                     "type": "string"
                 },
-                "spot_id": {
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/usecase.VenueRowInputDTO"
+                    }
+                }
+            }
+        },
+        "usecase.VenueSummaryDTO": {
+            "type": "object",
+            "properties": {
+                "address": {
                     "type": "string"
                 },
-                "ticket_kind": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
                     "type": "string"
                 }
             }
         }
+    },
+    "securityDefinitions": {
+        "ApiKeyAuth": {
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
+        "BearerAuth": {
+            "description": "JWT (HS256 ou RS256) no formato \"Bearer \u003ctoken\u003e\"",
+            "type": "apiKey",
+            "name": "Authorization",
+            "in": "header"
+        }
     }
 }`
 
@@ -12,6 +12,9 @@ import (
 
 	_ "github.com/go-sql-driver/mysql"
 
+	"github.com/Eddiesantle/golang-inbound-selling/internal/config"
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/infra/auth"
 	"github.com/Eddiesantle/golang-inbound-selling/internal/events/infra/repository"
 	"github.com/Eddiesantle/golang-inbound-selling/internal/events/infra/service"
 	"github.com/Eddiesantle/golang-inbound-selling/internal/events/usecase"
@@ -26,31 +29,117 @@ import (
 // @version 1.0
 // @description This is a sample server Petstore server.
 // @termsOfService http://swagger.io/terms/
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
+// @description JWT (HS256 ou RS256) no formato "Bearer <token>"
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
 func main() {
-	db, err := sql.Open("mysql", "test_user:test_password@tcp(golang-mysql:3306)/test_db")
+	cfg, err := config.Load()
 	if err != nil {
 		log.Fatal(err)
 	}
-	defer db.Close()
 
-	eventRepo, err := repository.NewMysqlEventRepository(db)
-	if err != nil {
-		log.Fatal(err)
+	// Subcomando "migrate": gerencia as migrações do banco e encerra
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(context.Background(), cfg, os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		return
 	}
 
-	// Apontamento para Gateway API - KONG
-	partnerBaseURLs := map[int]string{
-		1: "http://host.docker.internal:8000/partner1",
-		2: "http://host.docker.internal:8000/partner2",
+	// Seleciona a implementação do repositório: "mysql" (padrão) ou "memory" (testes e desenvolvimento local)
+	var repos domain.Repositories
+	switch cfg.Database.Driver {
+	case config.DatabaseDriverMySQL:
+		db, err := sql.Open("mysql", cfg.Database.DSN)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer db.Close()
+
+		if err := migrateOnStartup(context.Background(), db, cfg.Database); err != nil {
+			log.Fatal(err)
+		}
+
+		repos, err = repository.NewMysqlEventRepository(db)
+		if err != nil {
+			log.Fatal(err)
+		}
+	case config.DatabaseDriverMemory:
+		repos = repository.NewMemoryEventRepository()
 	}
 
-	listEventsUseCase := usecase.NewListEventsUseCase(eventRepo)
-	getEventUseCase := usecase.NewGetEventUseCase(eventRepo)
-	createEventUseCase := usecase.NewCreateEventUseCase(eventRepo)
-	partnerFactory := service.NewPartnerFactory(partnerBaseURLs)
-	buyTicketsUseCase := usecase.NewBuyTicketsUseCase(eventRepo, partnerFactory)
-	createSpotsUseCase := usecase.NewCreateSpotsUseCase(eventRepo)
-	listSpotsUseCase := usecase.NewListSpotsUseCase(eventRepo)
+	listEventsUseCase := usecase.NewListEventsUseCase(repos)
+	getEventUseCase := usecase.NewGetEventUseCase(repos)
+	createEventUseCase := usecase.NewCreateEventUseCase(repos)
+	partnerFactory, err := service.NewPartnerFactory(service.DefaultPartnerRegistry, cfg.Partners)
+	if err != nil {
+		log.Fatal(err)
+	}
+	buyTicketsUseCase := usecase.NewBuyTicketsUseCase(repos, partnerFactory)
+	createSpotsUseCase := usecase.NewCreateSpotsUseCase(repos)
+	listSpotsUseCase := usecase.NewListSpotsUseCase(repos)
+	holdSpotsUseCase := usecase.NewHoldSpotsUseCase(repos)
+	updateEventUseCase := usecase.NewUpdateEventUseCase(repos)
+	deleteEventUseCase := usecase.NewDeleteEventUseCase(repos)
+	deleteSpotUseCase := usecase.NewDeleteSpotUseCase(repos)
+	publishEventUseCase := usecase.NewPublishEventUseCase(repos)
+	openEventSalesUseCase := usecase.NewOpenEventSalesUseCase(repos)
+	postponeEventUseCase := usecase.NewPostponeEventUseCase(repos)
+	cancelEventUseCase := usecase.NewCancelEventUseCase(repos)
+	createVenueUseCase := usecase.NewCreateVenueUseCase(repos)
+	getVenueUseCase := usecase.NewGetVenueUseCase(repos)
+	listVenuesUseCase := usecase.NewListVenuesUseCase(repos)
+	createCouponUseCase := usecase.NewCreateCouponUseCase(repos)
+	getCouponUseCase := usecase.NewGetCouponUseCase(repos)
+	releaseExpiredHoldsUseCase := usecase.NewReleaseExpiredHoldsUseCase(repos)
+	compensateCheckoutsUseCase := usecase.NewCompensateCheckoutsUseCase(repos, partnerFactory, cfg.Workers.CompensationStaleAfter)
+	listPartnerBreakersUseCase := usecase.NewListPartnerBreakersUseCase(partnerFactory)
+	purgeIdempotencyKeysUseCase := usecase.NewPurgeIdempotencyKeysUseCase(repos)
+
+	// Contexto dos processos em segundo plano, cancelado no graceful shutdown
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	defer stopWorkers()
+
+	// Compensa periodicamente as reservas no parceiro que não viraram ingressos
+	// (inclusive as deixadas para trás por um restart do processo)
+	go runPeriodically(workersCtx, cfg.Workers.CompensationInterval, func() {
+		output, err := compensateCheckoutsUseCase.Execute(workersCtx)
+		if err != nil {
+			log.Printf("Erro ao compensar checkouts: %v\n", err)
+			return
+		}
+		if output.Compensated > 0 || output.Pending > 0 || output.Failed > 0 {
+			log.Printf("Checkouts compensados: %d, pendentes: %d, com falha: %d\n", output.Compensated, output.Pending, output.Failed)
+		}
+	})
+
+	// Libera periodicamente os spots cuja retenção expirou
+	go runPeriodically(workersCtx, cfg.Workers.HoldReleaseInterval, func() {
+		output, err := releaseExpiredHoldsUseCase.Execute(workersCtx)
+		if err != nil {
+			log.Printf("Erro ao liberar retenções expiradas: %v\n", err)
+			return
+		}
+		if output.Released > 0 {
+			log.Printf("Retenções expiradas liberadas: %d\n", output.Released)
+		}
+	})
+
+	// Remove periodicamente as chaves de idempotência expiradas
+	go runPeriodically(workersCtx, cfg.Workers.IdempotencyPurgeInterval, func() {
+		output, err := purgeIdempotencyKeysUseCase.Execute(workersCtx)
+		if err != nil {
+			log.Printf("Erro ao remover chaves de idempotência expiradas: %v\n", err)
+			return
+		}
+		if output.Deleted > 0 {
+			log.Printf("Chaves de idempotência expiradas removidas: %d\n", output.Deleted)
+		}
+	})
 
 	eventsHandler := httpHandler.NewEventsHandler(
 		listEventsUseCase,
@@ -59,7 +148,36 @@ func main() {
 		buyTicketsUseCase,
 		createEventUseCase,
 		createSpotsUseCase,
+		holdSpotsUseCase,
+		updateEventUseCase,
+		deleteEventUseCase,
+		deleteSpotUseCase,
+	)
+	eventLifecycleHandler := httpHandler.NewEventLifecycleHandler(
+		publishEventUseCase,
+		openEventSalesUseCase,
+		postponeEventUseCase,
+		cancelEventUseCase,
 	)
+	venuesHandler := httpHandler.NewVenuesHandler(createVenueUseCase, getVenueUseCase, listVenuesUseCase)
+	couponsHandler := httpHandler.NewCouponsHandler(createCouponUseCase, getCouponUseCase)
+	partnersHandler := httpHandler.NewPartnersHandler(listPartnerBreakersUseCase)
+
+	// Autenticação das rotas administrativas e de compra
+	var authenticator httpHandler.Authenticator
+	if cfg.Auth.Disabled {
+		log.Println("Autenticação desativada (auth.disabled): rotas protegidas estão abertas")
+	} else {
+		authn, err := auth.NewAuthenticator(cfg.Auth)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if !cfg.Auth.HasCredentials() {
+			log.Println("Nenhuma chave JWT ou de API configurada: rotas protegidas recusarão todas as requisições")
+		}
+		authenticator = authn
+	}
+	authMiddleware := httpHandler.NewAuthMiddleware(authenticator)
 
 	r := http.NewServeMux()
 	r.HandleFunc("/swagger/", httpSwagger.WrapHandler)
@@ -67,12 +185,26 @@ func main() {
 	r.HandleFunc("/events", eventsHandler.ListEvents)
 	r.HandleFunc("/events/{eventID}", eventsHandler.GetEvent)
 	r.HandleFunc("/events/{eventID}/spots", eventsHandler.ListSpots)
-	r.HandleFunc("POST /event", eventsHandler.CreateEvent)
-	r.HandleFunc("POST /checkout", eventsHandler.BuyTickets)
-	r.HandleFunc("POST /events/{eventID}/spots", eventsHandler.CreateSpots)
+	r.HandleFunc("POST /event", authMiddleware.Require(eventsHandler.CreateEvent, domain.RoleOrganizer, domain.RolePartnerAdmin))
+	r.HandleFunc("POST /checkout", authMiddleware.Require(eventsHandler.BuyTickets, domain.RoleCustomer))
+	r.HandleFunc("POST /events/{eventID}/spots", authMiddleware.Require(eventsHandler.CreateSpots, domain.RoleOrganizer, domain.RolePartnerAdmin))
+	r.HandleFunc("POST /events/{eventID}/holds", authMiddleware.Require(eventsHandler.HoldSpots, domain.RoleCustomer))
+	r.HandleFunc("PATCH /events/{eventID}", authMiddleware.Require(eventsHandler.UpdateEvent, domain.RoleOrganizer, domain.RolePartnerAdmin))
+	r.HandleFunc("DELETE /events/{eventID}", authMiddleware.Require(eventsHandler.DeleteEvent, domain.RoleOrganizer, domain.RolePartnerAdmin))
+	r.HandleFunc("DELETE /events/{eventID}/spots/{spotID}", authMiddleware.Require(eventsHandler.DeleteSpot, domain.RoleOrganizer, domain.RolePartnerAdmin))
+	r.HandleFunc("POST /events/{eventID}/publish", authMiddleware.Require(eventLifecycleHandler.PublishEvent, domain.RoleOrganizer, domain.RolePartnerAdmin))
+	r.HandleFunc("POST /events/{eventID}/open-sales", authMiddleware.Require(eventLifecycleHandler.OpenEventSales, domain.RoleOrganizer, domain.RolePartnerAdmin))
+	r.HandleFunc("POST /events/{eventID}/postpone", authMiddleware.Require(eventLifecycleHandler.PostponeEvent, domain.RoleOrganizer, domain.RolePartnerAdmin))
+	r.HandleFunc("POST /events/{eventID}/cancel", authMiddleware.Require(eventLifecycleHandler.CancelEvent, domain.RoleOrganizer, domain.RolePartnerAdmin))
+	r.HandleFunc("GET /venues", venuesHandler.ListVenues)
+	r.HandleFunc("GET /venues/{venueID}", venuesHandler.GetVenue)
+	r.HandleFunc("POST /venues", authMiddleware.Require(venuesHandler.CreateVenue, domain.RoleOrganizer, domain.RolePartnerAdmin))
+	r.HandleFunc("POST /coupons", authMiddleware.Require(couponsHandler.CreateCoupon, domain.RoleOrganizer, domain.RolePartnerAdmin))
+	r.HandleFunc("GET /coupons/{code}", authMiddleware.Require(couponsHandler.GetCoupon, domain.RoleOrganizer, domain.RolePartnerAdmin))
+	r.HandleFunc("GET /partners/breakers", authMiddleware.Require(partnersHandler.ListBreakers, domain.RolePartnerAdmin))
 
 	server := &http.Server{
-		Addr:    ":8080",
+		Addr:    cfg.HTTP.Addr,
 		Handler: r,
 	}
 
@@ -85,8 +217,9 @@ func main() {
 
 		// Recebido sinal de interrupção, iniciando o graceful shutdown
 		log.Println("Recebido sinal de interrupção, iniciando o graceful shutdown...")
+		stopWorkers()
 
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		ctx, cancel := context.WithTimeout(context.Background(), cfg.HTTP.ShutdownTimeout)
 		defer cancel()
 
 		if err := server.Shutdown(ctx); err != nil {
@@ -96,7 +229,7 @@ func main() {
 	}()
 
 	// Iniciando o servidor HTTP
-	log.Println("Servidor HTTP rodando na porta 8080")
+	log.Printf("Servidor HTTP rodando em %s\n", cfg.HTTP.Addr)
 	if err := server.ListenAndServe(); err != http.ErrServerClosed {
 		log.Fatalf("Erro ao iniciar o servidor HTTP: %v\n", err)
 	}
@@ -104,3 +237,18 @@ func main() {
 	<-idleConnsClosed
 	log.Println("Servidor HTTP finalizado")
 }
+
+// runPeriodically executa fn imediatamente e depois a cada interval, até que ctx seja cancelado.
+func runPeriodically(ctx context.Context, interval time.Duration, fn func()) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		fn()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
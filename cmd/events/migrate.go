@@ -0,0 +1,121 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/config"
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/infra/migration"
+)
+
+const migrateUsage = `uso: events migrate <comando>
+
+comandos:
+  up                 aplica as migrações pendentes
+  down [n]           desfaz as últimas n migrações aplicadas (padrão 1)
+  status             lista as migrações e se foram aplicadas
+  baseline <versão>  registra como aplicadas, sem executá-las, as migrações até a versão
+  seed               insere os dados de exemplo`
+
+// runMigrate implementa o subcomando "migrate".
+func runMigrate(ctx context.Context, cfg config.Config, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+	if cfg.Database.Driver != config.DatabaseDriverMySQL {
+		return fmt.Errorf("migrate: requires the %q database driver", config.DatabaseDriverMySQL)
+	}
+
+	db, err := sql.Open("mysql", cfg.Database.DSN)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	migrator, err := migration.NewMigrator(db)
+	if err != nil {
+		return err
+	}
+
+	switch command := args[0]; {
+	case command == "up" && len(args) == 1:
+		applied, err := migrator.Up(ctx)
+		log.Printf("Migrações aplicadas: %d\n", applied)
+		return err
+	case command == "down" && len(args) <= 2:
+		steps := 1
+		if len(args) == 2 {
+			if steps, err = strconv.Atoi(args[1]); err != nil || steps <= 0 {
+				return fmt.Errorf("migrate down: n must be a positive integer, got %q", args[1])
+			}
+		}
+		reverted, err := migrator.Down(ctx, steps)
+		log.Printf("Migrações desfeitas: %d\n", reverted)
+		return err
+	case command == "status" && len(args) == 1:
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		printMigrationStatus(statuses)
+		return nil
+	case command == "baseline" && len(args) == 2:
+		version, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil || version <= 0 {
+			return fmt.Errorf("migrate baseline: version must be a positive integer, got %q", args[1])
+		}
+		recorded, err := migrator.Baseline(ctx, version)
+		log.Printf("Migrações registradas sem execução: %d\n", recorded)
+		return err
+	case command == "seed" && len(args) == 1:
+		return migrator.Seed(ctx)
+	default:
+		return errors.New(migrateUsage)
+	}
+}
+
+func printMigrationStatus(statuses []migration.Status) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSÃO\tNOME\tAPLICADA EM\tOBSERVAÇÃO")
+	for _, status := range statuses {
+		appliedAt, note := "pendente", ""
+		if status.AppliedAt != nil {
+			appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+		}
+		switch {
+		case status.Unknown:
+			note = "desconhecida por este binário"
+		case status.Modified:
+			note = "alterada após aplicada"
+		}
+		fmt.Fprintf(w, "%04d\t%s\t%s\t%s\n", status.Version, status.Name, appliedAt, note)
+	}
+	w.Flush()
+}
+
+// migrateOnStartup aplica as migrações pendentes e, se configurado, os dados de exemplo.
+func migrateOnStartup(ctx context.Context, db *sql.DB, cfg config.DatabaseConfig) error {
+	if !cfg.AutoMigrate && !cfg.Seed {
+		return nil
+	}
+
+	migrator, err := migration.NewMigrator(db)
+	if err != nil {
+		return err
+	}
+	if cfg.AutoMigrate {
+		if _, err := migrator.Up(ctx); err != nil {
+			return err
+		}
+	}
+	if cfg.Seed {
+		return migrator.Seed(ctx)
+	}
+	return nil
+}
@@ -0,0 +1,341 @@
+// Package config carrega a configuração da aplicação a partir de valores padrão,
+// de um arquivo opcional (YAML ou JSON) e de variáveis de ambiente, nesta ordem de precedência.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/infra/auth"
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/infra/service"
+)
+
+// Variáveis de ambiente reconhecidas.
+const (
+	EnvConfigFile      = "EVENTS_CONFIG_FILE"
+	EnvHTTPAddr        = "EVENTS_HTTP_ADDR"
+	EnvShutdownTimeout = "EVENTS_SHUTDOWN_TIMEOUT"
+	EnvDatabaseDriver  = "EVENTS_DATABASE_DRIVER"
+	EnvDatabaseDSN     = "EVENTS_DATABASE_DSN"
+	EnvAutoMigrate     = "EVENTS_DATABASE_AUTO_MIGRATE"
+	EnvDatabaseSeed    = "EVENTS_DATABASE_SEED"
+	EnvAuthDisabled    = "EVENTS_AUTH_DISABLED"
+	EnvAuthJWTSecret   = "EVENTS_AUTH_JWT_SECRET" // Segredo HS256 adicionado às chaves JWT configuradas.
+
+	EnvCompensationInterval   = "EVENTS_WORKERS_COMPENSATION_INTERVAL"
+	EnvCompensationStaleAfter = "EVENTS_WORKERS_COMPENSATION_STALE_AFTER"
+	EnvHoldReleaseInterval    = "EVENTS_WORKERS_HOLD_RELEASE_INTERVAL"
+	EnvIdempotencyPurge       = "EVENTS_WORKERS_IDEMPOTENCY_PURGE_INTERVAL"
+
+	// EVENTS_PARTNER_<ID>_BASE_URL, EVENTS_PARTNER_<ID>_KIND e EVENTS_PARTNER_<ID>_TIMEOUT
+	// definem (ou sobrescrevem) a URL base, o tipo de adaptador e o prazo das chamadas do parceiro <ID>.
+	envPartnerPrefix  = "EVENTS_PARTNER_"
+	envPartnerBaseURL = "_BASE_URL"
+	envPartnerKind    = "_KIND"
+	envPartnerTimeout = "_TIMEOUT"
+)
+
+const (
+	DatabaseDriverMySQL  = "mysql"
+	DatabaseDriverMemory = "memory"
+)
+
+type Config struct {
+	HTTP     HTTPConfig              `yaml:"http"`
+	Database DatabaseConfig          `yaml:"database"`
+	Partners []service.PartnerConfig `yaml:"partners"`
+	Auth     auth.Config             `yaml:"auth"`
+	Workers  WorkersConfig           `yaml:"workers"`
+}
+
+type HTTPConfig struct {
+	Addr            string        `yaml:"addr"`             // Endereço de escuta do servidor. Ex: ":8080".
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"` // Tempo máximo do graceful shutdown. Ex: "5s".
+}
+
+type DatabaseConfig struct {
+	Driver      string `yaml:"driver"`       // "mysql" ou "memory".
+	DSN         string `yaml:"dsn"`          // Obrigatório quando Driver é "mysql".
+	AutoMigrate bool   `yaml:"auto_migrate"` // Aplica as migrações pendentes na inicialização (mysql).
+	Seed        bool   `yaml:"seed"`         // Insere os dados de exemplo na inicialização (mysql).
+}
+
+// WorkersConfig define a frequência dos processos executados em segundo plano.
+type WorkersConfig struct {
+	CompensationInterval     time.Duration `yaml:"compensation_interval"`      // Intervalo entre as compensações de checkouts. Ex: "30s".
+	CompensationStaleAfter   time.Duration `yaml:"compensation_stale_after"`   // Idade a partir da qual uma saga em andamento é considerada abandonada. Ex: "5m".
+	HoldReleaseInterval      time.Duration `yaml:"hold_release_interval"`      // Intervalo entre as liberações de retenções expiradas. Ex: "30s".
+	IdempotencyPurgeInterval time.Duration `yaml:"idempotency_purge_interval"` // Intervalo entre as remoções de chaves de idempotência expiradas. Ex: "10m".
+}
+
+// Default retorna a configuração usada pelo ambiente docker-compose do projeto.
+func Default() Config {
+	return Config{
+		HTTP: HTTPConfig{
+			Addr:            ":8080",
+			ShutdownTimeout: 5 * time.Second,
+		},
+		Database: DatabaseConfig{
+			Driver:      DatabaseDriverMySQL,
+			DSN:         "test_user:test_password@tcp(golang-mysql:3306)/test_db",
+			AutoMigrate: true,
+		},
+		// Apontamento para Gateway API - KONG
+		Partners: []service.PartnerConfig{
+			{ID: 1, Kind: "partner1", BaseURL: "http://host.docker.internal:8000/partner1"},
+			{ID: 2, Kind: "partner2", BaseURL: "http://host.docker.internal:8000/partner2"},
+		},
+		Workers: WorkersConfig{
+			CompensationInterval:     30 * time.Second,
+			CompensationStaleAfter:   5 * time.Minute,
+			HoldReleaseInterval:      30 * time.Second,
+			IdempotencyPurgeInterval: 10 * time.Minute,
+		},
+	}
+}
+
+// Load monta a configuração: parte dos valores padrão, aplica o arquivo indicado em
+// EVENTS_CONFIG_FILE (se houver), aplica as variáveis de ambiente e valida o resultado.
+func Load() (Config, error) {
+	cfg := Default()
+
+	if path := os.Getenv(EnvConfigFile); path != "" {
+		if err := cfg.loadFile(path); err != nil {
+			return Config{}, err
+		}
+	}
+
+	if err := cfg.loadEnv(os.Environ()); err != nil {
+		return Config{}, err
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
+
+// loadFile aplica um arquivo YAML ou JSON sobre a configuração atual.
+// JSON é um subconjunto de YAML, então o mesmo decodificador atende os dois formatos.
+// Campos desconhecidos são rejeitados para evitar erros de digitação silenciosos.
+func (c *Config) loadFile(path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("config: reading %s: %w", path, err)
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("config: parsing %s: %w", path, err)
+	}
+	return nil
+}
+
+// loadEnv aplica as variáveis de ambiente (no formato "CHAVE=valor") sobre a configuração atual.
+func (c *Config) loadEnv(environ []string) error {
+	env := make(map[string]string, len(environ))
+	for _, kv := range environ {
+		if key, value, ok := strings.Cut(kv, "="); ok {
+			env[key] = value
+		}
+	}
+
+	if v, ok := env[EnvHTTPAddr]; ok {
+		c.HTTP.Addr = v
+	}
+	durations := []struct {
+		key    string
+		target *time.Duration
+	}{
+		{EnvShutdownTimeout, &c.HTTP.ShutdownTimeout},
+		{EnvCompensationInterval, &c.Workers.CompensationInterval},
+		{EnvCompensationStaleAfter, &c.Workers.CompensationStaleAfter},
+		{EnvHoldReleaseInterval, &c.Workers.HoldReleaseInterval},
+		{EnvIdempotencyPurge, &c.Workers.IdempotencyPurgeInterval},
+	}
+	for _, d := range durations {
+		if v, ok := env[d.key]; ok {
+			parsed, err := time.ParseDuration(v)
+			if err != nil {
+				return fmt.Errorf("config: %s: %w", d.key, err)
+			}
+			*d.target = parsed
+		}
+	}
+	if v, ok := env[EnvDatabaseDriver]; ok {
+		c.Database.Driver = v
+	}
+	if v, ok := env[EnvDatabaseDSN]; ok {
+		c.Database.DSN = v
+	}
+	if v, ok := env[EnvAutoMigrate]; ok {
+		autoMigrate, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("config: %s: %w", EnvAutoMigrate, err)
+		}
+		c.Database.AutoMigrate = autoMigrate
+	}
+	if v, ok := env[EnvDatabaseSeed]; ok {
+		seed, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("config: %s: %w", EnvDatabaseSeed, err)
+		}
+		c.Database.Seed = seed
+	}
+	if v, ok := env[EnvAuthDisabled]; ok {
+		disabled, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("config: %s: %w", EnvAuthDisabled, err)
+		}
+		c.Auth.Disabled = disabled
+	}
+	if v, ok := env[EnvAuthJWTSecret]; ok && v != "" {
+		c.Auth.JWT.Keys = append(c.Auth.JWT.Keys, auth.JWTKeyConfig{Algorithm: auth.AlgorithmHS256, Secret: v})
+	}
+
+	// Ordena as chaves para que a ordem dos parceiros adicionados seja determinística.
+	keys := make([]string, 0, len(env))
+	for key := range env {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		if !strings.HasPrefix(key, envPartnerPrefix) {
+			continue
+		}
+		rest := strings.TrimPrefix(key, envPartnerPrefix)
+
+		var suffix string
+		switch {
+		case strings.HasSuffix(rest, envPartnerBaseURL):
+			suffix = envPartnerBaseURL
+		case strings.HasSuffix(rest, envPartnerKind):
+			suffix = envPartnerKind
+		case strings.HasSuffix(rest, envPartnerTimeout):
+			suffix = envPartnerTimeout
+		default:
+			continue
+		}
+
+		rawID := strings.TrimSuffix(rest, suffix)
+		id, err := strconv.Atoi(rawID)
+		if err != nil {
+			return fmt.Errorf("config: %s: invalid partner id %q", key, rawID)
+		}
+
+		partner := c.partner(id)
+		switch suffix {
+		case envPartnerBaseURL:
+			partner.BaseURL = env[key]
+		case envPartnerKind:
+			partner.Kind = env[key]
+		case envPartnerTimeout:
+			d, err := time.ParseDuration(env[key])
+			if err != nil {
+				return fmt.Errorf("config: %s: %w", key, err)
+			}
+			partner.Timeout = d
+		}
+	}
+	return nil
+}
+
+// partner retorna a configuração do parceiro id, criando uma entrada se ela não existir.
+func (c *Config) partner(id int) *service.PartnerConfig {
+	for i := range c.Partners {
+		if c.Partners[i].ID == id {
+			return &c.Partners[i]
+		}
+	}
+	c.Partners = append(c.Partners, service.PartnerConfig{ID: id})
+	return &c.Partners[len(c.Partners)-1]
+}
+
+// Validate verifica a configuração e reporta todos os problemas encontrados de uma vez.
+func (c Config) Validate() error {
+	var errs []error
+
+	if c.HTTP.Addr == "" {
+		errs = append(errs, errors.New("http.addr is required"))
+	}
+	if c.HTTP.ShutdownTimeout <= 0 {
+		errs = append(errs, errors.New("http.shutdown_timeout must be greater than zero"))
+	}
+
+	switch c.Database.Driver {
+	case DatabaseDriverMySQL:
+		if c.Database.DSN == "" {
+			errs = append(errs, errors.New("database.dsn is required for the mysql driver"))
+		}
+	case DatabaseDriverMemory:
+	default:
+		errs = append(errs, fmt.Errorf("database.driver must be %q or %q, got %q", DatabaseDriverMySQL, DatabaseDriverMemory, c.Database.Driver))
+	}
+
+	seen := make(map[int]bool, len(c.Partners))
+	for _, p := range c.Partners {
+		if p.ID <= 0 {
+			errs = append(errs, fmt.Errorf("partners: id must be greater than zero, got %d", p.ID))
+			continue
+		}
+		if seen[p.ID] {
+			errs = append(errs, fmt.Errorf("partners[%d]: duplicated id", p.ID))
+		}
+		seen[p.ID] = true
+
+		if p.Kind == "" {
+			errs = append(errs, fmt.Errorf("partners[%d].kind is required", p.ID))
+		}
+
+		u, err := url.Parse(p.BaseURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("partners[%d].base_url must be an absolute http(s) URL, got %q", p.ID, p.BaseURL))
+		}
+
+		if p.Timeout < 0 {
+			errs = append(errs, fmt.Errorf("partners[%d].timeout must not be negative, got %s", p.ID, p.Timeout))
+		}
+		if p.Retry.MaxAttempts < 0 || p.Retry.BaseDelay < 0 || p.Retry.MaxDelay < 0 {
+			errs = append(errs, fmt.Errorf("partners[%d].retry values must not be negative", p.ID))
+		}
+		if p.CircuitBreaker.FailureThreshold < 0 || p.CircuitBreaker.OpenTimeout < 0 {
+			errs = append(errs, fmt.Errorf("partners[%d].circuit_breaker values must not be negative", p.ID))
+		}
+	}
+
+	workers := []struct {
+		name  string
+		value time.Duration
+	}{
+		{"workers.compensation_interval", c.Workers.CompensationInterval},
+		{"workers.compensation_stale_after", c.Workers.CompensationStaleAfter},
+		{"workers.hold_release_interval", c.Workers.HoldReleaseInterval},
+		{"workers.idempotency_purge_interval", c.Workers.IdempotencyPurgeInterval},
+	}
+	for _, w := range workers {
+		if w.value <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be greater than zero", w.name))
+		}
+	}
+
+	if err := c.Auth.Validate(); err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("config: %w", errors.Join(errs...))
+	}
+	return nil
+}
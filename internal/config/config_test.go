@@ -0,0 +1,246 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/infra/auth"
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/infra/service"
+)
+
+// clearEnv remove as variáveis EVENTS_* do ambiente do teste, para que Load parta apenas dos padrões.
+func clearEnv(t *testing.T) {
+	t.Helper()
+	for _, kv := range os.Environ() {
+		key, _, _ := strings.Cut(kv, "=")
+		if strings.HasPrefix(key, "EVENTS_") {
+			t.Setenv(key, "")
+			os.Unsetenv(key)
+		}
+	}
+}
+
+func TestLoadDefaults(t *testing.T) {
+	clearEnv(t)
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(cfg, Default()) {
+		t.Errorf("Load() = %+v, want the defaults %+v", cfg, Default())
+	}
+}
+
+func TestLoadEnv(t *testing.T) {
+	cfg := Default()
+	err := cfg.loadEnv([]string{
+		EnvHTTPAddr + "=:9090",
+		EnvShutdownTimeout + "=15s",
+		EnvDatabaseDriver + "=memory",
+		EnvDatabaseDSN + "=user:pass@tcp(db:3306)/events",
+		EnvAutoMigrate + "=false",
+		EnvDatabaseSeed + "=true",
+		EnvAuthDisabled + "=1",
+		EnvAuthJWTSecret + "=segredo",
+		EnvCompensationInterval + "=1m",
+		EnvCompensationStaleAfter + "=15m",
+		EnvHoldReleaseInterval + "=10s",
+		EnvIdempotencyPurge + "=1h",
+		"EVENTS_PARTNER_1_BASE_URL=https://partner1.example.com",
+		"EVENTS_PARTNER_3_KIND=http",
+		"EVENTS_PARTNER_3_BASE_URL=https://partner3.example.com",
+		"EVENTS_PARTNER_3_TIMEOUT=2s",
+		"EVENTS_PARTNER_NOTES=ignorada, sem sufixo reconhecido",
+		"PATH=/usr/bin",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := Default()
+	want.HTTP = HTTPConfig{Addr: ":9090", ShutdownTimeout: 15 * time.Second}
+	want.Database = DatabaseConfig{Driver: DatabaseDriverMemory, DSN: "user:pass@tcp(db:3306)/events", AutoMigrate: false, Seed: true}
+	want.Auth.Disabled = true
+	want.Auth.JWT.Keys = []auth.JWTKeyConfig{{Algorithm: auth.AlgorithmHS256, Secret: "segredo"}}
+	want.Workers = WorkersConfig{CompensationInterval: time.Minute, CompensationStaleAfter: 15 * time.Minute, HoldReleaseInterval: 10 * time.Second, IdempotencyPurgeInterval: time.Hour}
+	want.Partners[0].BaseURL = "https://partner1.example.com"
+	want.Partners = append(want.Partners, service.PartnerConfig{ID: 3, Kind: "http", BaseURL: "https://partner3.example.com", Timeout: 2 * time.Second})
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("config = %+v, want %+v", cfg, want)
+	}
+}
+
+func TestLoadEnvRejectsInvalidValues(t *testing.T) {
+	tests := []struct {
+		env  string
+		want string // trecho esperado na mensagem de erro
+	}{
+		{EnvShutdownTimeout + "=5", EnvShutdownTimeout},
+		{EnvShutdownTimeout + "=soon", EnvShutdownTimeout},
+		{EnvAutoMigrate + "=yes", EnvAutoMigrate},
+		{EnvDatabaseSeed + "=2", EnvDatabaseSeed},
+		{EnvAuthDisabled + "=off-ish", EnvAuthDisabled},
+		{"EVENTS_PARTNER_ONE_BASE_URL=http://partner", `invalid partner id "ONE"`},
+		{"EVENTS_PARTNER_1_TIMEOUT=10", "EVENTS_PARTNER_1_TIMEOUT"},
+		{EnvCompensationInterval + "=30", EnvCompensationInterval},
+		{EnvCompensationStaleAfter + "=later", EnvCompensationStaleAfter},
+		{EnvHoldReleaseInterval + "=1x", EnvHoldReleaseInterval},
+		{EnvIdempotencyPurge + "=", EnvIdempotencyPurge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.env, func(t *testing.T) {
+			cfg := Default()
+			err := cfg.loadEnv([]string{tt.env})
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("loadEnv error = %v, want one mentioning %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(cfg *Config)
+		want   []string // trechos esperados na mensagem de erro; vazio para uma configuração válida
+	}{
+		{name: "defaults", change: func(cfg *Config) {}},
+		{name: "memory driver without dsn", change: func(cfg *Config) { cfg.Database = DatabaseConfig{Driver: DatabaseDriverMemory} }},
+		{name: "missing http addr", change: func(cfg *Config) { cfg.HTTP.Addr = "" }, want: []string{"http.addr is required"}},
+		{name: "zero shutdown timeout", change: func(cfg *Config) { cfg.HTTP.ShutdownTimeout = 0 }, want: []string{"http.shutdown_timeout"}},
+		{name: "mysql without dsn", change: func(cfg *Config) { cfg.Database.DSN = "" }, want: []string{"database.dsn is required"}},
+		{name: "unknown driver", change: func(cfg *Config) { cfg.Database.Driver = "postgres" }, want: []string{`database.driver must be "mysql" or "memory", got "postgres"`}},
+		{name: "partner without kind and url", change: func(cfg *Config) {
+			cfg.Partners = append(cfg.Partners, service.PartnerConfig{ID: 3})
+		}, want: []string{"partners[3].kind is required", "partners[3].base_url"}},
+		{name: "relative partner url", change: func(cfg *Config) { cfg.Partners[0].BaseURL = "/partner1" }, want: []string{"partners[1].base_url"}},
+		{name: "duplicated partner", change: func(cfg *Config) { cfg.Partners[1].ID = 1 }, want: []string{"partners[1]: duplicated id"}},
+		{name: "partner without id", change: func(cfg *Config) { cfg.Partners[0].ID = 0 }, want: []string{"id must be greater than zero"}},
+		{name: "negative partner settings", change: func(cfg *Config) {
+			cfg.Partners[0].Timeout = -time.Second
+			cfg.Partners[0].Retry.MaxAttempts = -1
+			cfg.Partners[0].CircuitBreaker.OpenTimeout = -time.Second
+		}, want: []string{"partners[1].timeout", "partners[1].retry", "partners[1].circuit_breaker"}},
+		{name: "zero worker intervals", change: func(cfg *Config) {
+			cfg.Workers = WorkersConfig{HoldReleaseInterval: -time.Second}
+		}, want: []string{"workers.compensation_interval", "workers.compensation_stale_after", "workers.hold_release_interval", "workers.idempotency_purge_interval"}},
+		{name: "invalid auth", change: func(cfg *Config) {
+			cfg.Auth.JWT.Keys = []auth.JWTKeyConfig{{Algorithm: "none"}}
+		}, want: []string{"auth.jwt.keys[0].algorithm"}},
+		{name: "reports every problem", change: func(cfg *Config) {
+			cfg.HTTP.Addr = ""
+			cfg.Database.DSN = ""
+		}, want: []string{"http.addr is required", "database.dsn is required"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := Default()
+			tt.change(&cfg)
+
+			err := cfg.Validate()
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Errorf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("Validate() = nil, want errors mentioning %q", tt.want)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("Validate() = %v, want it to mention %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadFileWithEnvPrecedence(t *testing.T) {
+	clearEnv(t)
+	path := filepath.Join(t.TempDir(), "events.yaml")
+	content := `
+http:
+  addr: ":7070"
+database:
+  driver: memory
+workers:
+  compensation_stale_after: 2m
+partners:
+  - id: 1
+    kind: partner1
+    base_url: "https://partner1.example.com"
+    timeout: 3s
+    retry:
+      max_attempts: 5
+`
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(EnvConfigFile, path)
+	t.Setenv(EnvHTTPAddr, ":6060")
+
+	cfg, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.HTTP.Addr != ":6060" {
+		t.Errorf("http.addr = %q, want the environment value :6060", cfg.HTTP.Addr)
+	}
+	if cfg.HTTP.ShutdownTimeout != Default().HTTP.ShutdownTimeout {
+		t.Errorf("http.shutdown_timeout = %s, want the default", cfg.HTTP.ShutdownTimeout)
+	}
+	if cfg.Database.Driver != DatabaseDriverMemory {
+		t.Errorf("database.driver = %q, want memory", cfg.Database.Driver)
+	}
+	wantWorkers := Default().Workers
+	wantWorkers.CompensationStaleAfter = 2 * time.Minute
+	if cfg.Workers != wantWorkers {
+		t.Errorf("workers = %+v, want the defaults with the file's stale_after %+v", cfg.Workers, wantWorkers)
+	}
+	// A lista do arquivo substitui a lista padrão de parceiros.
+	want := []service.PartnerConfig{{ID: 1, Kind: "partner1", BaseURL: "https://partner1.example.com", Timeout: 3 * time.Second, Retry: service.RetryPolicy{MaxAttempts: 5}}}
+	if !reflect.DeepEqual(cfg.Partners, want) {
+		t.Errorf("partners = %+v, want %+v", cfg.Partners, want)
+	}
+}
+
+func TestLoadFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "unknown field", content: "http:\n  adress: \":8080\"\n", want: "adress"},
+		{name: "invalid duration", content: "http:\n  shutdown_timeout: soon\n", want: "parsing"},
+		{name: "invalid number", content: "partners:\n  - id: one\n", want: "parsing"},
+		{name: "fails validation", content: "database:\n  driver: postgres\n", want: "database.driver"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clearEnv(t)
+			path := filepath.Join(t.TempDir(), "events.yaml")
+			if err := os.WriteFile(path, []byte(tt.content), 0o600); err != nil {
+				t.Fatal(err)
+			}
+			t.Setenv(EnvConfigFile, path)
+
+			if _, err := Load(); err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("Load() error = %v, want one mentioning %q", err, tt.want)
+			}
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		clearEnv(t)
+		t.Setenv(EnvConfigFile, filepath.Join(t.TempDir(), "missing.yaml"))
+		if _, err := Load(); err == nil || !strings.Contains(err.Error(), "reading") {
+			t.Errorf("Load() error = %v, want a reading error", err)
+		}
+	})
+}
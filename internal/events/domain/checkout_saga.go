@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrCheckoutSagaAbandoned = errors.New("checkout saga abandoned before completion")
+
+type CheckoutSagaStatus string
+
+const (
+	CheckoutSagaStarted      CheckoutSagaStatus = "started"      // reserva ainda não confirmada pelo parceiro
+	CheckoutSagaReserved     CheckoutSagaStatus = "reserved"     // parceiro confirmou, falta persistir localmente
+	CheckoutSagaCompleted    CheckoutSagaStatus = "completed"    // ingressos persistidos, nada a compensar
+	CheckoutSagaFailed       CheckoutSagaStatus = "failed"       // parceiro recusou a reserva, nada a compensar
+	CheckoutSagaCompensating CheckoutSagaStatus = "compensating" // cancelamento no parceiro pendente
+	CheckoutSagaCompensated  CheckoutSagaStatus = "compensated"  // reserva cancelada no parceiro
+	// cancelamento desistido após MaxCompensationAttempts tentativas; exige intervenção manual
+	CheckoutSagaCompensationFailed CheckoutSagaStatus = "compensation_failed"
+)
+
+// MaxCompensationAttempts limita as tentativas de cancelar a reserva no parceiro, para que uma
+// saga que nunca poderá ser compensada (por exemplo, de um parceiro removido) não seja repetida para sempre.
+const MaxCompensationAttempts = 10
+
+// Espera entre as tentativas de compensação: dobra a cada falha, de compensationBaseDelay até compensationMaxDelay.
+const (
+	compensationBaseDelay = 30 * time.Second
+	compensationMaxDelay  = time.Hour
+)
+
+// CheckoutSaga registra o andamento de uma compra que envolve o parceiro e o banco local,
+// permitindo desfazer a reserva no parceiro quando a persistência local falha.
+type CheckoutSaga struct {
+	ID             string
+	EventID        string
+	PartnerID      int
+	Spots          []string
+	TicketKind     string
+	Email          string
+	ReservationIDs []string
+	Status         CheckoutSagaStatus
+	Attempts       int
+	LastError      string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func NewCheckoutSaga(event *Event, spots []string, ticketKind, email string) *CheckoutSaga {
+	now := time.Now()
+	return &CheckoutSaga{
+		ID:             uuid.New().String(),
+		EventID:        event.ID,
+		PartnerID:      event.PartnerID,
+		Spots:          spots,
+		TicketKind:     ticketKind,
+		Email:          email,
+		ReservationIDs: []string{},
+		Status:         CheckoutSagaStarted,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+// MarkReserved registra as reservas confirmadas pelo parceiro.
+func (s *CheckoutSaga) MarkReserved(reservationIDs []string) {
+	s.ReservationIDs = reservationIDs
+	s.setStatus(CheckoutSagaReserved)
+}
+
+// MarkCompleted indica que os ingressos foram persistidos localmente.
+func (s *CheckoutSaga) MarkCompleted() {
+	s.setStatus(CheckoutSagaCompleted)
+}
+
+// MarkFailed indica que o parceiro recusou a reserva.
+func (s *CheckoutSaga) MarkFailed(err error) {
+	s.LastError = err.Error()
+	s.setStatus(CheckoutSagaFailed)
+}
+
+// StartCompensation indica que a reserva no parceiro precisa ser cancelada por causa de err.
+func (s *CheckoutSaga) StartCompensation(err error) {
+	s.LastError = err.Error()
+	s.setStatus(CheckoutSagaCompensating)
+}
+
+// CompensationFailed registra uma tentativa de cancelamento sem sucesso. A saga continua pendente
+// até atingir MaxCompensationAttempts, quando passa para CheckoutSagaCompensationFailed.
+func (s *CheckoutSaga) CompensationFailed(err error) {
+	s.Attempts++
+	s.LastError = err.Error()
+	if s.Attempts >= MaxCompensationAttempts {
+		s.setStatus(CheckoutSagaCompensationFailed)
+		return
+	}
+	s.setStatus(CheckoutSagaCompensating)
+}
+
+// NextCompensationAt retorna quando a próxima tentativa de cancelamento pode ser feita:
+// imediatamente na primeira e, depois de cada falha, com uma espera exponencial.
+func (s *CheckoutSaga) NextCompensationAt() time.Time {
+	if s.Attempts == 0 {
+		return s.UpdatedAt
+	}
+	delay := compensationBaseDelay
+	for i := 1; i < s.Attempts && delay < compensationMaxDelay; i++ {
+		delay *= 2
+	}
+	return s.UpdatedAt.Add(min(delay, compensationMaxDelay))
+}
+
+// MarkCompensated indica que a reserva foi cancelada no parceiro.
+func (s *CheckoutSaga) MarkCompensated() {
+	s.Attempts++
+	s.setStatus(CheckoutSagaCompensated)
+}
+
+func (s *CheckoutSaga) setStatus(status CheckoutSagaStatus) {
+	s.Status = status
+	s.UpdatedAt = time.Now()
+}
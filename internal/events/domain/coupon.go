@@ -0,0 +1,215 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CouponDiscountType indica como o cupom calcula o desconto de cada ingresso.
+type CouponDiscountType string
+
+const (
+	CouponDiscountPercentage CouponDiscountType = "percentage" // Percent pontos-base sobre o preço do ingresso
+	CouponDiscountFixed      CouponDiscountType = "fixed"      // Amount por ingresso, limitado ao preço
+)
+
+// IsValid indica se o tipo é uma das constantes CouponDiscount*.
+func (t CouponDiscountType) IsValid() bool {
+	return t == CouponDiscountPercentage || t == CouponDiscountFixed
+}
+
+// maxCouponCodeLength é o tamanho da coluna coupons.code.
+const maxCouponCodeLength = 32
+
+var couponCodePattern = regexp.MustCompile(`^[A-Z0-9][A-Z0-9_-]*$`)
+
+var (
+	ErrInvalidCoupon       = NewValidationError("invalid_coupon", "invalid coupon")
+	ErrCouponNotFound      = NewNotFoundError("coupon_not_found", "coupon not found")
+	ErrCouponCodeTaken     = NewConflictError("coupon_code_taken", "coupon code is already in use")
+	ErrCouponNotActive     = NewValidationError("coupon_not_active", "coupon is not within its validity window")
+	ErrCouponNotApplicable = NewValidationError("coupon_not_applicable", "coupon does not apply to this purchase")
+	ErrCouponNotStackable  = NewValidationError("coupon_not_stackable", "coupon cannot be combined with the other discounts of this purchase")
+	ErrCouponExhausted     = NewConflictError("coupon_exhausted", "coupon has reached its redemption limit")
+	ErrCouponEmailLimit    = NewConflictError("coupon_email_limit_reached", "coupon has reached its redemption limit for this email")
+)
+
+// NormalizeCouponCode padroniza o código informado pelo comprador: os códigos não diferenciam maiúsculas.
+func NormalizeCouponCode(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// IsValidCouponCode indica se o código (já normalizado) tem o formato aceito: letras maiúsculas,
+// dígitos, "_" e "-", começando por letra ou dígito, com 3 a 32 caracteres.
+func IsValidCouponCode(code string) bool {
+	return len(code) >= 3 && len(code) <= maxCouponCodeLength && couponCodePattern.MatchString(code)
+}
+
+// Coupon é um código promocional aplicado no checkout. O escopo é um evento (EventID) ou todos os
+// eventos de uma organização (EventID vazio); Organization é sempre preenchida.
+type Coupon struct {
+	ID           string
+	Code         string // normalizado (NormalizeCouponCode) e único
+	DiscountType CouponDiscountType
+	Percent      int64 // desconto em pontos-base (5000 = 50%), para CouponDiscountPercentage
+	Amount       Money // desconto por ingresso, para CouponDiscountFixed
+	ValidFrom    time.Time
+	ValidUntil   time.Time // zero = sem data de término
+	EventID      string
+	Organization string
+	// MaxRedemptions limita as compras que podem usar o cupom; 0 = sem limite.
+	MaxRedemptions int
+	// MaxRedemptionsPerEmail limita as compras de um mesmo e-mail com o cupom; 0 = sem limite.
+	MaxRedemptionsPerEmail int
+	// StacksWithCoupons permite usar o cupom junto com outros cupons na mesma compra.
+	StacksWithCoupons bool
+	// StacksWithTicketKinds permite usar o cupom em tipos de ingresso que já têm desconto (meia-entrada).
+	StacksWithTicketKinds bool
+	CreatedAt             time.Time
+}
+
+// NewCoupon cria o cupom descrito por c, gerando o ID e normalizando o código.
+// Sem ValidFrom, o cupom vale a partir de agora.
+func NewCoupon(c Coupon) (*Coupon, error) {
+	c.ID = uuid.New().String()
+	c.Code = NormalizeCouponCode(c.Code)
+	c.CreatedAt = time.Now()
+	if c.ValidFrom.IsZero() {
+		c.ValidFrom = c.CreatedAt
+	}
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return &c, nil
+}
+
+func (c *Coupon) Validate() error {
+	switch {
+	case !IsValidCouponCode(c.Code):
+		return fmt.Errorf("%w: code %q must have 3 to %d uppercase letters, digits, _ or -", ErrInvalidCoupon, c.Code, maxCouponCodeLength)
+	case c.DiscountType == CouponDiscountPercentage && (c.Percent <= 0 || c.Percent > basisPoints):
+		return fmt.Errorf("%w: %s: percentage discount must be between 1 and %d basis points", ErrInvalidCoupon, c.Code, basisPoints)
+	case c.DiscountType == CouponDiscountFixed && (!c.Amount.Currency.IsValid() || !c.Amount.IsPositive()):
+		return fmt.Errorf("%w: %s: fixed discount must be a positive amount in a supported currency", ErrInvalidCoupon, c.Code)
+	case !c.DiscountType.IsValid():
+		return fmt.Errorf("%w: %s: unknown discount type %q", ErrInvalidCoupon, c.Code, c.DiscountType)
+	case !c.ValidUntil.IsZero() && !c.ValidUntil.After(c.ValidFrom):
+		return fmt.Errorf("%w: %s: validity must end after it starts", ErrInvalidCoupon, c.Code)
+	case c.Organization == "":
+		return fmt.Errorf("%w: %s: organization is required", ErrInvalidCoupon, c.Code)
+	case c.MaxRedemptions < 0 || c.MaxRedemptionsPerEmail < 0:
+		return fmt.Errorf("%w: %s: redemption limits must not be negative", ErrInvalidCoupon, c.Code)
+	}
+	return nil
+}
+
+// IsActive indica se now está dentro da janela de validade do cupom.
+func (c *Coupon) IsActive(now time.Time) bool {
+	return !now.Before(c.ValidFrom) && (c.ValidUntil.IsZero() || now.Before(c.ValidUntil))
+}
+
+// CheckApplicable verifica se o cupom pode ser usado em now em um ingresso do evento com o
+// preço price (Event.SpotPrice do spot comprado): um desconto fixo precisa estar na mesma moeda.
+func (c *Coupon) CheckApplicable(event *Event, price Money, now time.Time) error {
+	if !c.IsActive(now) {
+		return fmt.Errorf("%w: %s", ErrCouponNotActive, c.Code)
+	}
+	if c.Organization != event.Organization || (c.EventID != "" && c.EventID != event.ID) {
+		return fmt.Errorf("%w: %s is not valid for this event", ErrCouponNotApplicable, c.Code)
+	}
+	if c.DiscountType == CouponDiscountFixed && c.Amount.Currency != price.Currency {
+		return fmt.Errorf("%w: %s is in %s, the ticket is in %s", ErrCouponNotApplicable, c.Code, c.Amount.Currency, price.Currency)
+	}
+	return nil
+}
+
+// CouponUsage conta as compras que já usaram um cupom: no total e as do e-mail do comprador.
+type CouponUsage struct {
+	Total   int
+	ByEmail int
+}
+
+// CheckUsage retorna ErrCouponExhausted ou ErrCouponEmailLimit se o cupom não puder ser usado em mais uma compra.
+func (c *Coupon) CheckUsage(usage CouponUsage) error {
+	if c.MaxRedemptions > 0 && usage.Total >= c.MaxRedemptions {
+		return fmt.Errorf("%w: %s", ErrCouponExhausted, c.Code)
+	}
+	if c.MaxRedemptionsPerEmail > 0 && usage.ByEmail >= c.MaxRedemptionsPerEmail {
+		return fmt.Errorf("%w: %s allows %d per email", ErrCouponEmailLimit, c.Code, c.MaxRedemptionsPerEmail)
+	}
+	return nil
+}
+
+// CheckCouponStacking aplica as regras de acumulação: um cupom só é combinado com outros cupons
+// se todos permitirem, e só vale em um tipo de ingresso com desconto se permitir StacksWithTicketKinds.
+func CheckCouponStacking(coupons []*Coupon, rule *TicketKindRule) error {
+	for _, c := range coupons {
+		if len(coupons) > 1 && !c.StacksWithCoupons {
+			return fmt.Errorf("%w: %s cannot be combined with other coupons", ErrCouponNotStackable, c.Code)
+		}
+		if rule.Discount > 0 && !c.StacksWithTicketKinds {
+			return fmt.Errorf("%w: %s cannot be combined with the %s ticket kind", ErrCouponNotStackable, c.Code, rule.Kind)
+		}
+	}
+	return nil
+}
+
+// discount retorna o desconto do cupom sobre price, nunca maior que price.
+func (c *Coupon) discount(price Money) Money {
+	if c.DiscountType == CouponDiscountPercentage {
+		return NewMoney(price.Amount-price.ApplyDiscount(c.Percent).Amount, price.Currency)
+	}
+	return NewMoney(min(c.Amount.Amount, price.Amount), price.Currency)
+}
+
+// ApplyCoupons aplica os cupons ao preço do ingresso (já com o desconto do tipo), primeiro os
+// percentuais e depois os de valor fixo, sem deixar o preço negativo. Retorna o desconto de cada
+// cupom, na ordem de coupons.
+func (t *Ticket) ApplyCoupons(coupons []*Coupon) []Money {
+	discounts := make([]Money, len(coupons))
+	for _, discountType := range []CouponDiscountType{CouponDiscountPercentage, CouponDiscountFixed} {
+		for i, c := range coupons {
+			if c.DiscountType != discountType {
+				continue
+			}
+			discounts[i] = c.discount(t.Price)
+			t.Price.Amount -= discounts[i].Amount
+			t.CouponDiscount.Amount += discounts[i].Amount
+		}
+	}
+	t.CouponDiscount.Currency = t.Price.Currency
+	return discounts
+}
+
+// CouponRedemption registra o uso de um cupom em um ingresso. As linhas de uma mesma compra
+// compartilham o CheckoutID, que é a unidade contada pelos limites de uso.
+type CouponRedemption struct {
+	ID         string
+	CouponID   string
+	CheckoutID string
+	TicketID   string
+	Email      string // normalizado em minúsculas, para o limite por e-mail
+	Discount   Money
+	CreatedAt  time.Time
+}
+
+func NewCouponRedemption(coupon *Coupon, checkoutID string, ticket *Ticket, email string, discount Money) *CouponRedemption {
+	return &CouponRedemption{
+		ID:         uuid.New().String(),
+		CouponID:   coupon.ID,
+		CheckoutID: checkoutID,
+		TicketID:   ticket.ID,
+		Email:      NormalizeCouponEmail(email),
+		Discount:   discount,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// NormalizeCouponEmail padroniza o e-mail usado no limite de uso por comprador.
+func NormalizeCouponEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
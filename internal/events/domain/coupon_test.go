@@ -0,0 +1,172 @@
+package domain
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func percentageCoupon(code string, percent int64) *Coupon {
+	return &Coupon{Code: code, DiscountType: CouponDiscountPercentage, Percent: percent, Organization: "acme"}
+}
+
+func fixedCoupon(code string, amount int64) *Coupon {
+	return &Coupon{Code: code, DiscountType: CouponDiscountFixed, Amount: NewMoney(amount, CurrencyBRL), Organization: "acme"}
+}
+
+func TestApplyCoupons(t *testing.T) {
+	tests := []struct {
+		name      string
+		price     int64
+		coupons   []*Coupon
+		want      int64   // preço final
+		discounts []int64 // desconto de cada cupom, na ordem de coupons
+	}{
+		{
+			// Com o fixo antes, o percentual incidiria sobre 90,00 e o preço seria 81,00.
+			name:      "percentage before fixed",
+			price:     10000,
+			coupons:   []*Coupon{fixedCoupon("FIXO10", 1000), percentageCoupon("DEZ", 1000)},
+			want:      8000,
+			discounts: []int64{1000, 1000},
+		},
+		{
+			name:      "fixed discounts never go below zero",
+			price:     5000,
+			coupons:   []*Coupon{fixedCoupon("FIXO30", 3000), fixedCoupon("OUTRO30", 3000)},
+			want:      0,
+			discounts: []int64{3000, 2000},
+		},
+		{
+			name:      "full percentage leaves nothing for fixed",
+			price:     5000,
+			coupons:   []*Coupon{fixedCoupon("FIXO10", 1000), percentageCoupon("CEM", basisPoints)},
+			want:      0,
+			discounts: []int64{0, 5000},
+		},
+		{
+			// O preço final é arredondado (499,5 -> 500), e o desconto é o que sobra.
+			name:      "rounds the final price",
+			price:     999,
+			coupons:   []*Coupon{percentageCoupon("METADE", 5000)},
+			want:      500,
+			discounts: []int64{499},
+		},
+		{
+			name:      "percentages compound in order",
+			price:     10000,
+			coupons:   []*Coupon{percentageCoupon("DEZ", 1000), percentageCoupon("VINTE", 2000)},
+			want:      7200,
+			discounts: []int64{1000, 1800},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ticket := &Ticket{Price: NewMoney(tt.price, CurrencyBRL), CouponDiscount: NewMoney(0, CurrencyBRL)}
+			discounts := ticket.ApplyCoupons(tt.coupons)
+
+			if ticket.Price != NewMoney(tt.want, CurrencyBRL) {
+				t.Errorf("price = %v, want %d", ticket.Price, tt.want)
+			}
+			if ticket.CouponDiscount != NewMoney(tt.price-tt.want, CurrencyBRL) {
+				t.Errorf("coupon discount = %v, want %d", ticket.CouponDiscount, tt.price-tt.want)
+			}
+			if len(discounts) != len(tt.discounts) {
+				t.Fatalf("discounts = %v, want %v", discounts, tt.discounts)
+			}
+			for i, discount := range discounts {
+				if discount != NewMoney(tt.discounts[i], CurrencyBRL) {
+					t.Errorf("discount of %s = %v, want %d", tt.coupons[i].Code, discount, tt.discounts[i])
+				}
+			}
+		})
+	}
+}
+
+func TestCouponCheckUsage(t *testing.T) {
+	tests := []struct {
+		name     string
+		total    int
+		perEmail int
+		usage    CouponUsage
+		want     error
+	}{
+		{name: "no limits", usage: CouponUsage{Total: 1000, ByEmail: 1000}},
+		{name: "below the total limit", total: 2, usage: CouponUsage{Total: 1}},
+		{name: "total limit reached", total: 2, usage: CouponUsage{Total: 2}, want: ErrCouponExhausted},
+		{name: "below the email limit", perEmail: 1, usage: CouponUsage{Total: 5}},
+		{name: "email limit reached", perEmail: 1, usage: CouponUsage{Total: 5, ByEmail: 1}, want: ErrCouponEmailLimit},
+		{name: "total limit checked first", total: 1, perEmail: 1, usage: CouponUsage{Total: 1, ByEmail: 1}, want: ErrCouponExhausted},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			coupon := fixedCoupon("LIMITE", 1000)
+			coupon.MaxRedemptions, coupon.MaxRedemptionsPerEmail = tt.total, tt.perEmail
+			if err := coupon.CheckUsage(tt.usage); !errors.Is(err, tt.want) {
+				t.Errorf("error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckCouponStacking(t *testing.T) {
+	stackable := func(code string, withCoupons, withKinds bool) *Coupon {
+		coupon := percentageCoupon(code, 1000)
+		coupon.StacksWithCoupons, coupon.StacksWithTicketKinds = withCoupons, withKinds
+		return coupon
+	}
+	full := &TicketKindRule{Kind: TicketKindFull}
+	half := &TicketKindRule{Kind: TicketKindHalf, Discount: 5000}
+
+	tests := []struct {
+		name    string
+		coupons []*Coupon
+		rule    *TicketKindRule
+		want    error
+	}{
+		{name: "single coupon on a full ticket", coupons: []*Coupon{stackable("UM", false, false)}, rule: full},
+		{name: "coupons that stack", coupons: []*Coupon{stackable("UM", true, false), stackable("DOIS", true, false)}, rule: full},
+		{name: "one coupon does not stack", coupons: []*Coupon{stackable("UM", true, false), stackable("DOIS", false, false)}, rule: full, want: ErrCouponNotStackable},
+		{name: "discounted kind not allowed", coupons: []*Coupon{stackable("UM", false, false)}, rule: half, want: ErrCouponNotStackable},
+		{name: "discounted kind allowed", coupons: []*Coupon{stackable("UM", false, true)}, rule: half},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := CheckCouponStacking(tt.coupons, tt.rule); !errors.Is(err, tt.want) {
+				t.Errorf("error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCouponCheckApplicable(t *testing.T) {
+	now := time.Now()
+	event := &Event{ID: "event-1", Organization: "acme", Price: NewMoney(10000, CurrencyBRL)}
+
+	tests := []struct {
+		name   string
+		coupon func(c *Coupon)
+		price  Money
+		want   error
+	}{
+		{name: "organization coupon", coupon: func(c *Coupon) {}, price: event.Price},
+		{name: "event coupon", coupon: func(c *Coupon) { c.EventID = event.ID }, price: event.Price},
+		{name: "other event", coupon: func(c *Coupon) { c.EventID = "event-2" }, price: event.Price, want: ErrCouponNotApplicable},
+		{name: "other organization", coupon: func(c *Coupon) { c.Organization = "other" }, price: event.Price, want: ErrCouponNotApplicable},
+		{name: "not started", coupon: func(c *Coupon) { c.ValidFrom = now.Add(time.Hour) }, price: event.Price, want: ErrCouponNotActive},
+		{name: "expired", coupon: func(c *Coupon) { c.ValidUntil = now }, price: event.Price, want: ErrCouponNotActive},
+		// A moeda é a do ingresso comprado, e não a de Event.Price.
+		{name: "fixed in the ticket currency", coupon: func(c *Coupon) { c.Amount.Currency = CurrencyUSD }, price: NewMoney(5000, CurrencyUSD)},
+		{name: "fixed in another currency", coupon: func(c *Coupon) {}, price: NewMoney(5000, CurrencyUSD), want: ErrCouponNotApplicable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			coupon := fixedCoupon("PROMO", 1000)
+			coupon.ValidFrom = now.Add(-time.Hour)
+			tt.coupon(coupon)
+			if err := coupon.CheckApplicable(event, tt.price, now); !errors.Is(err, tt.want) {
+				t.Errorf("error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
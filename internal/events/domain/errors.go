@@ -0,0 +1,55 @@
+package domain
+
+// ErrorKind classifica um erro de domínio, permitindo que cada camada de entrega
+// (HTTP, filas, ...) escolha a resposta adequada sem conhecer cada erro individualmente.
+type ErrorKind string
+
+const (
+	ErrorKindValidation      ErrorKind = "validation"      // a entrada viola uma regra de negócio
+	ErrorKindNotFound        ErrorKind = "not_found"       // o recurso não existe
+	ErrorKindConflict        ErrorKind = "conflict"        // o estado atual do recurso impede a operação
+	ErrorKindPartner         ErrorKind = "partner"         // o parceiro falhou ou está indisponível
+	ErrorKindUnauthenticated ErrorKind = "unauthenticated" // credenciais ausentes ou inválidas
+	ErrorKindForbidden       ErrorKind = "forbidden"       // o principal não tem permissão para a operação
+)
+
+// Error é um erro de domínio com um código estável, legível por máquina (ex.: "spot_already_reserved").
+// Os erros sentinela do pacote são *Error, então errors.Is continua funcionando normalmente.
+type Error struct {
+	Kind    ErrorKind
+	Code    string
+	Message string
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func NewValidationError(code, message string) *Error {
+	return &Error{Kind: ErrorKindValidation, Code: code, Message: message}
+}
+
+func NewNotFoundError(code, message string) *Error {
+	return &Error{Kind: ErrorKindNotFound, Code: code, Message: message}
+}
+
+func NewConflictError(code, message string) *Error {
+	return &Error{Kind: ErrorKindConflict, Code: code, Message: message}
+}
+
+func NewPartnerError(code, message string) *Error {
+	return &Error{Kind: ErrorKindPartner, Code: code, Message: message}
+}
+
+func NewUnauthenticatedError(code, message string) *Error {
+	return &Error{Kind: ErrorKindUnauthenticated, Code: code, Message: message}
+}
+
+func NewForbiddenError(code, message string) *Error {
+	return &Error{Kind: ErrorKindForbidden, Code: code, Message: message}
+}
+
+var (
+	ErrPartnerFailed      = NewPartnerError("partner_failed", "partner request failed")
+	ErrPartnerUnavailable = NewPartnerError("partner_unavailable", "partner is temporarily unavailable")
+)
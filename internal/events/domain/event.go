@@ -1,7 +1,7 @@
 package domain
 
 import (
-	"errors"
+	"fmt"
 	"time"
 
 	"github.com/google/uuid"
@@ -10,11 +10,15 @@ import (
 type Rating string
 
 var (
-	ErrEventNameRequired = errors.New("event name is required")
-	ErrEventDateFuture   = errors.New("Event date must be in the future")
-	ErrEventCapacityZero = errors.New("event capacity must be greater than zero")
-	ErrEventPriceZero    = errors.New("event price must be greater than zero")
-	ErrEventNotFound     = errors.New("event not found")
+	ErrEventNameRequired     = NewValidationError("event_name_required", "event name is required")
+	ErrEventDateFuture       = NewValidationError("event_date_in_past", "Event date must be in the future")
+	ErrEventCapacityZero     = NewValidationError("event_capacity_invalid", "event capacity must be greater than zero")
+	ErrEventPriceZero        = NewValidationError("event_price_invalid", "event price must be greater than zero")
+	ErrEventNotFound         = NewNotFoundError("event_not_found", "event not found")
+	ErrInvalidRating         = NewValidationError("invalid_rating", "rating must be one of L, L10, L12, L14, L16 or L18")
+	ErrEventPriceLocked      = NewConflictError("event_price_locked", "event price cannot change after tickets are sold")
+	ErrEventHasTickets       = NewConflictError("event_has_tickets", "event with sold tickets cannot be deleted; cancel it instead")
+	ErrEventCapacityExceeded = NewConflictError("event_capacity_exceeded", "event capacity exceeded")
 )
 
 const (
@@ -26,6 +30,15 @@ const (
 	Rating18    Rating = "L18"
 )
 
+// IsValid indica se a classificação é uma das constantes RatingLivre..Rating18.
+func (r Rating) IsValid() bool {
+	switch r {
+	case RatingLivre, Rating10, Rating12, Rating14, Rating16, Rating18:
+		return true
+	}
+	return false
+}
+
 type User struct {
 	ID    string
 	Email string
@@ -40,14 +53,19 @@ type Event struct {
 	Date         time.Time
 	ImageURL     string
 	Capacity     int
-	Price        float64
+	Price        Money
 	PartnerID    int
+	VenueID      string // vazio quando o evento não usa o mapa de um local
+	Status       EventStatus
+	Version      int // incrementada a cada UpdateEvent; detecta alterações concorrentes
 	Spots        []Spot
 	Tickets      []Ticket
+	Tiers        []PriceTier      // categorias de preço; spots sem categoria custam Price
+	TicketKinds  []TicketKindRule // tipos de ingresso oferecidos; vazio para DefaultTicketKindRules
 }
 
-// NewEvent creates a new event with the given parameters.
-func NewEvent(name, location, organization string, rating Rating, date time.Time, capacity int, price float64, imageUrl string, partnerID int) (*Event, error) {
+// NewEvent creates a new event with the given parameters. The event starts as a draft.
+func NewEvent(name, location, organization string, rating Rating, date time.Time, capacity int, price Money, imageUrl string, partnerID int) (*Event, error) {
 	event := &Event{
 		ID:           uuid.New().String(),
 		Name:         name,
@@ -59,7 +77,9 @@ func NewEvent(name, location, organization string, rating Rating, date time.Time
 		Price:        price,
 		ImageURL:     imageUrl,
 		PartnerID:    partnerID,
+		Status:       EventStatusDraft,
 		Spots:        make([]Spot, 0),
+		Tiers:        make([]PriceTier, 0),
 	}
 	if err := event.Validate(); err != nil {
 		return nil, err
@@ -73,6 +93,10 @@ func (e *Event) Validate() error {
 		return ErrEventNameRequired
 	}
 
+	if !e.Rating.IsValid() {
+		return ErrInvalidRating
+	}
+
 	if e.Date.Before(time.Now()) {
 		return ErrEventDateFuture
 	}
@@ -81,15 +105,87 @@ func (e *Event) Validate() error {
 		return ErrEventCapacityZero
 	}
 
-	if e.Price <= 0 {
+	if !e.Price.Currency.IsValid() {
+		return ErrUnsupportedCurrency
+	}
+
+	if !e.Price.IsPositive() {
 		return ErrEventPriceZero
 	}
 
 	return nil
 }
 
-// adicionar spot ao event
+// HasTickets indica se algum ingresso do evento já foi vendido. Requer Tickets carregado (FindEventByID).
+func (e *Event) HasTickets() bool {
+	return len(e.Tickets) > 0
+}
+
+// ChangePrice altera o preço (e a moeda) do evento, desde que nenhum ingresso tenha sido vendido.
+func (e *Event) ChangePrice(price Money) error {
+	if price == e.Price {
+		return nil
+	}
+	if e.HasTickets() {
+		return ErrEventPriceLocked
+	}
+	if price.Currency != e.Price.Currency && len(e.Tiers) > 0 {
+		return ErrPriceTierCurrency
+	}
+	e.Price = price
+	return nil
+}
+
+// RemainingCapacity retorna quantos ingressos ainda podem ser vendidos. Requer Tickets carregado.
+func (e *Event) RemainingCapacity() int {
+	return max(e.Capacity-len(e.Tickets), 0)
+}
+
+// CheckCapacity verifica se quantity ingressos ainda cabem na capacidade do evento.
+func (e *Event) CheckCapacity(quantity int) error {
+	if remaining := e.RemainingCapacity(); quantity > remaining {
+		return fmt.Errorf("%w: %d tickets remaining", ErrEventCapacityExceeded, remaining)
+	}
+	return nil
+}
+
+// ChangeCapacity altera a capacidade, que não pode ficar abaixo da quantidade de spots já criados.
+func (e *Event) ChangeCapacity(capacity int) error {
+	if capacity < len(e.Spots) {
+		return fmt.Errorf("%w: event already has %d spots", ErrEventCapacityExceeded, len(e.Spots))
+	}
+	e.Capacity = capacity
+	return nil
+}
+
+// AddVenueSpots associa um evento novo ao local e cria um spot para cada assento do mapa, no setor
+// do assento. O mapa precisa caber na capacidade do evento.
+func (e *Event) AddVenueSpots(venue *Venue) error {
+	if seats := venue.SeatCount(); seats > e.Capacity {
+		return fmt.Errorf("%w: venue has %d seats, capacity is %d", ErrEventCapacityExceeded, seats, e.Capacity)
+	}
+
+	e.VenueID = venue.ID
+	for _, section := range venue.Sections {
+		for _, row := range section.Rows {
+			for _, seat := range row.Seats {
+				spot, err := NewSpot(e, row.SpotName(seat))
+				if err != nil {
+					return err
+				}
+				spot.SectionID = section.ID
+				e.Spots = append(e.Spots, *spot)
+			}
+		}
+	}
+	return nil
+}
+
+// adicionar spot ao event; a quantidade de spots não pode passar da capacidade. Requer Spots carregado.
 func (e *Event) AddSpot(name string) (*Spot, error) {
+	if len(e.Spots) >= e.Capacity {
+		return nil, fmt.Errorf("%w: capacity is %d", ErrEventCapacityExceeded, e.Capacity)
+	}
 	spot, err := NewSpot(e, name)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,59 @@
+package domain
+
+import "time"
+
+// EventSortField é o campo usado para ordenar a listagem de eventos.
+type EventSortField string
+
+const (
+	EventSortByDate  EventSortField = "date"
+	EventSortByPrice EventSortField = "price"
+	EventSortByName  EventSortField = "name"
+)
+
+// IsValid indica se o campo é uma das constantes EventSortBy*.
+func (f EventSortField) IsValid() bool {
+	switch f {
+	case EventSortByDate, EventSortByPrice, EventSortByName:
+		return true
+	}
+	return false
+}
+
+// EventQuery descreve uma página da listagem de eventos. Os filtros com valor zero são ignorados.
+// A paginação é por cursor (keyset): After é a posição do último evento da página anterior,
+// e os eventos são ordenados por SortBy e, em caso de empate, pelo ID. Preços em moedas diferentes
+// não são comparáveis: PriceMin, PriceMax e EventSortByPrice só fazem sentido com Currency.
+type EventQuery struct {
+	DateFrom        time.Time // inclusive
+	DateTo          time.Time // inclusive
+	Location        string    // trecho do local, sem diferenciar maiúsculas
+	Organization    string
+	Rating          Rating
+	PartnerID       int
+	Currency        Currency
+	Statuses        []EventStatus // eventos em qualquer um destes estados; vazio não filtra
+	PriceMin        int64         // menor preço do evento (Event.LowestPrice), em unidades menores de Currency
+	PriceMax        int64         // menor preço do evento (Event.LowestPrice), em unidades menores de Currency
+	HasAvailability bool          // apenas eventos com algum spot que possa ser comprado em Now
+	Now             time.Time     // referência para retenções expiradas quando HasAvailability é true
+
+	SortBy   EventSortField
+	SortDesc bool
+	After    *EventCursor
+	Limit    int
+}
+
+// EventCursor identifica a posição de um evento na ordenação. Apenas o campo correspondente
+// a EventQuery.SortBy é usado, além do ID.
+type EventCursor struct {
+	ID    string
+	Date  time.Time
+	Price int64 // Event.LowestPrice, em unidades menores
+	Name  string
+}
+
+// Cursor retorna a posição do evento na ordenação. Requer Tiers carregado (ver LowestPrice).
+func (e *Event) Cursor() EventCursor {
+	return EventCursor{ID: e.ID, Date: e.Date, Price: e.LowestPrice().Amount, Name: e.Name}
+}
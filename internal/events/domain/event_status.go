@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// EventStatus é o estado do evento no seu ciclo de vida.
+type EventStatus string
+
+const (
+	EventStatusDraft     EventStatus = "draft"      // em preparação, invisível na listagem pública
+	EventStatusPublished EventStatus = "published"  // divulgado, vendas ainda fechadas
+	EventStatusSalesOpen EventStatus = "sales_open" // aceita retenções e compras
+	EventStatusSoldOut   EventStatus = "sold_out"   // todos os spots vendidos (automático)
+	EventStatusCancelled EventStatus = "cancelled"  // estado final; os ingressos aguardam reembolso
+	EventStatusPostponed EventStatus = "postponed"  // adiado; volta a published com uma nova data
+)
+
+var (
+	ErrInvalidEventStatus     = NewValidationError("invalid_event_status", "invalid event status")
+	ErrInvalidEventTransition = NewConflictError("invalid_event_transition", "event cannot move to this status")
+	ErrEventNotOnSale         = NewConflictError("event_not_on_sale", "event is not open for sales")
+	ErrEventCancelled         = NewConflictError("event_cancelled", "event is cancelled")
+	ErrEventModified          = NewConflictError("event_modified", "event was modified by another request")
+)
+
+// eventTransitions lista, para cada estado, os estados seguintes permitidos.
+var eventTransitions = map[EventStatus][]EventStatus{
+	EventStatusDraft:     {EventStatusPublished, EventStatusCancelled},
+	EventStatusPublished: {EventStatusSalesOpen, EventStatusPostponed, EventStatusCancelled},
+	EventStatusSalesOpen: {EventStatusSoldOut, EventStatusPostponed, EventStatusCancelled},
+	EventStatusSoldOut:   {EventStatusSalesOpen, EventStatusPostponed, EventStatusCancelled},
+	EventStatusPostponed: {EventStatusPublished, EventStatusCancelled},
+	EventStatusCancelled: {},
+}
+
+// IsValid indica se o status é uma das constantes EventStatus*.
+func (s EventStatus) IsValid() bool {
+	_, ok := eventTransitions[s]
+	return ok
+}
+
+// CanTransitionTo indica se o ciclo de vida permite passar de s para next.
+func (s EventStatus) CanTransitionTo(next EventStatus) bool {
+	for _, allowed := range eventTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Event) transitionTo(next EventStatus) error {
+	if !e.Status.CanTransitionTo(next) {
+		return fmt.Errorf("%w: %s -> %s", ErrInvalidEventTransition, e.Status, next)
+	}
+	e.Status = next
+	return nil
+}
+
+// Publish divulga um evento em rascunho ou republica um evento adiado. A data precisa estar no futuro.
+func (e *Event) Publish(now time.Time) error {
+	if !e.Date.After(now) {
+		return ErrEventDateFuture
+	}
+	return e.transitionTo(EventStatusPublished)
+}
+
+// OpenSales abre as vendas de um evento publicado.
+func (e *Event) OpenSales() error {
+	return e.transitionTo(EventStatusSalesOpen)
+}
+
+// Postpone adia o evento, opcionalmente já com a nova data, que precisa estar no futuro.
+// As vendas ficam suspensas até que o evento seja publicado novamente e reabra as vendas.
+func (e *Event) Postpone(newDate, now time.Time) error {
+	if !newDate.IsZero() && !newDate.After(now) {
+		return ErrEventDateFuture
+	}
+	if err := e.transitionTo(EventStatusPostponed); err != nil {
+		return err
+	}
+	if !newDate.IsZero() {
+		e.Date = newDate
+	}
+	return nil
+}
+
+// Cancel cancela o evento definitivamente. Os ingressos já emitidos devem ser marcados para reembolso.
+func (e *Event) Cancel() error {
+	return e.transitionTo(EventStatusCancelled)
+}
+
+// IsOnSale indica se o evento aceita retenções e compras.
+func (e *Event) IsOnSale() bool {
+	return e.Status == EventStatusSalesOpen
+}
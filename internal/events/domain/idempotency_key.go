@@ -0,0 +1,67 @@
+package domain
+
+import "time"
+
+// MaxIdempotencyKeyLength é o tamanho máximo da chave enviada pelo cliente.
+const MaxIdempotencyKeyLength = 255
+
+var (
+	ErrInvalidIdempotencyKey    = NewValidationError("invalid_idempotency_key", "idempotency key must have between 1 and 255 characters")
+	ErrIdempotencyKeyExists     = NewConflictError("idempotency_key_exists", "idempotency key already exists")
+	ErrIdempotencyKeyNotFound   = NewNotFoundError("idempotency_key_not_found", "idempotency key not found")
+	ErrIdempotencyKeyInProgress = NewConflictError("idempotency_key_in_progress", "a request with this idempotency key is still being processed")
+	ErrIdempotencyKeyMismatch   = NewValidationError("idempotency_key_mismatch", "idempotency key was already used with a different request body")
+)
+
+type IdempotencyKeyStatus string
+
+const (
+	IdempotencyKeyInProgress IdempotencyKeyStatus = "in_progress" // requisição original ainda em execução
+	IdempotencyKeyCompleted  IdempotencyKeyStatus = "completed"   // resposta armazenada para as repetições
+)
+
+// IdempotencyKeyLease é por quanto tempo uma chave em execução bloqueia repetições.
+// Passado esse prazo (por exemplo, após a queda do processo), a chave pode ser reutilizada;
+// a saga de compra garante a compensação de uma execução abandonada no meio.
+const IdempotencyKeyLease = 5 * time.Minute
+
+// IdempotencyKeyRetention é por quanto tempo a resposta de uma requisição concluída é guardada.
+const IdempotencyKeyRetention = 24 * time.Hour
+
+// IdempotencyKey associa a chave enviada pelo cliente à impressão digital da requisição
+// e, depois de concluída, à resposta que deve ser devolvida nas repetições.
+type IdempotencyKey struct {
+	Key         string
+	Fingerprint string
+	Status      IdempotencyKeyStatus
+	Response    []byte // resposta serializada (JSON), presente quando Status é completed
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+func NewIdempotencyKey(key, fingerprint string) (*IdempotencyKey, error) {
+	if len(key) == 0 || len(key) > MaxIdempotencyKeyLength {
+		return nil, ErrInvalidIdempotencyKey
+	}
+
+	now := time.Now()
+	return &IdempotencyKey{
+		Key:         key,
+		Fingerprint: fingerprint,
+		Status:      IdempotencyKeyInProgress,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(IdempotencyKeyLease),
+	}, nil
+}
+
+// Complete armazena a resposta e estende a validade da chave pelo período de retenção.
+func (k *IdempotencyKey) Complete(response []byte) {
+	k.Status = IdempotencyKeyCompleted
+	k.Response = response
+	k.ExpiresAt = time.Now().Add(IdempotencyKeyRetention)
+}
+
+// IsExpired indica se a chave já pode ser descartada.
+func (k *IdempotencyKey) IsExpired(now time.Time) bool {
+	return !now.Before(k.ExpiresAt)
+}
@@ -0,0 +1,158 @@
+package domain
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Currency é um código de moeda ISO 4217.
+type Currency string
+
+const (
+	CurrencyBRL Currency = "BRL"
+	CurrencyUSD Currency = "USD"
+	CurrencyEUR Currency = "EUR"
+
+	// DefaultCurrency é usada quando a moeda não é informada.
+	DefaultCurrency = CurrencyBRL
+)
+
+// currencyExponents é o número de casas decimais da unidade menor de cada moeda suportada.
+var currencyExponents = map[Currency]int{
+	CurrencyBRL: 2,
+	CurrencyUSD: 2,
+	CurrencyEUR: 2,
+}
+
+var (
+	ErrUnsupportedCurrency = NewValidationError("unsupported_currency", "unsupported currency")
+	ErrInvalidMoney        = NewValidationError("invalid_money", "invalid monetary amount")
+	ErrCurrencyMismatch    = NewValidationError("currency_mismatch", "amounts have different currencies")
+)
+
+// IsValid indica se a moeda é suportada.
+func (c Currency) IsValid() bool {
+	_, ok := currencyExponents[c]
+	return ok
+}
+
+// Exponent retorna o número de casas decimais da unidade menor da moeda.
+func (c Currency) Exponent() int {
+	return currencyExponents[c]
+}
+
+// Money é um valor monetário exato: Amount está em unidades menores da moeda (centavos para BRL).
+// Nenhuma operação passa por ponto flutuante.
+//
+// Regra de arredondamento: quando uma operação (meia-entrada, descontos) não resulta em um
+// número inteiro de unidades menores, o resultado é arredondado para a unidade mais próxima e
+// os empates são arredondados para longe do zero (half up). Ex.: metade de R$ 10,01 é R$ 5,01.
+type Money struct {
+	Amount   int64
+	Currency Currency
+}
+
+// basisPoints é o denominador dos descontos: 10000 pontos-base equivalem a 100%.
+const basisPoints = 10000
+
+func NewMoney(amount int64, currency Currency) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+// ParseMoney converte um valor decimal (ex.: "10", "10.5", "-10.50") na moeda informada.
+// Valores com mais casas decimais do que a moeda permite são rejeitados em vez de arredondados.
+func ParseMoney(value string, currency Currency) (Money, error) {
+	if !currency.IsValid() {
+		return Money{}, ErrUnsupportedCurrency
+	}
+
+	digits, negative := strings.CutPrefix(value, "-")
+	whole, fraction, hasFraction := strings.Cut(digits, ".")
+	exponent := currency.Exponent()
+	if whole == "" || (hasFraction && fraction == "") || len(fraction) > exponent || !isDigits(whole) || !isDigits(fraction) {
+		return Money{}, ErrInvalidMoney
+	}
+
+	amount, err := strconv.ParseInt(whole+fraction+strings.Repeat("0", exponent-len(fraction)), 10, 64)
+	if err != nil {
+		return Money{}, ErrInvalidMoney
+	}
+	if negative {
+		amount = -amount
+	}
+	return Money{Amount: amount, Currency: currency}, nil
+}
+
+func isDigits(value string) bool {
+	for _, c := range value {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// String formata o valor em unidades maiores, com todas as casas decimais da moeda (ex.: "10.50").
+func (m Money) String() string {
+	exponent := m.Currency.Exponent()
+	sign, amount := "", m.Amount
+	if amount < 0 {
+		sign, amount = "-", -amount
+	}
+
+	digits := strconv.FormatInt(amount, 10)
+	if exponent == 0 {
+		return sign + digits
+	}
+	if len(digits) <= exponent {
+		digits = strings.Repeat("0", exponent-len(digits)+1) + digits
+	}
+	return sign + digits[:len(digits)-exponent] + "." + digits[len(digits)-exponent:]
+}
+
+func (m Money) IsPositive() bool {
+	return m.Amount > 0
+}
+
+// Add soma dois valores da mesma moeda.
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, ErrCurrencyMismatch
+	}
+	return Money{Amount: m.Amount + other.Amount, Currency: m.Currency}, nil
+}
+
+// MulFrac multiplica o valor por numerator/denominator, arredondando conforme a regra de Money.
+// O produto intermediário não transborda; um resultado fora de int64 (só possível com
+// |numerator| > |denominator|) é limitado a math.MaxInt64 ou math.MinInt64.
+func (m Money) MulFrac(numerator, denominator int64) Money {
+	product := new(big.Int).Mul(big.NewInt(m.Amount), big.NewInt(numerator))
+	quotient, remainder := new(big.Int).QuoRem(product, big.NewInt(denominator), new(big.Int))
+	remainder.Abs(remainder).Lsh(remainder, 1)
+	if remainder.CmpAbs(big.NewInt(denominator)) >= 0 {
+		if (product.Sign() < 0) != (denominator < 0) {
+			quotient.Sub(quotient, big.NewInt(1))
+		} else {
+			quotient.Add(quotient, big.NewInt(1))
+		}
+	}
+
+	switch {
+	case quotient.IsInt64():
+		return Money{Amount: quotient.Int64(), Currency: m.Currency}
+	case quotient.Sign() > 0:
+		return Money{Amount: math.MaxInt64, Currency: m.Currency}
+	default:
+		return Money{Amount: math.MinInt64, Currency: m.Currency}
+	}
+}
+
+// ApplyDiscount retorna o valor com um desconto de discount pontos-base (5000 = 50%).
+// O valor final é arredondado, e não o desconto, para que o preço cobrado siga a regra de Money.
+// O desconto é limitado a 0..10000 pontos-base: o preço nunca fica negativo nem aumenta.
+func (m Money) ApplyDiscount(discount int64) Money {
+	discount = min(max(discount, 0), basisPoints)
+	return m.MulFrac(basisPoints-discount, basisPoints)
+}
@@ -0,0 +1,132 @@
+package domain
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestMoneyMulFrac(t *testing.T) {
+	tests := []struct {
+		name                   string
+		amount                 int64
+		numerator, denominator int64
+		want                   int64
+	}{
+		{name: "exact", amount: 1000, numerator: 1, denominator: 2, want: 500},
+		{name: "half rounds up", amount: 1001, numerator: 1, denominator: 2, want: 501},
+		{name: "below half rounds down", amount: 1000, numerator: 1, denominator: 3, want: 333},
+		{name: "above half rounds up", amount: 1000, numerator: 2, denominator: 3, want: 667},
+		{name: "smallest half", amount: 1, numerator: 1, denominator: 2, want: 1},
+		{name: "negative half rounds away from zero", amount: -1001, numerator: 1, denominator: 2, want: -501},
+		{name: "negative denominator", amount: 1001, numerator: 1, denominator: -2, want: -501},
+		{name: "negative numerator and denominator", amount: 1001, numerator: -1, denominator: -2, want: 501},
+		{name: "zero", amount: 0, numerator: 1, denominator: 3, want: 0},
+		{name: "zero numerator", amount: 1000, numerator: 0, denominator: 3, want: 0},
+		{name: "product above int64", amount: math.MaxInt64, numerator: basisPoints - 1, denominator: basisPoints, want: 9222449699651090329},
+		{name: "identity at max", amount: math.MaxInt64, numerator: basisPoints, denominator: basisPoints, want: math.MaxInt64},
+		{name: "identity at min", amount: math.MinInt64, numerator: basisPoints, denominator: basisPoints, want: math.MinInt64},
+		{name: "result above int64 saturates", amount: math.MaxInt64, numerator: 3, denominator: 2, want: math.MaxInt64},
+		{name: "result below int64 saturates", amount: math.MinInt64, numerator: 3, denominator: 2, want: math.MinInt64},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewMoney(tt.amount, CurrencyUSD).MulFrac(tt.numerator, tt.denominator)
+			if got != NewMoney(tt.want, CurrencyUSD) {
+				t.Errorf("%d * %d/%d = %+v, want %d USD", tt.amount, tt.numerator, tt.denominator, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneyApplyDiscount(t *testing.T) {
+	tests := []struct {
+		name     string
+		amount   int64
+		discount int64 // pontos-base
+		want     int64
+	}{
+		{name: "no discount", amount: 1001, discount: 0, want: 1001},
+		{name: "full discount", amount: 1001, discount: basisPoints, want: 0},
+		{name: "half of an odd amount rounds up", amount: 1001, discount: 5000, want: 501},
+		{name: "half of one cent", amount: 1, discount: 5000, want: 1},
+		{name: "exact half cent", amount: 150, discount: 9900, want: 2},
+		{name: "one basis point", amount: 10000, discount: 1, want: 9999},
+		{name: "discount above 100% clamps at zero", amount: 1001, discount: basisPoints + 1, want: 0},
+		{name: "huge discount clamps at zero", amount: 1001, discount: math.MaxInt64, want: 0},
+		{name: "negative discount never raises the price", amount: 1001, discount: -5000, want: 1001},
+		{name: "largest amount", amount: math.MaxInt64, discount: 1, want: 9222449699651090329},
+		{name: "largest amount fully discounted", amount: math.MaxInt64, discount: basisPoints, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewMoney(tt.amount, CurrencyBRL).ApplyDiscount(tt.discount)
+			if got != NewMoney(tt.want, CurrencyBRL) {
+				t.Errorf("%d with %d basis points off = %+v, want %d BRL", tt.amount, tt.discount, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMoneyAdd(t *testing.T) {
+	sum, err := NewMoney(1050, CurrencyBRL).Add(NewMoney(25, CurrencyBRL))
+	if err != nil || sum != NewMoney(1075, CurrencyBRL) {
+		t.Errorf("Add = %+v, %v, want 1075 BRL", sum, err)
+	}
+
+	if _, err := NewMoney(1050, CurrencyBRL).Add(NewMoney(25, CurrencyUSD)); !errors.Is(err, ErrCurrencyMismatch) {
+		t.Errorf("Add with another currency error = %v, want ErrCurrencyMismatch", err)
+	}
+}
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		value    string
+		currency Currency
+		want     int64
+		wantErr  error
+	}{
+		{value: "10", currency: CurrencyBRL, want: 1000},
+		{value: "10.5", currency: CurrencyBRL, want: 1050},
+		{value: "-10.50", currency: CurrencyUSD, want: -1050},
+		{value: "0.01", currency: CurrencyEUR, want: 1},
+		{value: "92233720368547758.07", currency: CurrencyBRL, want: math.MaxInt64},
+		{value: "92233720368547758.08", currency: CurrencyBRL, wantErr: ErrInvalidMoney},
+		{value: "10.001", currency: CurrencyBRL, wantErr: ErrInvalidMoney},
+		{value: "10.", currency: CurrencyBRL, wantErr: ErrInvalidMoney},
+		{value: ".5", currency: CurrencyBRL, wantErr: ErrInvalidMoney},
+		{value: "1e3", currency: CurrencyBRL, wantErr: ErrInvalidMoney},
+		{value: "+10", currency: CurrencyBRL, wantErr: ErrInvalidMoney},
+		{value: "", currency: CurrencyBRL, wantErr: ErrInvalidMoney},
+		{value: "10", currency: "JPY", wantErr: ErrUnsupportedCurrency},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.currency)+" "+tt.value, func(t *testing.T) {
+			got, err := ParseMoney(tt.value, tt.currency)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ParseMoney error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && got != NewMoney(tt.want, tt.currency) {
+				t.Errorf("ParseMoney = %+v, want %d %s", got, tt.want, tt.currency)
+			}
+		})
+	}
+}
+
+func TestMoneyString(t *testing.T) {
+	tests := []struct {
+		amount int64
+		want   string
+	}{
+		{0, "0.00"},
+		{5, "0.05"},
+		{1050, "10.50"},
+		{-1, "-0.01"},
+		{math.MaxInt64, "92233720368547758.07"},
+	}
+	for _, tt := range tests {
+		if got := NewMoney(tt.amount, CurrencyBRL).String(); got != tt.want {
+			t.Errorf("String(%d) = %q, want %q", tt.amount, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// maxPriceTierNameLength é o tamanho da coluna event_price_tiers.name.
+const maxPriceTierNameLength = 50
+
+var (
+	ErrPriceTierNameInvalid  = NewValidationError("price_tier_name_invalid", "price tier name is required and must have at most 50 characters")
+	ErrPriceTierNameTaken    = NewValidationError("price_tier_name_taken", "price tier name must be unique in the event")
+	ErrPriceTierPriceInvalid = NewValidationError("price_tier_price_invalid", "price tier price must be greater than zero and in the event currency")
+	ErrPriceTierNotFound     = NewValidationError("price_tier_not_found", "price tier not found in the event")
+	ErrPriceTierCurrency     = NewConflictError("price_tier_currency_locked", "event currency cannot change while the event has price tiers")
+)
+
+// PriceTier é uma categoria de preço do evento (VIP, Pista, Camarote). Os spots da categoria
+// custam Price em vez de Event.Price, que continua valendo para os spots sem categoria.
+type PriceTier struct {
+	ID      string
+	EventID string
+	Name    string
+	Price   Money // sempre na moeda do evento
+}
+
+// AddPriceTier cria uma categoria de preço no evento. Requer Tiers carregado (FindEventByID).
+func (e *Event) AddPriceTier(name string, price Money) (*PriceTier, error) {
+	if strings.TrimSpace(name) == "" || len(name) > maxPriceTierNameLength {
+		return nil, ErrPriceTierNameInvalid
+	}
+	if _, ok := e.PriceTierByName(name); ok {
+		return nil, fmt.Errorf("%w: %q", ErrPriceTierNameTaken, name)
+	}
+	if !price.IsPositive() || price.Currency != e.Price.Currency {
+		return nil, ErrPriceTierPriceInvalid
+	}
+
+	e.Tiers = append(e.Tiers, PriceTier{ID: uuid.New().String(), EventID: e.ID, Name: name, Price: price})
+	return &e.Tiers[len(e.Tiers)-1], nil
+}
+
+// PriceTierByName busca a categoria pelo nome.
+func (e *Event) PriceTierByName(name string) (*PriceTier, bool) {
+	for i := range e.Tiers {
+		if e.Tiers[i].Name == name {
+			return &e.Tiers[i], true
+		}
+	}
+	return nil, false
+}
+
+// PriceTier busca a categoria pelo ID.
+func (e *Event) PriceTier(tierID string) (*PriceTier, bool) {
+	for i := range e.Tiers {
+		if e.Tiers[i].ID == tierID {
+			return &e.Tiers[i], true
+		}
+	}
+	return nil, false
+}
+
+// AssignSectionTier coloca na categoria todos os spots do evento que pertencem ao setor do local.
+// Usado antes de persistir os spots materializados por AddVenueSpots.
+func (e *Event) AssignSectionTier(sectionID, tierID string) error {
+	if _, ok := e.PriceTier(tierID); !ok {
+		return ErrPriceTierNotFound
+	}
+	for i := range e.Spots {
+		if e.Spots[i].SectionID == sectionID {
+			e.Spots[i].TierID = tierID
+		}
+	}
+	return nil
+}
+
+// SpotPrice retorna o preço cheio do spot: o da sua categoria ou, sem categoria, Event.Price.
+func (e *Event) SpotPrice(spot *Spot) Money {
+	if tier, ok := e.PriceTier(spot.TierID); ok {
+		return tier.Price
+	}
+	return e.Price
+}
+
+// LowestPrice retorna o menor preço cheio do evento: Event.Price ou o da categoria mais barata.
+// É o preço usado pelos filtros e pela ordenação da listagem. Requer Tiers carregado.
+func (e *Event) LowestPrice() Money {
+	lowest := e.Price
+	for _, tier := range e.Tiers {
+		if tier.Price.Amount < lowest.Amount {
+			lowest = tier.Price
+		}
+	}
+	return lowest
+}
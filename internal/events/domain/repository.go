@@ -1,12 +1,106 @@
 package domain
 
+import (
+	"context"
+	"time"
+)
+
+// EventRepository persiste os eventos com os seus spots, ingressos, categorias de preço e tipos de ingresso.
 type EventRepository interface {
-	ListEvents() ([]Event, error)
-	FindEventByID(eventID string) (*Event, error)
-	FindSpotsByEventID(eventID string) ([]*Spot, error)
-	FindSpotByName(eventID, spotName string) (*Spot, error) // Atualizado
-	CreateEvent(event *Event) error
-	CreateSpot(spot *Spot) error
-	CreateTicket(ticket *Ticket) error
-	ReserveSpot(spotID, ticketID string) error
+	// ListEvents retorna uma página de eventos, com Tiers e sem Spots e Tickets, conforme query.
+	ListEvents(ctx context.Context, query EventQuery) ([]Event, error)
+	FindEventByID(ctx context.Context, eventID string) (*Event, error)
+	FindSpotsByEventID(ctx context.Context, eventID string) ([]*Spot, error)
+	FindSpotByName(ctx context.Context, eventID, spotName string) (*Spot, error) // Atualizado
+	CreateEvent(ctx context.Context, event *Event) error
+	// UpdateEvent persiste os dados do evento desde que Event.Version ainda seja a versão
+	// armazenada; caso contrário retorna ErrEventModified.
+	UpdateEvent(ctx context.Context, event *Event) error
+	// UpdateEventStatus muda o status do evento de from para to apenas se ele ainda estiver em from,
+	// e indica se a mudança aconteceu. Usado pelas transições automáticas (sold_out e reabertura).
+	UpdateEventStatus(ctx context.Context, eventID string, from, to EventStatus) (bool, error)
+	// LockEventStatus retorna o status do evento; dentro de RunInTx, bloqueia o evento até o fim
+	// da transação, serializando a compra com mudanças de status como o cancelamento.
+	LockEventStatus(ctx context.Context, eventID string) (EventStatus, error)
+	// CountUnsoldSpots conta os spots do evento que ainda não foram vendidos.
+	CountUnsoldSpots(ctx context.Context, eventID string) (int, error)
+	// MarkEventTicketsForRefund marca para reembolso os ingressos emitidos do evento e retorna quantos foram marcados.
+	MarkEventTicketsForRefund(ctx context.Context, eventID string) (int64, error)
+	// DeleteEvent remove o evento e os seus spots; o evento não pode ter ingressos.
+	DeleteEvent(ctx context.Context, eventID string) error
+	CreateSpot(ctx context.Context, spot *Spot) error
+	// CreatePriceTier persiste uma categoria de preço do evento; FindEventByID as carrega em Event.Tiers.
+	CreatePriceTier(ctx context.Context, tier *PriceTier) error
+	// CreateTicketKindRule persiste um tipo de ingresso oferecido pelo evento; FindEventByID
+	// carrega as regras em Event.TicketKinds.
+	CreateTicketKindRule(ctx context.Context, rule *TicketKindRule) error
+	// DeleteSpot remove o spot desde que Spot.Version ainda seja a versão armazenada;
+	// caso contrário retorna ErrSpotModified.
+	DeleteSpot(ctx context.Context, spot *Spot) error
+	CreateTicket(ctx context.Context, ticket *Ticket) error
+	// ReserveSpot persiste a venda do spot (ver Spot.Reserve) desde que Spot.Version
+	// ainda seja a versão armazenada; caso contrário retorna ErrSpotAlreadyReserved.
+	ReserveSpot(ctx context.Context, spot *Spot) error
+	// HoldSpot persiste a retenção do spot; retorna ErrSpotHeld se outra sessão o segurou antes.
+	HoldSpot(ctx context.Context, spot *Spot) error
+	// ReleaseExpiredHolds libera os spots cuja retenção expirou antes de now.
+	ReleaseExpiredHolds(ctx context.Context, now time.Time) (int64, error)
+}
+
+// VenueRepository persiste os locais e os seus mapas de assentos.
+type VenueRepository interface {
+	// CreateVenue persiste o local com todo o seu mapa de setores, fileiras e assentos.
+	CreateVenue(ctx context.Context, venue *Venue) error
+	// FindVenueByID retorna o local com o mapa completo; retorna ErrVenueNotFound se ele não existir.
+	FindVenueByID(ctx context.Context, venueID string) (*Venue, error)
+	// ListVenues retorna os locais ordenados por nome, sem Sections.
+	ListVenues(ctx context.Context) ([]Venue, error)
+}
+
+// CouponRepository persiste os cupons e os seus resgates.
+type CouponRepository interface {
+	// CreateCoupon persiste o cupom; retorna ErrCouponCodeTaken se o código já existir.
+	CreateCoupon(ctx context.Context, coupon *Coupon) error
+	// FindCouponByCode busca o cupom pelo código normalizado; retorna ErrCouponNotFound se ele não
+	// existir. Dentro de RunInTx, bloqueia o cupom até o fim da transação, serializando os resgates.
+	FindCouponByCode(ctx context.Context, code string) (*Coupon, error)
+	// CountCouponRedemptions conta as compras que usaram o cupom, no total e as do e-mail informado.
+	// Dentro de RunInTx, conta também os resgates confirmados depois do início da transação.
+	CountCouponRedemptions(ctx context.Context, couponID, email string) (CouponUsage, error)
+	CreateCouponRedemption(ctx context.Context, redemption *CouponRedemption) error
+}
+
+// CheckoutSagaRepository persiste o andamento das sagas de compra.
+type CheckoutSagaRepository interface {
+	CreateCheckoutSaga(ctx context.Context, saga *CheckoutSaga) error
+	UpdateCheckoutSaga(ctx context.Context, saga *CheckoutSaga) error
+	// FindPendingCheckoutSagas retorna as sagas em compensação e as que ficaram paradas
+	// (iniciadas ou reservadas) desde antes de staleBefore, por exemplo após um restart.
+	FindPendingCheckoutSagas(ctx context.Context, staleBefore time.Time) ([]*CheckoutSaga, error)
+}
+
+// IdempotencyRepository persiste as chaves de idempotência e as respostas guardadas com elas.
+type IdempotencyRepository interface {
+	// CreateIdempotencyKey registra a chave; retorna ErrIdempotencyKeyExists se ela já existir.
+	CreateIdempotencyKey(ctx context.Context, key *IdempotencyKey) error
+	FindIdempotencyKey(ctx context.Context, key string) (*IdempotencyKey, error)
+	// CompleteIdempotencyKey armazena a resposta de uma chave em execução.
+	CompleteIdempotencyKey(ctx context.Context, key *IdempotencyKey) error
+	// DeleteIdempotencyKey remove a chave se ela ainda estiver em execução, liberando uma nova tentativa.
+	DeleteIdempotencyKey(ctx context.Context, key string) error
+	// DeleteExpiredIdempotencyKeys remove as chaves expiradas antes de now.
+	DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error)
+}
+
+// Repositories reúne os repositórios de um mesmo banco, permitindo que um caso de uso
+// altere eventos, locais, cupons, sagas e chaves de idempotência em uma única transação.
+type Repositories interface {
+	EventRepository
+	VenueRepository
+	CouponRepository
+	CheckoutSagaRepository
+	IdempotencyRepository
+	// RunInTx executa fn como uma unidade de trabalho: todas as operações feitas
+	// através dos repositórios recebidos são confirmadas juntas ou nenhuma delas é persistida.
+	RunInTx(ctx context.Context, fn func(tx Repositories) error) error
 }
@@ -1,14 +1,9 @@
 package domain
 
-import (
-	"errors"
-	"fmt"
-)
-
 type spotService struct{}
 
 var (
-	ErrInvalidQuantity = errors.New("quantity must be greater than zero")
+	ErrInvalidQuantity = NewValidationError("invalid_quantity", "quantity must be greater than zero")
 )
 
 func NewSpotService() *spotService {
@@ -21,12 +16,24 @@ func (s *spotService) GenerateSports(event *Event, quantity int) error {
 	}
 
 	for i := 0; i < quantity; i++ {
-		spotName := fmt.Sprintf("%c%d", 'A'+i/10, i%10+1)
-		spot, err := NewSpot(event, spotName)
-		if err != nil {
+		if _, err := event.AddSpot(DefaultSpotName(i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GenerateSpotsFromLayout gera os spots do evento conforme a planta.
+func (s *spotService) GenerateSpotsFromLayout(event *Event, layout SpotLayout) error {
+	names, err := layout.SpotNames()
+	if err != nil {
+		return err
+	}
+
+	for _, name := range names {
+		if _, err := event.AddSpot(name); err != nil {
 			return err
 		}
-		event.Spots = append(event.Spots, *spot)
 	}
 	return nil
 }
@@ -1,34 +1,47 @@
 package domain
 
 import (
-	"errors"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 var (
-	ErrSpotInvalidNumber       = errors.New("invalid spot number")
-	ErrSpotNotFound            = errors.New("spot not found")
-	ErrSpotAlreadyReserved     = errors.New("spot already reserved")
-	ErrSpotNameTwoCharacters   = errors.New("spot name must be at least 2 characters long")
-	ErrSpotNameRequired        = errors.New("spot name is required")
-	ErrSpotNameStartWithLatter = errors.New("spot name must start with a latter")
-	ErrSpotNameStartWithNumber = errors.New("spot name must end with a number")
+	ErrSpotInvalidNumber       = NewValidationError("spot_invalid_number", "invalid spot number")
+	ErrSpotNotFound            = NewNotFoundError("spot_not_found", "spot not found")
+	ErrSpotAlreadyReserved     = NewConflictError("spot_already_reserved", "spot already reserved")
+	ErrSpotNameTwoCharacters   = NewValidationError("spot_name_too_short", "spot name must be at least 2 characters long")
+	ErrSpotNameRequired        = NewValidationError("spot_name_required", "spot name is required")
+	ErrSpotNameStartWithLatter = NewValidationError("spot_name_invalid_start", "spot name must start with a row of letters or a row number followed by -")
+	ErrSpotNameStartWithNumber = NewValidationError("spot_name_invalid_end", "spot name must end with a seat number")
+	ErrSpotNameTooLong         = NewValidationError("spot_name_too_long", "spot name must have at most 10 characters")
+	ErrSpotHeld                = NewConflictError("spot_held", "spot is held by another session")
+	ErrSpotNotHeld             = NewConflictError("spot_not_held", "spot is not held by this session")
+	ErrSpotHoldOwnerRequired   = NewValidationError("spot_hold_owner_required", "spot hold owner is required")
+	ErrSpotSold                = NewConflictError("spot_sold", "sold spot cannot be deleted")
+	ErrSpotModified            = NewConflictError("spot_modified", "spot was modified by another request")
 )
 
 type SpotStatus string
 
 const (
 	SpotStatusAvailable SpotStatus = "available"
+	SpotStatusReserved  SpotStatus = "reserved" // segurado temporariamente por uma sessão até HoldExpiresAt
 	SpotStatusSold      SpotStatus = "sold"
 )
 
 type Spot struct {
-	ID       string
-	EventID  string
-	Name     string // all name uses the rule: Letter+Number. Ex: A1, B2, C3, etc.
-	Status   SpotStatus
-	TicketID string
+	ID            string
+	EventID       string
+	SectionID     string // setor do local (Venue) a que o spot pertence; vazio sem mapa
+	TierID        string // categoria de preço (PriceTier); vazio para o preço do evento
+	Name          string // all name uses the rule: Letter+Number. Ex: A1, B2, C3, etc.
+	Status        SpotStatus
+	TicketID      string
+	HoldOwner     string    // dono da retenção (SpotHoldOwner) enquanto Status == SpotStatusReserved
+	HoldExpiresAt time.Time // instante em que a retenção expira
+	Version       int       // incrementada a cada alteração persistida (concorrência otimista)
 }
 
 func NewSpot(event *Event, name string) (*Spot, error) {
@@ -54,7 +67,11 @@ func NewSpot(event *Event, name string) (*Spot, error) {
 	//?Validate return
 }
 
-// spot checks if the spot data is valid
+// maxSpotNameLength é o tamanho da coluna spots.name.
+const maxSpotNameLength = 10
+
+// spot checks if the spot data is valid. The name is a row label followed by the seat number:
+// one or more letters and a number ("A1", "AB12"), or two numbers separated by a hyphen ("12-3").
 func (s *Spot) Validate() error {
 	if len(s.Name) == 0 {
 		return ErrSpotNameRequired
@@ -64,21 +81,101 @@ func (s *Spot) Validate() error {
 		return ErrSpotNameTwoCharacters
 	}
 
-	if s.Name[0] < 'A' || s.Name[0] > 'Z' {
-		return ErrSpotNameStartWithLatter
+	if len(s.Name) > maxSpotNameLength {
+		return ErrSpotNameTooLong
+	}
+
+	row, seat, numericRow := strings.Cut(s.Name, "-")
+	if numericRow {
+		if !isSpotNumber(row) {
+			return ErrSpotNameStartWithLatter
+		}
+	} else {
+		letters := strings.IndexFunc(s.Name, func(r rune) bool { return r < 'A' || r > 'Z' })
+		if letters == 0 {
+			return ErrSpotNameStartWithLatter
+		}
+		if letters < 0 {
+			return ErrSpotNameStartWithNumber
+		}
+		seat = s.Name[letters:]
 	}
 
-	if s.Name[1] < '0' || s.Name[1] > '9' {
+	if !isSpotNumber(seat) {
 		return ErrSpotNameStartWithNumber
 	}
 	return nil
 }
 
+// isSpotNumber indica se value é um número positivo sem zeros à esquerda.
+func isSpotNumber(value string) bool {
+	if value == "" || value[0] == '0' {
+		return false
+	}
+	for _, c := range value {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+	return true
+}
+
 func (s *Spot) Reserve(TicketID string) error {
 	if s.Status == SpotStatusSold {
 		return ErrSpotAlreadyReserved
 	}
 	s.Status = SpotStatusSold
 	s.TicketID = TicketID
+	s.HoldOwner = ""
+	s.HoldExpiresAt = time.Time{}
+	return nil
+}
+
+// Hold segura o spot para owner até expiresAt, impedindo que outras sessões o comprem.
+// Uma retenção expirada pode ser tomada por outra sessão; a própria sessão pode renovar a sua.
+func (s *Spot) Hold(owner string, expiresAt, now time.Time) error {
+	if owner == "" {
+		return ErrSpotHoldOwnerRequired
+	}
+	if s.Status == SpotStatusSold {
+		return ErrSpotAlreadyReserved
+	}
+	if s.IsHeld(now) && s.HoldOwner != owner {
+		return ErrSpotHeld
+	}
+	s.Status = SpotStatusReserved
+	s.HoldOwner = owner
+	s.HoldExpiresAt = expiresAt
+	return nil
+}
+
+// ReleaseHold devolve um spot segurado para o status disponível.
+func (s *Spot) ReleaseHold() {
+	if s.Status != SpotStatusReserved {
+		return
+	}
+	s.Status = SpotStatusAvailable
+	s.HoldOwner = ""
+	s.HoldExpiresAt = time.Time{}
+}
+
+// IsHeld indica se o spot está segurado por alguma sessão em now.
+func (s *Spot) IsHeld(now time.Time) bool {
+	return s.Status == SpotStatusReserved && now.Before(s.HoldExpiresAt)
+}
+
+// IsHeldBy indica se o spot está segurado por owner em now.
+func (s *Spot) IsHeldBy(owner string, now time.Time) bool {
+	return s.IsHeld(now) && s.HoldOwner == owner
+}
+
+// CanDelete indica se o spot pode ser removido em now: spots vendidos ou segurados por uma sessão não podem.
+func (s *Spot) CanDelete(now time.Time) error {
+	if s.Status == SpotStatusSold {
+		return ErrSpotSold
+	}
+	if s.IsHeld(now) {
+		return ErrSpotHeld
+	}
 	return nil
 }
@@ -0,0 +1,166 @@
+package domain
+
+import (
+	"strconv"
+	"strings"
+)
+
+// DefaultSeatsPerRow é a quantidade de spots por fileira quando apenas a quantidade de spots é informada.
+const DefaultSeatsPerRow = 10
+
+// RowLabelScheme define como as fileiras são rotuladas.
+type RowLabelScheme string
+
+const (
+	RowLabelLetters RowLabelScheme = "letters" // A..Z, AA..AZ, BA..ZZ, AAA..; spots "A1", "AB12"
+	RowLabelNumeric RowLabelScheme = "numeric" // 1, 2, 3..; spots "1-1", "12-30"
+)
+
+var (
+	ErrInvalidSpotLayout = NewValidationError("invalid_spot_layout", "spot layout must have at least one row and one seat per row")
+	ErrSpotNameTaken     = NewConflictError("spot_name_taken", "spot name already exists in the event")
+)
+
+// IsValid indica se o esquema é uma das constantes RowLabel*.
+func (s RowLabelScheme) IsValid() bool {
+	return s == RowLabelLetters || s == RowLabelNumeric
+}
+
+// SpotLayout descreve a planta dos spots de um evento: fileiras rotuladas conforme LabelScheme,
+// cada uma com os assentos numerados a partir de 1.
+type SpotLayout struct {
+	Rows        int            // quantidade de fileiras; ignorada quando RowSeats é informado
+	SeatsPerRow int            // assentos de cada fileira; ignorado quando RowSeats é informado
+	RowSeats    []int          // assentos de cada fileira, em ordem
+	LabelScheme RowLabelScheme // padrão: RowLabelLetters
+	SkipLabels  []string       // rótulos de fileira que não são usados, ex.: "I" e "O"
+}
+
+// seats retorna a quantidade de assentos de cada fileira.
+func (l SpotLayout) seats() []int {
+	if len(l.RowSeats) > 0 {
+		return l.RowSeats
+	}
+	seats := make([]int, max(l.Rows, 0))
+	for i := range seats {
+		seats[i] = l.SeatsPerRow
+	}
+	return seats
+}
+
+func (l SpotLayout) scheme() RowLabelScheme {
+	if l.LabelScheme == "" {
+		return RowLabelLetters
+	}
+	return l.LabelScheme
+}
+
+// TotalSpots retorna a quantidade de spots da planta.
+func (l SpotLayout) TotalSpots() int {
+	total := 0
+	for _, n := range l.seats() {
+		total += n
+	}
+	return total
+}
+
+func (l SpotLayout) Validate() error {
+	if !l.scheme().IsValid() {
+		return ErrInvalidSpotLayout
+	}
+	seats := l.seats()
+	if len(seats) == 0 {
+		return ErrInvalidSpotLayout
+	}
+	for _, n := range seats {
+		if n <= 0 {
+			return ErrInvalidSpotLayout
+		}
+	}
+	return nil
+}
+
+// RowPlan é uma fileira da planta: o rótulo e a quantidade de assentos, numerados a partir de 1.
+type RowPlan struct {
+	Label string
+	Seats int
+}
+
+// Plan retorna as fileiras da planta, em ordem, pulando os rótulos de SkipLabels.
+func (l SpotLayout) Plan() ([]RowPlan, error) {
+	if err := l.Validate(); err != nil {
+		return nil, err
+	}
+
+	skip := make(map[string]bool, len(l.SkipLabels))
+	for _, label := range l.SkipLabels {
+		skip[strings.ToUpper(strings.TrimSpace(label))] = true
+	}
+
+	scheme := l.scheme()
+	seats := l.seats()
+	plan := make([]RowPlan, len(seats))
+	index := 0
+	for i, n := range seats {
+		label := RowLabel(scheme, index)
+		for skip[label] {
+			index++
+			label = RowLabel(scheme, index)
+		}
+		index++
+		plan[i] = RowPlan{Label: label, Seats: n}
+	}
+	return plan, nil
+}
+
+// SpotNames gera os nomes dos spots, fileira por fileira.
+func (l SpotLayout) SpotNames() ([]string, error) {
+	plan, err := l.Plan()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, l.TotalSpots())
+	for _, row := range plan {
+		for seat := 1; seat <= row.Seats; seat++ {
+			names = append(names, SeatSpotName(row.Label, seat))
+		}
+	}
+	return names, nil
+}
+
+// RowLabel retorna o rótulo da fileira de índice index (a partir de 0). Com letras, a sequência
+// segue a das colunas de uma planilha: A..Z, AA..AZ, BA..ZZ, AAA...
+func RowLabel(scheme RowLabelScheme, index int) string {
+	if scheme == RowLabelNumeric {
+		return strconv.Itoa(index + 1)
+	}
+	var label []byte
+	for n := index + 1; n > 0; n = (n - 1) / 26 {
+		label = append([]byte{byte('A' + (n-1)%26)}, label...)
+	}
+	return string(label)
+}
+
+// SpotName monta o nome do spot a partir do rótulo da fileira e do número do assento.
+// Fileiras numéricas usam um hífen para separar os números, ex.: "12-3".
+func SpotName(scheme RowLabelScheme, row string, seat int) string {
+	if scheme == RowLabelNumeric {
+		return row + "-" + strconv.Itoa(seat)
+	}
+	return row + strconv.Itoa(seat)
+}
+
+// SeatSpotName monta o nome do spot de um assento, identificando o esquema pelo rótulo da fileira.
+func SeatSpotName(row string, seat int) string {
+	if row != "" && row[0] >= '0' && row[0] <= '9' {
+		return SpotName(RowLabelNumeric, row, seat)
+	}
+	return SpotName(RowLabelLetters, row, seat)
+}
+
+// DefaultSpotName retorna o nome do spot de índice index (a partir de 0) na planta padrão:
+// fileiras de A em diante com DefaultSeatsPerRow assentos cada (A1..A10, B1.., Z10, AA1..).
+func DefaultSpotName(index int) string {
+	return SpotName(RowLabelLetters, RowLabel(RowLabelLetters, index/DefaultSeatsPerRow), index%DefaultSeatsPerRow+1)
+}
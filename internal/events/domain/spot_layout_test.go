@@ -0,0 +1,147 @@
+package domain
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestRowLabel(t *testing.T) {
+	tests := []struct {
+		scheme RowLabelScheme
+		index  int
+		want   string
+	}{
+		{RowLabelLetters, 0, "A"},
+		{RowLabelLetters, 1, "B"},
+		{RowLabelLetters, 25, "Z"},
+		{RowLabelLetters, 26, "AA"},
+		{RowLabelLetters, 27, "AB"},
+		{RowLabelLetters, 51, "AZ"},
+		{RowLabelLetters, 52, "BA"},
+		{RowLabelLetters, 701, "ZZ"},
+		{RowLabelLetters, 702, "AAA"},
+		{RowLabelLetters, 18277, "ZZZ"},
+		{RowLabelNumeric, 0, "1"},
+		{RowLabelNumeric, 99, "100"},
+	}
+	for _, tt := range tests {
+		if got := RowLabel(tt.scheme, tt.index); got != tt.want {
+			t.Errorf("RowLabel(%s, %d) = %q, want %q", tt.scheme, tt.index, got, tt.want)
+		}
+	}
+}
+
+func TestDefaultSpotName(t *testing.T) {
+	tests := []struct {
+		index int
+		want  string
+	}{
+		{0, "A1"},
+		{9, "A10"},
+		{10, "B1"},
+		{259, "Z10"},
+		{260, "AA1"},
+		{269, "AA10"},
+		{270, "AB1"},
+	}
+	for _, tt := range tests {
+		if got := DefaultSpotName(tt.index); got != tt.want {
+			t.Errorf("DefaultSpotName(%d) = %q, want %q", tt.index, got, tt.want)
+		}
+	}
+}
+
+func TestSpotLayoutSpotNames(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout SpotLayout
+		want   []string
+	}{
+		{name: "rows and seats", layout: SpotLayout{Rows: 2, SeatsPerRow: 3}, want: []string{"A1", "A2", "A3", "B1", "B2", "B3"}},
+		{name: "seats per row", layout: SpotLayout{RowSeats: []int{2, 1}, Rows: 5, SeatsPerRow: 5}, want: []string{"A1", "A2", "B1"}},
+		{name: "numeric rows", layout: SpotLayout{Rows: 2, SeatsPerRow: 2, LabelScheme: RowLabelNumeric}, want: []string{"1-1", "1-2", "2-1", "2-2"}},
+		{name: "skipped labels", layout: SpotLayout{Rows: 3, SeatsPerRow: 1, SkipLabels: []string{" b ", "D"}}, want: []string{"A1", "C1", "E1"}},
+		{name: "skipped numeric labels", layout: SpotLayout{Rows: 2, SeatsPerRow: 1, LabelScheme: RowLabelNumeric, SkipLabels: []string{"1"}}, want: []string{"2-1", "3-1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.layout.SpotNames()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SpotNames = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestSpotLayoutRollover garante que a planta passa de Z para AA e que todos os nomes gerados
+// são aceitos por Spot.Validate.
+func TestSpotLayoutRollover(t *testing.T) {
+	layout := SpotLayout{Rows: 28, SeatsPerRow: 2}
+	names, err := layout.SpotNames()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != layout.TotalSpots() || len(names) != 56 {
+		t.Fatalf("got %d names, want %d", len(names), layout.TotalSpots())
+	}
+	if got, want := names[50:], []string{"Z1", "Z2", "AA1", "AA2", "AB1", "AB2"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("last names = %q, want %q", got, want)
+	}
+
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if seen[name] {
+			t.Errorf("duplicated name %q", name)
+		}
+		seen[name] = true
+		if err := (&Spot{Name: name}).Validate(); err != nil {
+			t.Errorf("name %q: %v", name, err)
+		}
+	}
+
+	// A planta padrão gera os mesmos nomes que DefaultSpotName.
+	defaults, err := SpotLayout{Rows: 30, SeatsPerRow: DefaultSeatsPerRow}.SpotNames()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, name := range defaults {
+		if name != DefaultSpotName(i) {
+			t.Fatalf("name %d = %q, want %q", i, name, DefaultSpotName(i))
+		}
+		if err := (&Spot{Name: name}).Validate(); err != nil {
+			t.Errorf("default name %q: %v", name, err)
+		}
+	}
+}
+
+func TestSpotLayoutBounds(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout SpotLayout
+	}{
+		{name: "empty", layout: SpotLayout{}},
+		{name: "no rows", layout: SpotLayout{Rows: 0, SeatsPerRow: 10}},
+		{name: "negative rows", layout: SpotLayout{Rows: -1, SeatsPerRow: 10}},
+		{name: "no seats", layout: SpotLayout{Rows: 2, SeatsPerRow: 0}},
+		{name: "negative seats", layout: SpotLayout{Rows: 2, SeatsPerRow: -3}},
+		{name: "row without seats", layout: SpotLayout{RowSeats: []int{3, 0, 2}}},
+		{name: "unknown scheme", layout: SpotLayout{Rows: 1, SeatsPerRow: 1, LabelScheme: "roman"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.layout.SpotNames(); !errors.Is(err, ErrInvalidSpotLayout) {
+				t.Errorf("SpotNames error = %v, want ErrInvalidSpotLayout", err)
+			}
+		})
+	}
+
+	// Um assento só: o menor nome válido.
+	names, err := SpotLayout{Rows: 1, SeatsPerRow: 1}.SpotNames()
+	if err != nil || !reflect.DeepEqual(names, []string{"A1"}) {
+		t.Errorf("SpotNames = %q, %v, want [A1]", names, err)
+	}
+}
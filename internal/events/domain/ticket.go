@@ -1,11 +1,8 @@
 package domain
 
-import (
-	"errors"
-
-	"github.com/google/uuid"
-)
+import "github.com/google/uuid"
 
+// TicketKind é o código de um tipo de ingresso; as regras de cada tipo ficam em TicketKindRule.
 type TicketKind string
 
 const (
@@ -13,45 +10,69 @@ const (
 	TicketKindFull TicketKind = "full"
 )
 
-var ErrTicketPriceZero = errors.New("Ticket price must be greater than zero")
+// TicketStatus indica se o ingresso é válido ou aguarda reembolso.
+type TicketStatus string
+
+const (
+	TicketStatusIssued        TicketStatus = "issued"
+	TicketStatusRefundPending TicketStatus = "refund_pending" // o evento foi cancelado
+)
+
+// HalfPriceDiscount é o desconto da meia-entrada, em pontos-base.
+const HalfPriceDiscount = 5000
+
+var (
+	ErrTicketPriceInvalid = NewValidationError("ticket_price_invalid", "ticket price must not be negative")
+	ErrInvalidTicketKind  = NewValidationError("invalid_ticket_kind", "invalid ticket type")
+)
 
 type Ticket struct {
 	ID         string
 	EventID    string
 	Spot       *Spot
 	TicketKind TicketKind
-	Price      float64
-}
-
-func IsValidTicketKind(ticketKind TicketKind) bool {
-	return ticketKind == TicketKindHalf || ticketKind == TicketKindFull
+	Price      Money
+	Status     TicketStatus
+	// EligibilityDocument é o número do comprovante informado no checkout, quando o tipo exige um.
+	EligibilityDocument string
+	// CouponDiscount é o desconto dos cupons já abatido de Price (ver ApplyCoupons).
+	CouponDiscount Money
 }
 
-func (t *Ticket) CalculatePrice() {
-	if t.TicketKind == TicketKindHalf {
-		t.Price /= 2
-	}
+// CalculatePrice aplica ao preço cheio do spot (Event.SpotPrice) o desconto do tipo de ingresso, arredondando conforme a regra de Money.
+func (t *Ticket) CalculatePrice(rule *TicketKindRule) {
+	t.Price = t.Price.ApplyDiscount(rule.Discount)
 }
 
+// Validate verifica o preço; ingressos de cortesia (desconto de 100%) custam zero.
 func (t *Ticket) Validate() error {
-	if t.Price <= 0 {
-		return ErrTicketPriceZero
+	if t.Price.Amount < 0 {
+		return ErrTicketPriceInvalid
 	}
 	return nil
 }
 
-func NewTicket(event *Event, spot *Spot, ticketKind TicketKind) (*Ticket, error) {
-	if !IsValidTicketKind(ticketKind) {
-		return nil, errors.New("invalid ticket type")
+// NewTicket emite um ingresso do tipo informado, que o evento precisa oferecer (Event.TicketKindRule).
+// document é o comprovante de elegibilidade, guardado apenas quando o tipo exige um.
+func NewTicket(event *Event, spot *Spot, ticketKind TicketKind, document string) (*Ticket, error) {
+	rule, err := event.TicketKindRule(ticketKind)
+	if err != nil {
+		return nil, err
 	}
+	price := event.SpotPrice(spot)
 	ticket := &Ticket{
-		ID:         uuid.New().String(),
-		EventID:    event.ID,
-		Spot:       spot,
-		TicketKind: ticketKind,
-		Price:      event.Price,
+		ID:             uuid.New().String(),
+		EventID:        event.ID,
+		Spot:           spot,
+		TicketKind:     ticketKind,
+		Price:          price,
+		Status:         TicketStatusIssued,
+		CouponDiscount: NewMoney(0, price.Currency),
+	}
+	if rule.Document != EligibilityDocumentNone {
+		ticket.EligibilityDocument = document
 	}
-	ticket.CalculatePrice()
+	ticket.CalculatePrice(rule)
 	if err := ticket.Validate(); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,170 @@
+package domain
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Tipos de ingresso conhecidos. Cada evento escolhe quais oferece, e com quais regras, em
+// Event.TicketKinds; outros códigos também são aceitos (ver TicketKind.IsValid).
+const (
+	TicketKindStudent  TicketKind = "student"  // meia-entrada de estudante
+	TicketKindSenior   TicketKind = "senior"   // meia-entrada de idoso
+	TicketKindPCD      TicketKind = "pcd"      // meia-entrada de pessoa com deficiência, com acompanhante
+	TicketKindCourtesy TicketKind = "courtesy" // cortesia
+)
+
+// maxTicketKindLength é o tamanho das colunas ticket_kind.
+const maxTicketKindLength = 20
+
+// maxCompanionSeats limita os acompanhantes cobertos por um comprovante.
+const maxCompanionSeats = 3
+
+var ticketKindPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// IsValid indica se o código tem o formato aceito: letras minúsculas, dígitos e "_", começando
+// por uma letra, com até 20 caracteres. Se o evento oferece o tipo é decidido por Event.TicketKindRule.
+func (k TicketKind) IsValid() bool {
+	return len(k) <= maxTicketKindLength && ticketKindPattern.MatchString(string(k))
+}
+
+// EligibilityDocument é o comprovante que o comprador precisa informar no checkout para ter
+// direito ao benefício do tipo de ingresso. O documento é conferido na entrada do evento.
+type EligibilityDocument string
+
+const (
+	EligibilityDocumentNone       EligibilityDocument = ""
+	EligibilityDocumentStudentID  EligibilityDocument = "student_id"             // carteira de identificação estudantil
+	EligibilityDocumentIDCard     EligibilityDocument = "id_card"                // documento oficial com foto (idade)
+	EligibilityDocumentDisability EligibilityDocument = "disability_certificate" // laudo ou cartão de benefício
+	EligibilityDocumentInvitation EligibilityDocument = "invitation_code"        // código do convite
+)
+
+// IsValid indica se o comprovante é uma das constantes EligibilityDocument*.
+func (d EligibilityDocument) IsValid() bool {
+	switch d {
+	case EligibilityDocumentNone, EligibilityDocumentStudentID, EligibilityDocumentIDCard, EligibilityDocumentDisability, EligibilityDocumentInvitation:
+		return true
+	}
+	return false
+}
+
+var (
+	ErrInvalidTicketKindRule       = NewValidationError("invalid_ticket_kind_rule", "invalid ticket kind rule")
+	ErrTicketKindQuotaExceeded     = NewConflictError("ticket_kind_quota_exceeded", "ticket kind quota exceeded for this event")
+	ErrEligibilityDocumentRequired = NewValidationError("eligibility_document_required", "ticket kind requires an eligibility document")
+	ErrEligibilityTooManyTickets   = NewValidationError("eligibility_too_many_tickets", "an eligibility document covers one ticket plus the allowed companions")
+)
+
+// TicketKindRule é a regra de um tipo de ingresso em um evento.
+type TicketKindRule struct {
+	EventID string
+	Kind    TicketKind
+	Name    string
+	// Discount é o desconto sobre o preço cheio do spot, em pontos-base (5000 = 50%, 10000 = cortesia).
+	Discount int64
+	// QuotaPercent é a parcela máxima da capacidade do evento vendida neste tipo; 0 = sem cota.
+	QuotaPercent int
+	// Document é o comprovante exigido no checkout; vazio quando o tipo não exige comprovante.
+	Document EligibilityDocument
+	// CompanionSeats é quantos acompanhantes um comprovante cobre, com o mesmo benefício.
+	CompanionSeats int
+}
+
+// DefaultTicketKindRules são os tipos oferecidos pelos eventos sem regras próprias:
+// inteira e meia-entrada de 50%, sem cota nem comprovante.
+func DefaultTicketKindRules() []TicketKindRule {
+	return []TicketKindRule{
+		{Kind: TicketKindFull, Name: "Inteira"},
+		{Kind: TicketKindHalf, Name: "Meia-entrada", Discount: HalfPriceDiscount},
+	}
+}
+
+func (r *TicketKindRule) Validate() error {
+	switch {
+	case !r.Kind.IsValid():
+		return fmt.Errorf("%w: kind %q must be lowercase letters, digits or _ with at most %d characters", ErrInvalidTicketKindRule, r.Kind, maxTicketKindLength)
+	case strings.TrimSpace(r.Name) == "" || len(r.Name) > 100:
+		return fmt.Errorf("%w: %s: name is required and must have at most 100 characters", ErrInvalidTicketKindRule, r.Kind)
+	case r.Discount < 0 || r.Discount > basisPoints:
+		return fmt.Errorf("%w: %s: discount must be between 0 and %d basis points", ErrInvalidTicketKindRule, r.Kind, basisPoints)
+	case r.QuotaPercent < 0 || r.QuotaPercent > 100:
+		return fmt.Errorf("%w: %s: quota must be between 0 and 100 percent", ErrInvalidTicketKindRule, r.Kind)
+	case !r.Document.IsValid():
+		return fmt.Errorf("%w: %s: unknown eligibility document %q", ErrInvalidTicketKindRule, r.Kind, r.Document)
+	case r.CompanionSeats < 0 || r.CompanionSeats > maxCompanionSeats:
+		return fmt.Errorf("%w: %s: companion seats must be between 0 and %d", ErrInvalidTicketKindRule, r.Kind, maxCompanionSeats)
+	case r.CompanionSeats > 0 && r.Document == EligibilityDocumentNone:
+		return fmt.Errorf("%w: %s: companion seats require an eligibility document", ErrInvalidTicketKindRule, r.Kind)
+	}
+	return nil
+}
+
+// CheckEligibility verifica se o comprovante informado cobre quantity ingressos deste tipo.
+func (r *TicketKindRule) CheckEligibility(document string, quantity int) error {
+	if r.Document == EligibilityDocumentNone {
+		return nil
+	}
+	if strings.TrimSpace(document) == "" {
+		return fmt.Errorf("%w: %s", ErrEligibilityDocumentRequired, r.Document)
+	}
+	if quantity > 1+r.CompanionSeats {
+		return fmt.Errorf("%w: %s allows %d tickets per document", ErrEligibilityTooManyTickets, r.Kind, 1+r.CompanionSeats)
+	}
+	return nil
+}
+
+// AddTicketKindRule passa a oferecer o tipo de ingresso no evento. Sem nenhuma regra própria
+// o evento oferece DefaultTicketKindRules; a primeira regra adicionada as substitui.
+func (e *Event) AddTicketKindRule(rule TicketKindRule) error {
+	if err := rule.Validate(); err != nil {
+		return err
+	}
+	for _, existing := range e.TicketKinds {
+		if existing.Kind == rule.Kind {
+			return fmt.Errorf("%w: %s is duplicated", ErrInvalidTicketKindRule, rule.Kind)
+		}
+	}
+	rule.EventID = e.ID
+	e.TicketKinds = append(e.TicketKinds, rule)
+	return nil
+}
+
+// TicketKindRules retorna os tipos de ingresso oferecidos pelo evento.
+func (e *Event) TicketKindRules() []TicketKindRule {
+	if len(e.TicketKinds) == 0 {
+		return DefaultTicketKindRules()
+	}
+	return e.TicketKinds
+}
+
+// TicketKindRule busca a regra do tipo de ingresso; retorna ErrInvalidTicketKind se o evento não o oferece.
+func (e *Event) TicketKindRule(kind TicketKind) (*TicketKindRule, error) {
+	rules := e.TicketKindRules()
+	for i := range rules {
+		if rules[i].Kind == kind {
+			return &rules[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: event does not offer %q", ErrInvalidTicketKind, kind)
+}
+
+// CheckTicketKindQuota retorna ErrTicketKindQuotaExceeded se quantity ingressos do tipo não
+// couberem na sua cota. Requer Tickets carregado (FindEventByID).
+func (e *Event) CheckTicketKindQuota(rule *TicketKindRule, quantity int) error {
+	if rule.QuotaPercent == 0 {
+		return nil
+	}
+	quota := e.Capacity * rule.QuotaPercent / 100
+	sold := 0
+	for _, ticket := range e.Tickets {
+		if ticket.TicketKind == rule.Kind {
+			sold++
+		}
+	}
+	if sold+quantity > quota {
+		return fmt.Errorf("%w: %s has %d of %d tickets remaining", ErrTicketKindQuotaExceeded, rule.Kind, max(quota-sold, 0), quota)
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package domain
+
+import "strings"
+
+// ErrInvalidInput é o erro de domínio associado a ValidationErrors.
+var ErrInvalidInput = NewValidationError("invalid_input", "one or more fields are invalid")
+
+// FieldError descreve o problema encontrado em um campo da entrada.
+type FieldError struct {
+	Field   string `json:"field" example:"email"`
+	Code    string `json:"code" example:"invalid_format"`
+	Message string `json:"message" example:"email must be a valid address"`
+}
+
+// Códigos usados em FieldError.Code.
+const (
+	FieldRequired      = "required"
+	FieldInvalidFormat = "invalid_format"
+	FieldInvalidValue  = "invalid_value"
+	FieldOutOfRange    = "out_of_range"
+	FieldDuplicate     = "duplicate"
+)
+
+// ValidationErrors reúne todos os erros de campo de uma entrada, para que o cliente
+// possa corrigi-los de uma só vez.
+type ValidationErrors []FieldError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, fieldErr := range e {
+		messages[i] = fieldErr.Field + ": " + fieldErr.Message
+	}
+	return ErrInvalidInput.Message + ": " + strings.Join(messages, "; ")
+}
+
+func (e ValidationErrors) Unwrap() error {
+	return ErrInvalidInput
+}
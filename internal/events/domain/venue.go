@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrVenueNameRequired    = NewValidationError("venue_name_required", "venue name is required")
+	ErrVenueSectionRequired = NewValidationError("venue_section_required", "venue must have at least one section")
+	ErrVenueSectionName     = NewValidationError("venue_section_name_invalid", "section name is required and must be unique in the venue")
+	ErrVenueRowLabel        = NewValidationError("venue_row_label_invalid", "row label must be unique in the venue and form valid spot names")
+	ErrVenueNotFound        = NewNotFoundError("venue_not_found", "venue not found")
+)
+
+// Venue é um local físico (teatro, estádio) com um mapa de assentos reutilizado pelos seus eventos.
+// Os rótulos das fileiras são únicos no local inteiro, de modo que cada assento corresponde a um
+// nome de spot único no evento (ver SeatSpotName).
+type Venue struct {
+	ID       string
+	Name     string
+	Address  string
+	Sections []Section
+}
+
+// Section é um setor do local (plateia, camarote); agrupa fileiras.
+type Section struct {
+	ID      string
+	VenueID string
+	Name    string
+	Rows    []Row
+}
+
+type Row struct {
+	ID        string
+	SectionID string
+	Label     string
+	Seats     []Seat
+}
+
+type Seat struct {
+	ID     string
+	RowID  string
+	Number int
+}
+
+// SpotName retorna o nome do spot que representa o assento nos eventos do local.
+func (r *Row) SpotName(seat Seat) string {
+	return SeatSpotName(r.Label, seat.Number)
+}
+
+// NewVenue cria um local ainda sem setores; use AddSection para montar o mapa.
+func NewVenue(name, address string) (*Venue, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, ErrVenueNameRequired
+	}
+	return &Venue{
+		ID:       uuid.New().String(),
+		Name:     name,
+		Address:  address,
+		Sections: []Section{},
+	}, nil
+}
+
+// AddSection adiciona um setor com as fileiras do plano, com os assentos numerados a partir de 1.
+func (v *Venue) AddSection(name string, plan []RowPlan) (*Section, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, ErrVenueSectionName
+	}
+	labels := make(map[string]bool)
+	for _, section := range v.Sections {
+		if section.Name == name {
+			return nil, fmt.Errorf("%w: %q", ErrVenueSectionName, name)
+		}
+		for _, row := range section.Rows {
+			labels[row.Label] = true
+		}
+	}
+	if len(plan) == 0 {
+		return nil, ErrInvalidSpotLayout
+	}
+
+	section := Section{ID: uuid.New().String(), VenueID: v.ID, Name: name, Rows: make([]Row, len(plan))}
+	for i, rowPlan := range plan {
+		if rowPlan.Seats <= 0 {
+			return nil, ErrInvalidSpotLayout
+		}
+		if labels[rowPlan.Label] {
+			return nil, fmt.Errorf("%w: %q", ErrVenueRowLabel, rowPlan.Label)
+		}
+		labels[rowPlan.Label] = true
+
+		row := Row{ID: uuid.New().String(), SectionID: section.ID, Label: rowPlan.Label, Seats: make([]Seat, rowPlan.Seats)}
+		for n := range row.Seats {
+			row.Seats[n] = Seat{ID: uuid.New().String(), RowID: row.ID, Number: n + 1}
+		}
+		// O assento de maior número tem o nome mais longo: se ele for válido, todos são.
+		last := Spot{Name: row.SpotName(row.Seats[len(row.Seats)-1])}
+		if err := last.Validate(); err != nil {
+			return nil, fmt.Errorf("%w: %q: %w", ErrVenueRowLabel, rowPlan.Label, err)
+		}
+		section.Rows[i] = row
+	}
+
+	v.Sections = append(v.Sections, section)
+	return &v.Sections[len(v.Sections)-1], nil
+}
+
+// Validate verifica se o local tem nome e ao menos um setor.
+func (v *Venue) Validate() error {
+	if strings.TrimSpace(v.Name) == "" {
+		return ErrVenueNameRequired
+	}
+	if len(v.Sections) == 0 {
+		return ErrVenueSectionRequired
+	}
+	return nil
+}
+
+// SeatCount retorna a quantidade de assentos do local.
+func (v *Venue) SeatCount() int {
+	count := 0
+	for _, section := range v.Sections {
+		for _, row := range section.Rows {
+			count += len(row.Seats)
+		}
+	}
+	return count
+}
+
+// Location descreve o local para Event.Location.
+func (v *Venue) Location() string {
+	if v.Address == "" {
+		return v.Name
+	}
+	return v.Name + ", " + v.Address
+}
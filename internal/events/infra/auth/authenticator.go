@@ -0,0 +1,100 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+)
+
+// APIKeyHeader é o cabeçalho que carrega a chave de API.
+const APIKeyHeader = "X-API-Key"
+
+// Authenticator identifica o principal de uma requisição a partir do cabeçalho
+// "Authorization: Bearer <jwt>" ou do cabeçalho X-API-Key.
+type Authenticator struct {
+	jwt     JWTConfig
+	keys    []jwtKey
+	apiKeys map[[sha256.Size]byte]domain.Principal
+	now     func() time.Time
+}
+
+// NewAuthenticator carrega as chaves configuradas. A configuração deve ter sido validada com Config.Validate.
+func NewAuthenticator(cfg Config) (*Authenticator, error) {
+	a := &Authenticator{
+		jwt:     cfg.JWT,
+		apiKeys: make(map[[sha256.Size]byte]domain.Principal, len(cfg.APIKeys)),
+		now:     time.Now,
+	}
+
+	for _, keyCfg := range cfg.JWT.Keys {
+		key, err := loadJWTKey(keyCfg)
+		if err != nil {
+			return nil, err
+		}
+		a.keys = append(a.keys, key)
+	}
+
+	// As chaves de API são indexadas pelo seu hash, de modo que o texto da chave não fica em memória
+	// e a busca não depende de uma comparação byte a byte com o valor recebido.
+	for i, keyCfg := range cfg.APIKeys {
+		var hash [sha256.Size]byte
+		if keyCfg.KeySHA256 != "" {
+			decoded, err := hex.DecodeString(keyCfg.KeySHA256)
+			if err != nil || len(decoded) != sha256.Size {
+				return nil, fmt.Errorf("auth: api_keys[%d].key_sha256 is invalid", i)
+			}
+			copy(hash[:], decoded)
+		} else {
+			hash = sha256.Sum256([]byte(keyCfg.Key))
+		}
+		a.apiKeys[hash] = domain.Principal{
+			Subject:      keyCfg.Subject,
+			Roles:        toRoles(keyCfg.Roles),
+			Organization: keyCfg.Organization,
+			PartnerID:    keyCfg.PartnerID,
+		}
+	}
+
+	return a, nil
+}
+
+// Authenticate retorna o principal da requisição. Sem credenciais, ou com credenciais inválidas,
+// retorna um erro que satisfaz errors.Is(err, domain.ErrUnauthenticated).
+func (a *Authenticator) Authenticate(r *http.Request) (*domain.Principal, error) {
+	if apiKey := r.Header.Get(APIKeyHeader); apiKey != "" {
+		principal, ok := a.apiKeys[sha256.Sum256([]byte(apiKey))]
+		if !ok {
+			return nil, fmt.Errorf("%w: invalid API key", domain.ErrUnauthenticated)
+		}
+		return &principal, nil
+	}
+
+	scheme, token, found := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return nil, domain.ErrUnauthenticated
+	}
+
+	claims, err := parseJWT(strings.TrimSpace(token), a.keys, a.jwt, a.now())
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", domain.ErrUnauthenticated, err)
+	}
+	return &domain.Principal{
+		Subject:      claims.Subject,
+		Roles:        toRoles(claims.Roles),
+		Organization: claims.Organization,
+		PartnerID:    claims.PartnerID,
+	}, nil
+}
+
+func toRoles(values []string) []domain.Role {
+	roles := make([]domain.Role, len(values))
+	for i, value := range values {
+		roles[i] = domain.Role(value)
+	}
+	return roles
+}
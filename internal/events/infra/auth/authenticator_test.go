@@ -0,0 +1,193 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+)
+
+// writePEM grava um bloco PEM em um arquivo temporário e retorna o caminho.
+func writePEM(t *testing.T, blockType string, der []byte) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "key.pem")
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestAuthenticate(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	pkix, err := x509.MarshalPKIXPublicKey(&testRSAKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	partnerKeyHash := sha256.Sum256([]byte("partner-key"))
+	cfg := Config{
+		JWT: JWTConfig{
+			Issuer: "https://auth.example.com",
+			Keys: []JWTKeyConfig{
+				{ID: "hs-1", Algorithm: AlgorithmHS256, Secret: "hs-secret"},
+				{ID: "rs-1", Algorithm: AlgorithmRS256, PublicKeyFile: writePEM(t, "PUBLIC KEY", pkix)},
+			},
+		},
+		APIKeys: []APIKeyConfig{
+			{Key: "organizer-key", Subject: "backoffice", Roles: []string{"organizer"}, Organization: "acme"},
+			{KeySHA256: hex.EncodeToString(partnerKeyHash[:]), Subject: "partner-2", Roles: []string{"partner-admin"}, PartnerID: 2},
+		},
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	authenticator, err := NewAuthenticator(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	authenticator.now = func() time.Time { return now }
+
+	claims := map[string]any{"sub": "user-1", "iss": cfg.JWT.Issuer, "exp": now.Add(time.Hour).Unix(), "roles": []string{"customer", "organizer"}, "org": "acme"}
+	rsToken := signToken(t, map[string]any{"alg": AlgorithmRS256, "kid": "rs-1"}, claims, rs256(testRSAKey))
+	hsToken := signToken(t, map[string]any{"alg": AlgorithmHS256, "kid": "hs-1"}, claims, hs256([]byte("hs-secret")))
+	claims["exp"] = now.Add(-time.Hour).Unix()
+	expired := signToken(t, map[string]any{"alg": AlgorithmHS256}, claims, hs256([]byte("hs-secret")))
+
+	customer := &domain.Principal{Subject: "user-1", Roles: []domain.Role{domain.RoleCustomer, domain.RoleOrganizer}, Organization: "acme"}
+	tests := []struct {
+		name    string
+		headers map[string]string
+		want    *domain.Principal
+		wantErr error // além de domain.ErrUnauthenticated
+	}{
+		{name: "RS256 token", headers: map[string]string{"Authorization": "Bearer " + rsToken}, want: customer},
+		{name: "HS256 token", headers: map[string]string{"Authorization": "Bearer " + hsToken}, want: customer},
+		{name: "lowercase scheme", headers: map[string]string{"Authorization": "bearer " + hsToken}, want: customer},
+		{name: "API key", headers: map[string]string{APIKeyHeader: "organizer-key"},
+			want: &domain.Principal{Subject: "backoffice", Roles: []domain.Role{domain.RoleOrganizer}, Organization: "acme"}},
+		{name: "hashed API key", headers: map[string]string{APIKeyHeader: "partner-key"},
+			want: &domain.Principal{Subject: "partner-2", Roles: []domain.Role{domain.RolePartnerAdmin}, PartnerID: 2}},
+		{name: "API key takes precedence", headers: map[string]string{APIKeyHeader: "organizer-key", "Authorization": "Bearer " + expired},
+			want: &domain.Principal{Subject: "backoffice", Roles: []domain.Role{domain.RoleOrganizer}, Organization: "acme"}},
+
+		{name: "no credentials"},
+		{name: "invalid API key", headers: map[string]string{APIKeyHeader: "guessed-key"}},
+		{name: "API key with different case", headers: map[string]string{APIKeyHeader: "Organizer-Key"}},
+		{name: "hash sent as the API key", headers: map[string]string{APIKeyHeader: hex.EncodeToString(partnerKeyHash[:])}},
+		{name: "basic scheme", headers: map[string]string{"Authorization": "Basic dXNlcjpwYXNz"}},
+		{name: "bearer without token", headers: map[string]string{"Authorization": "Bearer "}},
+		{name: "expired token", headers: map[string]string{"Authorization": "Bearer " + expired}, wantErr: errTokenExpired},
+		{name: "malformed token", headers: map[string]string{"Authorization": "Bearer abc"}, wantErr: errMalformedToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/", nil)
+			for name, value := range tt.headers {
+				r.Header.Set(name, value)
+			}
+
+			got, err := authenticator.Authenticate(r)
+			if tt.want != nil {
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !reflect.DeepEqual(got, tt.want) {
+					t.Errorf("principal = %+v, want %+v", got, tt.want)
+				}
+				return
+			}
+			if !errors.Is(err, domain.ErrUnauthenticated) {
+				t.Fatalf("error = %v, want domain.ErrUnauthenticated", err)
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// TestAuthenticateRevokedAPIKey cobre a revogação de uma chave de API, que é feita removendo-a da
+// configuração: o Authenticator recriado com a nova lista recusa a chave antiga.
+func TestAuthenticateRevokedAPIKey(t *testing.T) {
+	keys := []APIKeyConfig{
+		{Key: "old-key", Subject: "integration", Roles: []string{"organizer"}},
+		{Key: "new-key", Subject: "integration", Roles: []string{"organizer"}},
+	}
+	authenticate := func(authenticator *Authenticator, key string) error {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set(APIKeyHeader, key)
+		_, err := authenticator.Authenticate(r)
+		return err
+	}
+
+	before, err := NewAuthenticator(Config{APIKeys: keys})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := authenticate(before, "old-key"); err != nil {
+		t.Fatalf("old key before the revocation: %v", err)
+	}
+
+	after, err := NewAuthenticator(Config{APIKeys: keys[1:]})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := authenticate(after, "old-key"); !errors.Is(err, domain.ErrUnauthenticated) {
+		t.Errorf("revoked key error = %v, want domain.ErrUnauthenticated", err)
+	}
+	if err := authenticate(after, "new-key"); err != nil {
+		t.Errorf("remaining key: %v", err)
+	}
+}
+
+func TestNewAuthenticatorLoadsRSAKeys(t *testing.T) {
+	pkix, err := x509.MarshalPKIXPublicKey(&testRSAKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecPKIX, err := x509.MarshalPKIXPublicKey(&ecKey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	notPEM := filepath.Join(t.TempDir(), "key.txt")
+	if err := os.WriteFile(notPEM, []byte("not a key"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		file    string
+		wantErr bool
+	}{
+		{name: "PKIX", file: writePEM(t, "PUBLIC KEY", pkix)},
+		{name: "PKCS #1", file: writePEM(t, "RSA PUBLIC KEY", x509.MarshalPKCS1PublicKey(&testRSAKey.PublicKey))},
+		{name: "missing file", file: filepath.Join(t.TempDir(), "missing.pem"), wantErr: true},
+		{name: "not PEM", file: notPEM, wantErr: true},
+		{name: "not RSA", file: writePEM(t, "PUBLIC KEY", ecPKIX), wantErr: true},
+		{name: "corrupted key", file: writePEM(t, "PUBLIC KEY", pkix[:len(pkix)/2]), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAuthenticator(Config{JWT: JWTConfig{Keys: []JWTKeyConfig{{Algorithm: AlgorithmRS256, PublicKeyFile: tt.file}}}})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewAuthenticator error = %v, want error %t", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+// Package auth autentica as requisições por tokens JWT (HS256/RS256, com chaves configuradas
+// localmente) ou por chaves de API estáticas, produzindo um domain.Principal.
+package auth
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+)
+
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+)
+
+type Config struct {
+	Disabled bool           `yaml:"disabled"` // Desativa a autenticação (apenas para desenvolvimento local).
+	JWT      JWTConfig      `yaml:"jwt"`
+	APIKeys  []APIKeyConfig `yaml:"api_keys"`
+}
+
+type JWTConfig struct {
+	Issuer   string         `yaml:"issuer"`   // Se definido, o claim iss deve ser igual a este valor.
+	Audience string         `yaml:"audience"` // Se definido, o claim aud deve conter este valor.
+	Keys     []JWTKeyConfig `yaml:"keys"`
+}
+
+// JWTKeyConfig descreve uma chave de verificação. ID corresponde ao cabeçalho kid do token;
+// tokens sem kid são verificados com todas as chaves do algoritmo.
+type JWTKeyConfig struct {
+	ID            string `yaml:"id"`
+	Algorithm     string `yaml:"algorithm"`       // HS256 ou RS256.
+	Secret        string `yaml:"secret"`          // Segredo compartilhado (HS256).
+	PublicKeyFile string `yaml:"public_key_file"` // Chave pública RSA em PEM (RS256).
+}
+
+// APIKeyConfig associa uma chave de API estática a um principal. A chave pode ser informada
+// em texto (Key) ou, preferencialmente, pelo seu SHA-256 em hexadecimal (KeySHA256).
+type APIKeyConfig struct {
+	Key          string   `yaml:"key"`
+	KeySHA256    string   `yaml:"key_sha256"`
+	Subject      string   `yaml:"subject"`
+	Roles        []string `yaml:"roles"`
+	Organization string   `yaml:"organization"`
+	PartnerID    int      `yaml:"partner_id"`
+}
+
+// HasCredentials indica se há alguma forma de autenticação configurada.
+func (c Config) HasCredentials() bool {
+	return len(c.JWT.Keys) > 0 || len(c.APIKeys) > 0
+}
+
+// Validate verifica a configuração e reporta todos os problemas encontrados de uma vez.
+func (c Config) Validate() error {
+	var errs []error
+
+	for i, key := range c.JWT.Keys {
+		switch key.Algorithm {
+		case AlgorithmHS256:
+			if key.Secret == "" {
+				errs = append(errs, fmt.Errorf("auth.jwt.keys[%d].secret is required for %s", i, AlgorithmHS256))
+			}
+		case AlgorithmRS256:
+			if key.PublicKeyFile == "" {
+				errs = append(errs, fmt.Errorf("auth.jwt.keys[%d].public_key_file is required for %s", i, AlgorithmRS256))
+			}
+		default:
+			errs = append(errs, fmt.Errorf("auth.jwt.keys[%d].algorithm must be %q or %q, got %q", i, AlgorithmHS256, AlgorithmRS256, key.Algorithm))
+		}
+	}
+
+	for i, key := range c.APIKeys {
+		switch {
+		case key.Key == "" && key.KeySHA256 == "":
+			errs = append(errs, fmt.Errorf("auth.api_keys[%d]: key or key_sha256 is required", i))
+		case key.Key != "" && key.KeySHA256 != "":
+			errs = append(errs, fmt.Errorf("auth.api_keys[%d]: key and key_sha256 are mutually exclusive", i))
+		case key.KeySHA256 != "":
+			if decoded, err := hex.DecodeString(key.KeySHA256); err != nil || len(decoded) != 32 {
+				errs = append(errs, fmt.Errorf("auth.api_keys[%d].key_sha256 must be a hex-encoded SHA-256", i))
+			}
+		}
+		if key.Subject == "" {
+			errs = append(errs, fmt.Errorf("auth.api_keys[%d].subject is required", i))
+		}
+		if err := validateRoles(key.Roles); err != nil {
+			errs = append(errs, fmt.Errorf("auth.api_keys[%d].roles: %w", i, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func validateRoles(roles []string) error {
+	if len(roles) == 0 {
+		return errors.New("at least one role is required")
+	}
+	for _, role := range roles {
+		if !domain.Role(role).IsValid() {
+			return fmt.Errorf("unknown role %q", role)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// clockSkew é a tolerância aplicada aos claims exp e nbf.
+const clockSkew = 30 * time.Second
+
+var (
+	errMalformedToken   = errors.New("malformed token")
+	errUnsupportedAlg   = errors.New("unsupported token algorithm")
+	errInvalidSignature = errors.New("invalid token signature")
+	errMissingExpiry    = errors.New("token has no expiration")
+	errTokenExpired     = errors.New("token is expired")
+	errTokenNotYetValid = errors.New("token is not valid yet")
+	errInvalidIssuer    = errors.New("invalid token issuer")
+	errInvalidAudience  = errors.New("invalid token audience")
+)
+
+// jwtKey é uma chave de verificação pronta para uso.
+type jwtKey struct {
+	id        string
+	algorithm string
+	secret    []byte
+	publicKey *rsa.PublicKey
+}
+
+func loadJWTKey(cfg JWTKeyConfig) (jwtKey, error) {
+	key := jwtKey{id: cfg.ID, algorithm: cfg.Algorithm}
+	switch cfg.Algorithm {
+	case AlgorithmHS256:
+		key.secret = []byte(cfg.Secret)
+	case AlgorithmRS256:
+		content, err := os.ReadFile(cfg.PublicKeyFile)
+		if err != nil {
+			return jwtKey{}, fmt.Errorf("auth: reading %s: %w", cfg.PublicKeyFile, err)
+		}
+		key.publicKey, err = parseRSAPublicKey(content)
+		if err != nil {
+			return jwtKey{}, fmt.Errorf("auth: %s: %w", cfg.PublicKeyFile, err)
+		}
+	default:
+		return jwtKey{}, fmt.Errorf("auth: unsupported algorithm %q", cfg.Algorithm)
+	}
+	return key, nil
+}
+
+// parseRSAPublicKey aceita chaves "PUBLIC KEY" (PKIX) e "RSA PUBLIC KEY" (PKCS #1).
+func parseRSAPublicKey(content []byte) (*rsa.PublicKey, error) {
+	block, _ := pem.Decode(content)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	if block.Type == "RSA PUBLIC KEY" {
+		return x509.ParsePKCS1PublicKey(block.Bytes)
+	}
+
+	parsed, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	publicKey, ok := parsed.(*rsa.PublicKey)
+	if !ok {
+		return nil, errors.New("public key is not RSA")
+	}
+	return publicKey, nil
+}
+
+func (k jwtKey) verify(signingInput, signature []byte) bool {
+	switch k.algorithm {
+	case AlgorithmHS256:
+		mac := hmac.New(sha256.New, k.secret)
+		mac.Write(signingInput)
+		return hmac.Equal(mac.Sum(nil), signature)
+	case AlgorithmRS256:
+		hashed := sha256.Sum256(signingInput)
+		return rsa.VerifyPKCS1v15(k.publicKey, crypto.SHA256, hashed[:], signature) == nil
+	default:
+		return false
+	}
+}
+
+type jwtHeader struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+// jwtClaims são os claims reconhecidos. roles, org e partner_id são claims privados desta aplicação.
+type jwtClaims struct {
+	Subject      string   `json:"sub"`
+	Issuer       string   `json:"iss"`
+	Audience     audience `json:"aud"`
+	ExpiresAt    *float64 `json:"exp"`
+	NotBefore    *float64 `json:"nbf"`
+	Roles        []string `json:"roles"`
+	Organization string   `json:"org"`
+	PartnerID    int      `json:"partner_id"`
+}
+
+// audience aceita o claim aud tanto como texto quanto como lista.
+type audience []string
+
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var list []string
+	if err := json.Unmarshal(data, &list); err != nil {
+		return err
+	}
+	*a = list
+	return nil
+}
+
+func (a audience) contains(value string) bool {
+	for _, v := range a {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// parseJWT verifica a assinatura e os claims temporais do token e retorna seus claims.
+func parseJWT(token string, keys []jwtKey, cfg JWTConfig, now time.Time) (*jwtClaims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, errMalformedToken
+	}
+
+	var header jwtHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, errMalformedToken
+	}
+	// Apenas algoritmos configurados explicitamente são aceitos (em particular, nunca "none").
+	if header.Algorithm != AlgorithmHS256 && header.Algorithm != AlgorithmRS256 {
+		return nil, errUnsupportedAlg
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errMalformedToken
+	}
+
+	signingInput := []byte(parts[0] + "." + parts[1])
+	verified := false
+	for _, key := range keys {
+		if key.algorithm != header.Algorithm || (header.KeyID != "" && key.id != header.KeyID) {
+			continue
+		}
+		if key.verify(signingInput, signature) {
+			verified = true
+			break
+		}
+	}
+	if !verified {
+		return nil, errInvalidSignature
+	}
+
+	var claims jwtClaims
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, errMalformedToken
+	}
+
+	if claims.ExpiresAt == nil {
+		return nil, errMissingExpiry
+	}
+	if now.After(unixTime(*claims.ExpiresAt).Add(clockSkew)) {
+		return nil, errTokenExpired
+	}
+	if claims.NotBefore != nil && now.Add(clockSkew).Before(unixTime(*claims.NotBefore)) {
+		return nil, errTokenNotYetValid
+	}
+	if cfg.Issuer != "" && claims.Issuer != cfg.Issuer {
+		return nil, errInvalidIssuer
+	}
+	if cfg.Audience != "" && !claims.Audience.contains(cfg.Audience) {
+		return nil, errInvalidAudience
+	}
+	return &claims, nil
+}
+
+func decodeSegment(segment string, v any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func unixTime(seconds float64) time.Time {
+	return time.Unix(0, int64(seconds*float64(time.Second)))
+}
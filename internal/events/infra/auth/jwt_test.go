@@ -0,0 +1,172 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testRSAKey é gerada uma vez por execução; gerar chaves RSA é lento.
+var testRSAKey = func() *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic(err)
+	}
+	return key
+}()
+
+// signToken monta um JWT com o cabeçalho e os claims informados, assinado por sign.
+func signToken(t *testing.T, header, claims map[string]any, sign func(signingInput []byte) []byte) string {
+	t.Helper()
+	encode := func(v any) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+	signingInput := encode(header) + "." + encode(claims)
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(sign([]byte(signingInput)))
+}
+
+func hs256(secret []byte) func([]byte) []byte {
+	return func(signingInput []byte) []byte {
+		mac := hmac.New(sha256.New, secret)
+		mac.Write(signingInput)
+		return mac.Sum(nil)
+	}
+}
+
+func rs256(key *rsa.PrivateKey) func([]byte) []byte {
+	return func(signingInput []byte) []byte {
+		hashed := sha256.Sum256(signingInput)
+		signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, hashed[:])
+		if err != nil {
+			panic(err)
+		}
+		return signature
+	}
+}
+
+func TestParseJWT(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	secret := []byte("hs-secret")
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PUBLIC KEY", Bytes: x509.MarshalPKCS1PublicKey(&testRSAKey.PublicKey)})
+	keys := []jwtKey{
+		{id: "hs-1", algorithm: AlgorithmHS256, secret: secret},
+		{id: "rs-1", algorithm: AlgorithmRS256, publicKey: &testRSAKey.PublicKey},
+	}
+	cfg := JWTConfig{Issuer: "https://auth.example.com", Audience: "events"}
+
+	claims := func(change func(c map[string]any)) map[string]any {
+		c := map[string]any{
+			"sub":        "user-1",
+			"iss":        cfg.Issuer,
+			"aud":        cfg.Audience,
+			"exp":        now.Add(time.Hour).Unix(),
+			"roles":      []string{"customer"},
+			"org":        "acme",
+			"partner_id": 2,
+		}
+		if change != nil {
+			change(c)
+		}
+		return c
+	}
+	hsHeader := map[string]any{"alg": AlgorithmHS256, "typ": "JWT"}
+	rsHeader := map[string]any{"alg": AlgorithmRS256, "kid": "rs-1"}
+	valid := signToken(t, hsHeader, claims(nil), hs256(secret))
+	segments := strings.Split(valid, ".")
+
+	tests := []struct {
+		name  string
+		token string
+		keys  []jwtKey // padrão: keys
+		want  error    // nil para um token válido
+	}{
+		{name: "HS256", token: valid},
+		{name: "RS256", token: signToken(t, rsHeader, claims(nil), rs256(testRSAKey))},
+		{name: "RS256 without kid", token: signToken(t, map[string]any{"alg": AlgorithmRS256}, claims(nil), rs256(testRSAKey))},
+		{name: "audience list", token: signToken(t, hsHeader, claims(func(c map[string]any) { c["aud"] = []string{"other", "events"} }), hs256(secret))},
+		{name: "fractional expiry", token: signToken(t, hsHeader, claims(func(c map[string]any) { c["exp"] = float64(now.Unix()) + 0.5 }), hs256(secret))},
+
+		{name: "alg none", token: signToken(t, map[string]any{"alg": "none"}, claims(nil), func([]byte) []byte { return nil }), want: errUnsupportedAlg},
+		{name: "alg None", token: signToken(t, map[string]any{"alg": "None"}, claims(nil), func([]byte) []byte { return nil }), want: errUnsupportedAlg},
+		{name: "unsupported alg", token: signToken(t, map[string]any{"alg": "HS512"}, claims(nil), hs256(secret)), want: errUnsupportedAlg},
+		// Confusão de algoritmos: o atacante assina com HS256 usando a chave pública RSA como segredo.
+		{name: "HS256 signed with the RSA public key", token: signToken(t, map[string]any{"alg": AlgorithmHS256, "kid": "rs-1"}, claims(nil), hs256(publicPEM)), want: errInvalidSignature},
+		{name: "HS256 token without HS256 keys", token: valid, keys: keys[1:], want: errInvalidSignature},
+		{name: "RS256 header on an HS256 signature", token: signToken(t, rsHeader, claims(nil), hs256(secret)), want: errInvalidSignature},
+		{name: "unknown kid", token: signToken(t, map[string]any{"alg": AlgorithmRS256, "kid": "rs-2"}, claims(nil), rs256(testRSAKey)), want: errInvalidSignature},
+		{name: "kid of another key", token: signToken(t, map[string]any{"alg": AlgorithmHS256, "kid": "hs-1"}, claims(nil), hs256([]byte("other-secret"))), want: errInvalidSignature},
+		{name: "signed by another RSA key", token: signToken(t, rsHeader, claims(nil), rs256(otherKey)), want: errInvalidSignature},
+		{name: "tampered claims", token: segments[0] + "." + base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"admin","exp":9999999999}`)) + "." + segments[2], want: errInvalidSignature},
+		{name: "empty signature", token: segments[0] + "." + segments[1] + ".", want: errInvalidSignature},
+		{name: "no keys", token: valid, keys: []jwtKey{}, want: errInvalidSignature},
+
+		{name: "two segments", token: segments[0] + "." + segments[1], want: errMalformedToken},
+		{name: "four segments", token: valid + ".extra", want: errMalformedToken},
+		{name: "empty token", token: "", want: errMalformedToken},
+		{name: "header is not base64url", token: "!!." + segments[1] + "." + segments[2], want: errMalformedToken},
+		{name: "header is not JSON", token: base64.RawURLEncoding.EncodeToString([]byte("alg")) + "." + segments[1] + "." + segments[2], want: errMalformedToken},
+		{name: "signature is not base64url", token: segments[0] + "." + segments[1] + ".+/=", want: errMalformedToken},
+		{name: "claims are not JSON", token: signRaw(t, `{"alg":"HS256"}`, `not json`, hs256(secret)), want: errMalformedToken},
+		{name: "malformed audience", token: signToken(t, hsHeader, claims(func(c map[string]any) { c["aud"] = 1 }), hs256(secret)), want: errMalformedToken},
+
+		{name: "missing exp", token: signToken(t, hsHeader, claims(func(c map[string]any) { delete(c, "exp") }), hs256(secret)), want: errMissingExpiry},
+		{name: "expired", token: signToken(t, hsHeader, claims(func(c map[string]any) { c["exp"] = now.Add(-clockSkew - time.Second).Unix() }), hs256(secret)), want: errTokenExpired},
+		{name: "expired within the clock skew", token: signToken(t, hsHeader, claims(func(c map[string]any) { c["exp"] = now.Add(-clockSkew).Unix() }), hs256(secret))},
+		{name: "before nbf", token: signToken(t, hsHeader, claims(func(c map[string]any) { c["nbf"] = now.Add(clockSkew + time.Second).Unix() }), hs256(secret)), want: errTokenNotYetValid},
+		{name: "before nbf within the clock skew", token: signToken(t, hsHeader, claims(func(c map[string]any) { c["nbf"] = now.Add(clockSkew).Unix() }), hs256(secret))},
+		{name: "wrong issuer", token: signToken(t, hsHeader, claims(func(c map[string]any) { c["iss"] = "https://evil.example.com" }), hs256(secret)), want: errInvalidIssuer},
+		{name: "missing issuer", token: signToken(t, hsHeader, claims(func(c map[string]any) { delete(c, "iss") }), hs256(secret)), want: errInvalidIssuer},
+		{name: "wrong audience", token: signToken(t, hsHeader, claims(func(c map[string]any) { c["aud"] = []string{"billing"} }), hs256(secret)), want: errInvalidAudience},
+		{name: "missing audience", token: signToken(t, hsHeader, claims(func(c map[string]any) { delete(c, "aud") }), hs256(secret)), want: errInvalidAudience},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verifyKeys := keys
+			if tt.keys != nil {
+				verifyKeys = tt.keys
+			}
+			got, err := parseJWT(tt.token, verifyKeys, cfg, now)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("parseJWT error = %v, want %v", err, tt.want)
+			}
+			if tt.want == nil && (got.Subject != "user-1" || got.Organization != "acme" || got.PartnerID != 2) {
+				t.Errorf("claims = %+v, want user-1 of acme and partner 2", got)
+			}
+		})
+	}
+}
+
+func TestParseJWTWithoutIssuerAndAudience(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	secret := []byte("hs-secret")
+	token := signToken(t, map[string]any{"alg": AlgorithmHS256}, map[string]any{"sub": "user-1", "iss": "anyone", "exp": now.Add(time.Minute).Unix()}, hs256(secret))
+
+	if _, err := parseJWT(token, []jwtKey{{algorithm: AlgorithmHS256, secret: secret}}, JWTConfig{}, now); err != nil {
+		t.Errorf("parseJWT error = %v, want iss and aud to be ignored when not configured", err)
+	}
+}
+
+// signRaw assina cabeçalho e claims já serializados, permitindo claims que não são JSON.
+func signRaw(t *testing.T, header, claims string, sign func([]byte) []byte) string {
+	t.Helper()
+	signingInput := base64.RawURLEncoding.EncodeToString([]byte(header)) + "." + base64.RawURLEncoding.EncodeToString([]byte(claims))
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(sign([]byte(signingInput)))
+}
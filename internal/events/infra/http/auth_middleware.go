@@ -0,0 +1,56 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+)
+
+// Authenticator identifica o principal de uma requisição.
+type Authenticator interface {
+	Authenticate(r *http.Request) (*domain.Principal, error)
+}
+
+// AuthMiddleware protege as rotas que exigem autenticação.
+type AuthMiddleware struct {
+	authenticator Authenticator
+}
+
+// NewAuthMiddleware cria o middleware. Com authenticator nil a autenticação fica desativada
+// e as rotas protegidas são atendidas sem principal.
+func NewAuthMiddleware(authenticator Authenticator) *AuthMiddleware {
+	return &AuthMiddleware{authenticator: authenticator}
+}
+
+// Require só encaminha a requisição para next se ela estiver autenticada com algum dos papéis informados.
+// O principal autenticado fica disponível para os casos de uso via domain.PrincipalFromContext.
+func (m *AuthMiddleware) Require(next http.HandlerFunc, roles ...domain.Role) http.HandlerFunc {
+	if m.authenticator == nil {
+		return next
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		principal, err := m.authenticator.Authenticate(r)
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="events"`)
+			writeError(w, r, err)
+			return
+		}
+
+		if !hasAnyRole(principal, roles) {
+			writeError(w, r, domain.ErrForbidden)
+			return
+		}
+
+		next(w, r.WithContext(domain.ContextWithPrincipal(r.Context(), principal)))
+	}
+}
+
+func hasAnyRole(principal *domain.Principal, roles []domain.Role) bool {
+	for _, role := range roles {
+		if principal.HasRole(role) {
+			return true
+		}
+	}
+	return false
+}
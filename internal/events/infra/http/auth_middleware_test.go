@@ -0,0 +1,83 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+)
+
+// authenticatorFunc adapta uma função à interface Authenticator.
+type authenticatorFunc func(r *http.Request) (*domain.Principal, error)
+
+func (f authenticatorFunc) Authenticate(r *http.Request) (*domain.Principal, error) {
+	return f(r)
+}
+
+func TestAuthMiddlewareRequire(t *testing.T) {
+	organizer := &domain.Principal{Subject: "user-1", Roles: []domain.Role{domain.RoleOrganizer}, Organization: "acme"}
+	tests := []struct {
+		name       string
+		principal  *domain.Principal
+		err        error
+		wantStatus int
+		wantCode   string
+	}{
+		{name: "authorized", principal: organizer, wantStatus: http.StatusNoContent},
+		{name: "unauthenticated", err: fmt.Errorf("%w: token is expired", domain.ErrUnauthenticated), wantStatus: http.StatusUnauthorized, wantCode: domain.ErrUnauthenticated.Code},
+		{name: "missing role", principal: &domain.Principal{Subject: "user-2", Roles: []domain.Role{domain.RoleCustomer}}, wantStatus: http.StatusForbidden, wantCode: domain.ErrForbidden.Code},
+		{name: "no roles", principal: &domain.Principal{Subject: "user-3"}, wantStatus: http.StatusForbidden, wantCode: domain.ErrForbidden.Code},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			middleware := NewAuthMiddleware(authenticatorFunc(func(r *http.Request) (*domain.Principal, error) {
+				return tt.principal, tt.err
+			}))
+			var got *domain.Principal
+			handler := middleware.Require(func(w http.ResponseWriter, r *http.Request) {
+				got, _ = domain.PrincipalFromContext(r.Context())
+				w.WriteHeader(http.StatusNoContent)
+			}, domain.RoleOrganizer, domain.RolePartnerAdmin)
+
+			w := httptest.NewRecorder()
+			handler(w, httptest.NewRequest(http.MethodPost, "/event", nil))
+
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", w.Code, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusNoContent {
+				if got != nil {
+					t.Error("handler called for a refused request")
+				}
+				if !strings.Contains(w.Body.String(), `"code":"`+tt.wantCode+`"`) {
+					t.Errorf("body = %s, want code %s", w.Body, tt.wantCode)
+				}
+				if tt.wantStatus == http.StatusUnauthorized && w.Header().Get("WWW-Authenticate") == "" {
+					t.Error("401 without WWW-Authenticate")
+				}
+				return
+			}
+			if got != tt.principal {
+				t.Errorf("principal in the context = %+v, want %+v", got, tt.principal)
+			}
+		})
+	}
+}
+
+func TestAuthMiddlewareDisabled(t *testing.T) {
+	called := false
+	handler := NewAuthMiddleware(nil).Require(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+		if _, ok := domain.PrincipalFromContext(r.Context()); ok {
+			t.Error("principal in the context with authentication disabled")
+		}
+	}, domain.RoleOrganizer)
+
+	handler(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/event", nil))
+	if !called {
+		t.Error("handler not called with authentication disabled")
+	}
+}
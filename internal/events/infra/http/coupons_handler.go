@@ -0,0 +1,84 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/usecase"
+)
+
+type CouponsHandler struct {
+	createCouponUseCase *usecase.CreateCouponUseCase
+	getCouponUseCase    *usecase.GetCouponUseCase
+}
+
+func NewCouponsHandler(
+	createCouponUseCase *usecase.CreateCouponUseCase,
+	getCouponUseCase *usecase.GetCouponUseCase,
+) *CouponsHandler {
+	return &CouponsHandler{
+		createCouponUseCase: createCouponUseCase,
+		getCouponUseCase:    getCouponUseCase,
+	}
+}
+
+// CreateCoupon handles the request to create a coupon.
+// @Summary Create a coupon
+// @Description Create a promo code for one event (event_id) or for every event of an organization (organization). A percentage coupon takes discount_percent; a fixed coupon takes discount_amount per ticket. Codes are case-insensitive and unique.
+// @Tags Coupons
+// @Accept json
+// @Produce json
+// @Param input body usecase.CreateCouponInputDTO true "Input data"
+// @Success 201 {object} usecase.CouponDTO
+// @Failure 400 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /coupons [post]
+func (h *CouponsHandler) CreateCoupon(w http.ResponseWriter, r *http.Request) {
+	var input usecase.CreateCouponInputDTO
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		writeBadRequest(w, r, err)
+		return
+	}
+
+	output, err := h.createCouponUseCase.Execute(r.Context(), input)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(output)
+}
+
+// GetCoupon handles the request to get a coupon by code.
+// @Summary Get coupon by code
+// @Description Get a coupon and how many purchases have redeemed it
+// @Tags Coupons
+// @Produce json
+// @Param code path string true "Coupon code"
+// @Success 200 {object} usecase.CouponDTO
+// @Failure 404 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /coupons/{code} [get]
+func (h *CouponsHandler) GetCoupon(w http.ResponseWriter, r *http.Request) {
+	input := usecase.GetCouponInputDTO{Code: r.PathValue("code")}
+
+	output, err := h.getCouponUseCase.Execute(r.Context(), input)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
@@ -0,0 +1,155 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/usecase"
+)
+
+type EventLifecycleHandler struct {
+	publishEventUseCase   *usecase.PublishEventUseCase
+	openEventSalesUseCase *usecase.OpenEventSalesUseCase
+	postponeEventUseCase  *usecase.PostponeEventUseCase
+	cancelEventUseCase    *usecase.CancelEventUseCase
+}
+
+func NewEventLifecycleHandler(
+	publishEventUseCase *usecase.PublishEventUseCase,
+	openEventSalesUseCase *usecase.OpenEventSalesUseCase,
+	postponeEventUseCase *usecase.PostponeEventUseCase,
+	cancelEventUseCase *usecase.CancelEventUseCase,
+) *EventLifecycleHandler {
+	return &EventLifecycleHandler{
+		publishEventUseCase:   publishEventUseCase,
+		openEventSalesUseCase: openEventSalesUseCase,
+		postponeEventUseCase:  postponeEventUseCase,
+		cancelEventUseCase:    cancelEventUseCase,
+	}
+}
+
+// PublishEvent handles the request to publish an event.
+// @Summary Publish an event
+// @Description Publish a draft event, or republish a postponed one. The event date must be in the future.
+// @Tags Events
+// @Produce json
+// @Param eventID path string true "Event ID"
+// @Success 200 {object} usecase.EventLifecycleOutputDTO
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /events/{eventID}/publish [post]
+func (h *EventLifecycleHandler) PublishEvent(w http.ResponseWriter, r *http.Request) {
+	input := usecase.EventLifecycleInputDTO{EventID: r.PathValue("eventID")}
+
+	output, err := h.publishEventUseCase.Execute(r.Context(), input)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
+
+// OpenEventSales handles the request to open ticket sales for an event.
+// @Summary Open ticket sales
+// @Description Open ticket sales for a published event; holds and checkout are only accepted while sales are open
+// @Tags Events
+// @Produce json
+// @Param eventID path string true "Event ID"
+// @Success 200 {object} usecase.EventLifecycleOutputDTO
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /events/{eventID}/open-sales [post]
+func (h *EventLifecycleHandler) OpenEventSales(w http.ResponseWriter, r *http.Request) {
+	input := usecase.EventLifecycleInputDTO{EventID: r.PathValue("eventID")}
+
+	output, err := h.openEventSalesUseCase.Execute(r.Context(), input)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
+
+// PostponeEvent handles the request to postpone an event.
+// @Summary Postpone an event
+// @Description Postpone an event, suspending sales until it is published again. The new date is optional.
+// @Tags Events
+// @Accept json
+// @Produce json
+// @Param eventID path string true "Event ID"
+// @Param input body usecase.PostponeEventInputDTO false "New date"
+// @Success 200 {object} usecase.EventLifecycleOutputDTO
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /events/{eventID}/postpone [post]
+func (h *EventLifecycleHandler) PostponeEvent(w http.ResponseWriter, r *http.Request) {
+	var input usecase.PostponeEventInputDTO
+
+	// O corpo é opcional: sem ele o evento é adiado sem nova data.
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil && !errors.Is(err, io.EOF) {
+		writeBadRequest(w, r, err)
+		return
+	}
+
+	input.EventID = r.PathValue("eventID")
+
+	output, err := h.postponeEventUseCase.Execute(r.Context(), input)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
+
+// CancelEvent handles the request to cancel an event.
+// @Summary Cancel an event
+// @Description Cancel an event permanently and flag all of its issued tickets for refund
+// @Tags Events
+// @Produce json
+// @Param eventID path string true "Event ID"
+// @Success 200 {object} usecase.EventLifecycleOutputDTO
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /events/{eventID}/cancel [post]
+func (h *EventLifecycleHandler) CancelEvent(w http.ResponseWriter, r *http.Request) {
+	input := usecase.EventLifecycleInputDTO{EventID: r.PathValue("eventID")}
+
+	output, err := h.cancelEventUseCase.Execute(r.Context(), input)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
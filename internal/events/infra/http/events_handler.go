@@ -14,6 +14,10 @@ type EventsHandler struct {
 	createEventUseCase *usecase.CreateEventUseCase
 	buyTicketsUseCase  *usecase.BuyTicketsUseCase
 	createSpotsUseCase *usecase.CreateSpotsUseCase
+	holdSpotsUseCase   *usecase.HoldSpotsUseCase
+	updateEventUseCase *usecase.UpdateEventUseCase
+	deleteEventUseCase *usecase.DeleteEventUseCase
+	deleteSpotUseCase  *usecase.DeleteSpotUseCase
 }
 
 func NewEventsHandler(
@@ -23,6 +27,10 @@ func NewEventsHandler(
 	buyTicketsUseCase *usecase.BuyTicketsUseCase,
 	createEventUseCase *usecase.CreateEventUseCase,
 	createSpotsUseCase *usecase.CreateSpotsUseCase,
+	holdSpotsUseCase *usecase.HoldSpotsUseCase,
+	updateEventUseCase *usecase.UpdateEventUseCase,
+	deleteEventUseCase *usecase.DeleteEventUseCase,
+	deleteSpotUseCase *usecase.DeleteSpotUseCase,
 ) *EventsHandler {
 	return &EventsHandler{
 		listEventsUseCase:  listEventsUseCase,
@@ -31,22 +39,59 @@ func NewEventsHandler(
 		buyTicketsUseCase:  buyTicketsUseCase,
 		createEventUseCase: createEventUseCase,
 		createSpotsUseCase: createSpotsUseCase,
+		holdSpotsUseCase:   holdSpotsUseCase,
+		updateEventUseCase: updateEventUseCase,
+		deleteEventUseCase: deleteEventUseCase,
+		deleteSpotUseCase:  deleteSpotUseCase,
 	}
 }
 
-// ListEvents handles the request to list all events.
-// @Summary List all events
-// @Description Get all events with their details
+// ListEvents handles the request to list events.
+// @Summary List events
+// @Description List events one page at a time, optionally filtered and sorted. Pass next_cursor as cursor to get the next page.
 // @Tags Events
 // @Accept json
 // @Produce json
+// @Param date_from query string false "Events on or after this date (YYYY-MM-DD or RFC 3339)"
+// @Param date_to query string false "Events on or before this date (YYYY-MM-DD or RFC 3339)"
+// @Param location query string false "Part of the event location"
+// @Param organization query string false "Organization"
+// @Param rating query string false "Rating (L, L10, L12, L14, L16, L18)"
+// @Param partner_id query int false "Partner ID"
+// @Param currency query string false "ISO 4217 currency of the events and of the price range; required with price_min, price_max or sort by price"
+// @Param status query string false "Comma-separated statuses (published, sales_open, sold_out, cancelled, postponed); drafts are never listed"
+// @Param price_min query number false "Minimum price, in major units of currency; compared with the lowest price of the event across its tiers"
+// @Param price_max query number false "Maximum price, in major units of currency; compared with the lowest price of the event across its tiers"
+// @Param has_availability query bool false "Only events with spots available for purchase"
+// @Param sort query string false "date, price (the lowest price across tiers, requires currency) or name; prefix with - for descending order" default(date)
+// @Param limit query int false "Page size (1-100)" default(20)
+// @Param cursor query string false "next_cursor of the previous page"
 // @Success 200 {object} usecase.ListEventsOutputDTO
-// @Failure 500 {object} string
+// @Failure 400 {object} Problem
+// @Failure 500 {object} Problem
 // @Router /events [get]
 func (h *EventsHandler) ListEvents(w http.ResponseWriter, r *http.Request) {
-	output, err := h.listEventsUseCase.Execute()
+	query := r.URL.Query()
+	input := usecase.ListEventsInputDTO{
+		DateFrom:        query.Get("date_from"),
+		DateTo:          query.Get("date_to"),
+		Location:        query.Get("location"),
+		Organization:    query.Get("organization"),
+		Rating:          query.Get("rating"),
+		PartnerID:       query.Get("partner_id"),
+		Currency:        query.Get("currency"),
+		Status:          query.Get("status"),
+		PriceMin:        query.Get("price_min"),
+		PriceMax:        query.Get("price_max"),
+		HasAvailability: query.Get("has_availability"),
+		Sort:            query.Get("sort"),
+		Limit:           query.Get("limit"),
+		Cursor:          query.Get("cursor"),
+	}
+
+	output, err := h.listEventsUseCase.Execute(r.Context(), input)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
@@ -62,17 +107,16 @@ func (h *EventsHandler) ListEvents(w http.ResponseWriter, r *http.Request) {
 // @Produce json
 // @Param eventID path string true "Event ID"
 // @Success 200 {object} usecase.GetEventOutputDTO
-// @Failure 400 {object} string
-// @Failure 404 {object} string
-// @Failure 500 {object} string
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
 // @Router /events/{eventID} [get]
 func (h *EventsHandler) GetEvent(w http.ResponseWriter, r *http.Request) {
 	eventID := r.PathValue("eventID")
 	input := usecase.GetEventInputDTO{ID: eventID}
 
-	output, err := h.getEventUseCase.Execute(input)
+	output, err := h.getEventUseCase.Execute(r.Context(), input)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
@@ -88,16 +132,16 @@ func (h *EventsHandler) GetEvent(w http.ResponseWriter, r *http.Request) {
 // @Produce json
 // @Param eventID path string true "Event ID"
 // @Success 200 {object} usecase.ListSpotsOutputDTO
-// @Failure 400 {object} string
-// @Failure 500 {object} string
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
 // @Router /events/{eventID}/spots [get]
 func (h *EventsHandler) ListSpots(w http.ResponseWriter, r *http.Request) {
 	eventID := r.PathValue("eventID")
 	input := usecase.ListSpotsInputDTO{EventID: eventID}
 
-	output, err := h.listSpotsUseCase.Execute(input)
+	output, err := h.listSpotsUseCase.Execute(r.Context(), input)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
@@ -107,30 +151,44 @@ func (h *EventsHandler) ListSpots(w http.ResponseWriter, r *http.Request) {
 
 // BuyTickets handles the request to buy tickets for an event.
 // @Summary Buy tickets for an event
-// @Description Buy tickets for a specific event
+// @Description Buy tickets for a specific event. Requests retried with the same Idempotency-Key return the original response. coupon_codes applies promo codes to every ticket; each ticket shows its final price and coupon discount.
 // @Tags Events
 // @Accept json
 // @Produce json
+// @Param Idempotency-Key header string false "Key that identifies the purchase across retries; scoped to the authenticated user"
 // @Param input body usecase.BuyTicketsInputDTO true "Input data"
 // @Success 200 {object} usecase.BuyTicketsOutputDTO
-// @Failure 400 {object} string
-// @Failure 500 {object} string
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 502 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /checkout [post]
 func (h *EventsHandler) BuyTickets(w http.ResponseWriter, r *http.Request) {
 	var input usecase.BuyTicketsInputDTO
 
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeBadRequest(w, r, err)
 		return
 	}
 
-	output, err := h.buyTicketsUseCase.Execute(input)
+	input.IdempotencyKey = r.Header.Get("Idempotency-Key")
+
+	output, err := h.buyTicketsUseCase.Execute(r.Context(), input)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
+	if output.Replayed {
+		w.Header().Set("Idempotent-Replayed", "true")
+	}
 	json.NewEncoder(w).Encode(output)
 }
 
@@ -141,9 +199,13 @@ func (h *EventsHandler) BuyTickets(w http.ResponseWriter, r *http.Request) {
 // @Accept json
 // @Produce json
 // @Param input body usecase.CreateEventInputDTO true "Input data"
-// @Success 200 {object} usecase.CreateEventInputDTO
-// @Failure 400 {object} string
-// @Failure 500 {object} string
+// @Success 201 {object} usecase.CreateEventOutputDTO
+// @Failure 400 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
 // @Router /event [post]
 func (h *EventsHandler) CreateEvent(w http.ResponseWriter, r *http.Request) {
 	var input usecase.CreateEventInputDTO
@@ -152,39 +214,186 @@ func (h *EventsHandler) CreateEvent(w http.ResponseWriter, r *http.Request) {
 	//fmt.Println("Method:", r.Method)
 
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeBadRequest(w, r, err)
 		return
 	}
 
-	output, err := h.createEventUseCase.Execute(input)
+	output, err := h.createEventUseCase.Execute(r.Context(), input)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
-	w.WriteHeader(http.StatusCreated)
 	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(output)
 }
 
+// CreateSpots handles the request to create spots for an event.
+// @Summary Create spots for an event
+// @Description Create a number of spots for an event
+// @Tags Events
+// @Accept json
+// @Produce json
+// @Param eventID path string true "Event ID"
+// @Param input body usecase.CreateSpotsInputDTO true "Input data"
+// @Success 201 {object} usecase.CreateSpotsOutputDTO
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /events/{eventID}/spots [post]
 func (h *EventsHandler) CreateSpots(w http.ResponseWriter, r *http.Request) {
 	eventID := r.PathValue("eventID")
 	var input usecase.CreateSpotsInputDTO
 
 	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeBadRequest(w, r, err)
 		return
 	}
 
 	input.EventID = eventID
 
-	output, err := h.createSpotsUseCase.Execute(input)
+	output, err := h.createSpotsUseCase.Execute(r.Context(), input)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(output)
+}
+
+// HoldSpots handles the request to temporarily hold spots before checkout.
+// @Summary Hold spots for an event
+// @Description Hold a set of spots for a session for N minutes; checkout only accepts spots held by the same session of the same authenticated user
+// @Tags Events
+// @Accept json
+// @Produce json
+// @Param eventID path string true "Event ID"
+// @Param input body usecase.HoldSpotsInputDTO true "Input data"
+// @Success 201 {object} usecase.HoldSpotsOutputDTO
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /events/{eventID}/holds [post]
+func (h *EventsHandler) HoldSpots(w http.ResponseWriter, r *http.Request) {
+	eventID := r.PathValue("eventID")
+	var input usecase.HoldSpotsInputDTO
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		writeBadRequest(w, r, err)
+		return
+	}
+
+	input.EventID = eventID
+
+	output, err := h.holdSpotsUseCase.Execute(r.Context(), input)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
 	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(output)
 }
+
+// UpdateEvent handles the request to update an event.
+// @Summary Update an event
+// @Description Change only the fields sent. The price cannot change after tickets are sold, and a new date must be in the future.
+// @Tags Events
+// @Accept json
+// @Produce json
+// @Param eventID path string true "Event ID"
+// @Param input body usecase.UpdateEventInputDTO true "Fields to change"
+// @Success 200 {object} usecase.EventDTO
+// @Failure 400 {object} Problem
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /events/{eventID} [patch]
+func (h *EventsHandler) UpdateEvent(w http.ResponseWriter, r *http.Request) {
+	var input usecase.UpdateEventInputDTO
+
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		writeBadRequest(w, r, err)
+		return
+	}
+
+	input.EventID = r.PathValue("eventID")
+
+	output, err := h.updateEventUseCase.Execute(r.Context(), input)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
+
+// DeleteEvent handles the request to delete an event.
+// @Summary Delete an event
+// @Description Delete an event and its spots. Events with sold tickets must be cancelled instead.
+// @Tags Events
+// @Param eventID path string true "Event ID"
+// @Success 204
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /events/{eventID} [delete]
+func (h *EventsHandler) DeleteEvent(w http.ResponseWriter, r *http.Request) {
+	input := usecase.DeleteEventInputDTO{EventID: r.PathValue("eventID")}
+
+	if err := h.deleteEventUseCase.Execute(r.Context(), input); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// DeleteSpot handles the request to delete a spot of an event.
+// @Summary Delete a spot
+// @Description Delete a spot of an event. Sold spots and spots held by a session cannot be deleted.
+// @Tags Events
+// @Param eventID path string true "Event ID"
+// @Param spotID path string true "Spot ID"
+// @Success 204
+// @Failure 404 {object} Problem
+// @Failure 409 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /events/{eventID}/spots/{spotID} [delete]
+func (h *EventsHandler) DeleteSpot(w http.ResponseWriter, r *http.Request) {
+	input := usecase.DeleteSpotInputDTO{EventID: r.PathValue("eventID"), SpotID: r.PathValue("spotID")}
+
+	if err := h.deleteSpotUseCase.Execute(r.Context(), input); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,41 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/usecase"
+)
+
+type PartnersHandler struct {
+	listPartnerBreakersUseCase *usecase.ListPartnerBreakersUseCase
+}
+
+func NewPartnersHandler(listPartnerBreakersUseCase *usecase.ListPartnerBreakersUseCase) *PartnersHandler {
+	return &PartnersHandler{
+		listPartnerBreakersUseCase: listPartnerBreakersUseCase,
+	}
+}
+
+// ListBreakers handles the request to list the circuit breaker state of each partner.
+// @Summary List partner circuit breakers
+// @Description Get the circuit breaker state (closed, open or half_open) of each configured partner
+// @Tags Partners
+// @Produce json
+// @Success 200 {object} usecase.ListPartnerBreakersOutputDTO
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /partners/breakers [get]
+func (h *PartnersHandler) ListBreakers(w http.ResponseWriter, r *http.Request) {
+	output, err := h.listPartnerBreakersUseCase.Execute(r.Context())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
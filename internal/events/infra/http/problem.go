@@ -0,0 +1,102 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+)
+
+// Problem é o corpo das respostas de erro, no formato RFC 7807 (application/problem+json).
+// Code é um identificador estável do erro, pensado para ser tratado pelos clientes;
+// Errors lista os problemas de cada campo quando a entrada é inválida.
+type Problem struct {
+	Type     string              `json:"type" example:"urn:events:problem:event_not_found"`
+	Title    string              `json:"title" example:"Not Found"`
+	Status   int                 `json:"status" example:"404"`
+	Detail   string              `json:"detail,omitempty" example:"event not found"`
+	Instance string              `json:"instance,omitempty" example:"/events/8beff8fd-39e4-49ea-ae5e-a0ec9af888c5"`
+	Code     string              `json:"code" example:"event_not_found"`
+	Errors   []domain.FieldError `json:"errors,omitempty"`
+}
+
+const (
+	codeInvalidRequestBody = "invalid_request_body"
+	codeInternalError      = "internal_error"
+)
+
+// errorStatus associa cada tipo de erro de domínio a um código de status HTTP.
+var errorStatus = map[domain.ErrorKind]int{
+	domain.ErrorKindValidation:      http.StatusUnprocessableEntity,
+	domain.ErrorKindNotFound:        http.StatusNotFound,
+	domain.ErrorKindConflict:        http.StatusConflict,
+	domain.ErrorKindPartner:         http.StatusBadGateway,
+	domain.ErrorKindUnauthenticated: http.StatusUnauthorized,
+	domain.ErrorKindForbidden:       http.StatusForbidden,
+}
+
+// writeError é o ponto central de tradução de erros para respostas HTTP.
+// Erros de domínio viram o status correspondente ao seu tipo; os demais viram 500
+// sem expor detalhes internos, que ficam apenas no log.
+//
+// O detail de um erro de domínio é a sua mensagem. Só os erros de validação trazem a cadeia
+// completa, montada pelo domínio para explicar a entrada recusada; nos demais tipos a cadeia pode
+// carregar causas internas (ex.: a URL do parceiro) e fica apenas no log.
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) {
+		status, ok := errorStatus[domainErr.Kind]
+		if !ok {
+			status = http.StatusInternalServerError
+		}
+		// Campos ausentes ou malformados tornam a requisição inválida (400); os demais erros de
+		// validação recusam uma entrada bem formada por uma regra de negócio e continuam 422.
+		if errors.Is(err, domain.ErrInvalidInput) {
+			status = http.StatusBadRequest
+		}
+		problem := newProblem(r, status, domainErr.Code, domainErr.Message)
+
+		var fieldErrs domain.ValidationErrors
+		switch {
+		case errors.As(err, &fieldErrs):
+			problem.Errors = fieldErrs
+		case domainErr.Kind == domain.ErrorKindValidation:
+			problem.Detail = err.Error()
+		case err.Error() != domainErr.Message:
+			log.Printf("%s %s: %v", r.Method, r.URL.Path, err)
+		}
+		writeProblemJSON(w, problem)
+		return
+	}
+
+	log.Printf("%s %s: %v", r.Method, r.URL.Path, err)
+	writeProblem(w, r, http.StatusInternalServerError, codeInternalError, "")
+}
+
+// writeBadRequest responde a um corpo de requisição que não pôde ser decodificado.
+func writeBadRequest(w http.ResponseWriter, r *http.Request, err error) {
+	writeProblem(w, r, http.StatusBadRequest, codeInvalidRequestBody, err.Error())
+}
+
+func writeProblem(w http.ResponseWriter, r *http.Request, status int, code, detail string) {
+	writeProblemJSON(w, newProblem(r, status, code, detail))
+}
+
+func newProblem(r *http.Request, status int, code, detail string) Problem {
+	return Problem{
+		Type:     "urn:events:problem:" + code,
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: r.URL.Path,
+		Code:     code,
+	}
+}
+
+func writeProblemJSON(w http.ResponseWriter, problem Problem) {
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(problem.Status)
+	json.NewEncoder(w).Encode(problem)
+}
@@ -0,0 +1,133 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/usecase"
+)
+
+func TestWriteError(t *testing.T) {
+	partnerCause := &url.Error{Op: "Post", URL: "http://partner.internal:8000/events/1/reserve", Err: errors.New("connection reset by peer")}
+	tests := []struct {
+		name string
+		err  error
+		want Problem
+	}{
+		{
+			name: "domain error",
+			err:  domain.ErrEventNotFound,
+			want: Problem{Status: http.StatusNotFound, Code: "event_not_found", Detail: domain.ErrEventNotFound.Message},
+		},
+		{
+			name: "wrapped partner error hides the cause",
+			err:  fmt.Errorf("%w: %w", domain.ErrPartnerFailed, partnerCause),
+			want: Problem{Status: http.StatusBadGateway, Code: "partner_failed", Detail: domain.ErrPartnerFailed.Message},
+		},
+		{
+			name: "wrapped conflict hides the context",
+			err:  fmt.Errorf("%w: SUMMER10", domain.ErrCouponExhausted),
+			want: Problem{Status: http.StatusConflict, Code: domain.ErrCouponExhausted.Code, Detail: domain.ErrCouponExhausted.Message},
+		},
+		{
+			name: "wrapped validation error keeps the explanation",
+			err:  fmt.Errorf("%w: SUMMER10 is not valid for this event", domain.ErrCouponNotApplicable),
+			want: Problem{Status: http.StatusUnprocessableEntity, Code: domain.ErrCouponNotApplicable.Code, Detail: domain.ErrCouponNotApplicable.Message + ": SUMMER10 is not valid for this event"},
+		},
+		{
+			name: "field errors",
+			err:  domain.ValidationErrors{{Field: "email", Code: domain.FieldInvalidFormat, Message: "email must be a valid address"}},
+			want: Problem{
+				Status: http.StatusBadRequest,
+				Code:   "invalid_input",
+				Detail: domain.ErrInvalidInput.Message,
+				Errors: []domain.FieldError{{Field: "email", Code: domain.FieldInvalidFormat, Message: "email must be a valid address"}},
+			},
+		},
+		{
+			name: "internal error",
+			err:  errors.New("dial tcp 10.0.0.5:3306: connection refused"),
+			want: Problem{Status: http.StatusInternalServerError, Code: codeInternalError},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodPost, "/checkout", nil)
+			w := httptest.NewRecorder()
+
+			writeError(w, r, tt.err)
+
+			if w.Code != tt.want.Status {
+				t.Errorf("status = %d, want %d", w.Code, tt.want.Status)
+			}
+			if got := w.Header().Get("Content-Type"); got != "application/problem+json" {
+				t.Errorf("Content-Type = %q, want application/problem+json", got)
+			}
+			var got Problem
+			if err := json.NewDecoder(w.Body).Decode(&got); err != nil {
+				t.Fatal(err)
+			}
+			tt.want.Type = "urn:events:problem:" + tt.want.Code
+			tt.want.Title = http.StatusText(tt.want.Status)
+			tt.want.Instance = "/checkout"
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("problem =\n%+v\nwant\n%+v", got, tt.want)
+			}
+			if strings.Contains(got.Detail, "partner.internal") || strings.Contains(got.Detail, "10.0.0.5") {
+				t.Errorf("detail %q leaks an internal address", got.Detail)
+			}
+		})
+	}
+}
+
+// TestInvalidInputIsBadRequest garante que a validação de cada entrada resulta em 400 invalid_input
+// com os campos, enquanto erros de validação de regras de negócio continuam 422.
+func TestInvalidInputIsBadRequest(t *testing.T) {
+	_, queryErr := usecase.ListEventsInputDTO{Limit: "0"}.Query(time.Now())
+	inputs := map[string]error{
+		"BuyTicketsInputDTO":   usecase.BuyTicketsInputDTO{}.Validate(),
+		"CreateEventInputDTO":  usecase.CreateEventInputDTO{}.Validate(),
+		"CreateSpotsInputDTO":  usecase.CreateSpotsInputDTO{}.Validate(),
+		"CreateVenueInputDTO":  usecase.CreateVenueInputDTO{}.Validate(),
+		"CreateCouponInputDTO": usecase.CreateCouponInputDTO{}.Validate(),
+		"HoldSpotsInputDTO":    usecase.HoldSpotsInputDTO{}.Validate(),
+		"ListEventsInputDTO":   queryErr,
+		"bare ErrInvalidInput": domain.ErrInvalidInput,
+	}
+	for name, err := range inputs {
+		t.Run(name, func(t *testing.T) {
+			if err == nil {
+				t.Fatal("empty input is valid, want validation errors")
+			}
+			w := httptest.NewRecorder()
+			writeError(w, httptest.NewRequest(http.MethodPost, "/", nil), err)
+
+			var problem Problem
+			if err := json.NewDecoder(w.Body).Decode(&problem); err != nil {
+				t.Fatal(err)
+			}
+			if w.Code != http.StatusBadRequest || problem.Code != domain.ErrInvalidInput.Code {
+				t.Errorf("response = %d %s, want 400 %s", w.Code, problem.Code, domain.ErrInvalidInput.Code)
+			}
+			var fieldErrs domain.ValidationErrors
+			if errors.As(err, &fieldErrs) && !reflect.DeepEqual(problem.Errors, []domain.FieldError(fieldErrs)) {
+				t.Errorf("errors = %+v, want %+v", problem.Errors, fieldErrs)
+			}
+		})
+	}
+
+	w := httptest.NewRecorder()
+	writeError(w, httptest.NewRequest(http.MethodPost, "/checkout", nil), domain.ErrInvalidRating)
+	if w.Code != http.StatusUnprocessableEntity {
+		t.Errorf("business validation status = %d, want 422", w.Code)
+	}
+}
@@ -0,0 +1,102 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/usecase"
+)
+
+type VenuesHandler struct {
+	createVenueUseCase *usecase.CreateVenueUseCase
+	getVenueUseCase    *usecase.GetVenueUseCase
+	listVenuesUseCase  *usecase.ListVenuesUseCase
+}
+
+func NewVenuesHandler(
+	createVenueUseCase *usecase.CreateVenueUseCase,
+	getVenueUseCase *usecase.GetVenueUseCase,
+	listVenuesUseCase *usecase.ListVenuesUseCase,
+) *VenuesHandler {
+	return &VenuesHandler{
+		createVenueUseCase: createVenueUseCase,
+		getVenueUseCase:    getVenueUseCase,
+		listVenuesUseCase:  listVenuesUseCase,
+	}
+}
+
+// CreateVenue handles the request to create a venue.
+// @Summary Create a venue
+// @Description Create a venue with a reusable seating map. Each section lists its rows or generates them from a layout; row labels must be unique across the venue. Events created with the venue_id get one spot per seat.
+// @Tags Venues
+// @Accept json
+// @Produce json
+// @Param input body usecase.CreateVenueInputDTO true "Input data"
+// @Success 201 {object} usecase.VenueDTO
+// @Failure 400 {object} Problem
+// @Failure 422 {object} Problem
+// @Failure 401 {object} Problem
+// @Failure 403 {object} Problem
+// @Failure 500 {object} Problem
+// @Security BearerAuth
+// @Security ApiKeyAuth
+// @Router /venues [post]
+func (h *VenuesHandler) CreateVenue(w http.ResponseWriter, r *http.Request) {
+	var input usecase.CreateVenueInputDTO
+	if err := json.NewDecoder(r.Body).Decode(&input); err != nil {
+		writeBadRequest(w, r, err)
+		return
+	}
+
+	output, err := h.createVenueUseCase.Execute(r.Context(), input)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(output)
+}
+
+// ListVenues handles the request to list all venues.
+// @Summary List venues
+// @Description Get all venues ordered by name, without their seating maps
+// @Tags Venues
+// @Produce json
+// @Success 200 {object} usecase.ListVenuesOutputDTO
+// @Failure 500 {object} Problem
+// @Router /venues [get]
+func (h *VenuesHandler) ListVenues(w http.ResponseWriter, r *http.Request) {
+	output, err := h.listVenuesUseCase.Execute(r.Context())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
+
+// GetVenue handles the request to get a venue by ID.
+// @Summary Get venue by ID
+// @Description Get a venue with its seating map
+// @Tags Venues
+// @Produce json
+// @Param venueID path string true "Venue ID"
+// @Success 200 {object} usecase.VenueDTO
+// @Failure 404 {object} Problem
+// @Failure 500 {object} Problem
+// @Router /venues/{venueID} [get]
+func (h *VenuesHandler) GetVenue(w http.ResponseWriter, r *http.Request) {
+	input := usecase.GetVenueInputDTO{ID: r.PathValue("venueID")}
+
+	output, err := h.getVenueUseCase.Execute(r.Context(), input)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(output)
+}
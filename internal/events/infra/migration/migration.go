@@ -0,0 +1,133 @@
+// Package migration aplica as migrações versionadas do banco MySQL, embutidas no binário.
+//
+// Cada migração é um par de arquivos migrations/NNNN_nome.up.sql e NNNN_nome.down.sql.
+// As versões aplicadas ficam na tabela schema_migrations junto com o SHA-256 do script up,
+// de modo que uma migração já aplicada e depois alterada é detectada em vez de ignorada.
+package migration
+
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+//go:embed seed/*.sql
+var seedFiles embed.FS
+
+var (
+	ErrChecksumMismatch = errors.New("migration: applied migration was modified")
+	ErrUnknownMigration = errors.New("migration: database has a migration unknown to this binary")
+	ErrNoDownScript     = errors.New("migration: migration has no down script")
+)
+
+// Migration é uma migração versionada. Checksum é o SHA-256 do script up, em hexadecimal.
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+// Status descreve uma migração conhecida pelo binário ou registrada no banco.
+type Status struct {
+	Version   int64
+	Name      string
+	AppliedAt *time.Time // nil se a migração ainda não foi aplicada
+	Modified  bool       // o script up mudou depois de aplicado
+	Unknown   bool       // registrada no banco, mas ausente deste binário
+}
+
+// seedScript é um script de dados de exemplo; os scripts são executados em ordem de nome.
+type seedScript struct {
+	Name string
+	SQL  string
+}
+
+var migrationFileName = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+// loadMigrations lê as migrações de dir em fsys, ordenadas por versão.
+func loadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int64]*Migration)
+	scripts := make(map[string]string) // "versão.direção" -> arquivo, para detectar versões repetidas
+	for _, entry := range entries {
+		match := migrationFileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("migration: unexpected file name %q", entry.Name())
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version <= 0 {
+			return nil, fmt.Errorf("migration: invalid version in %q", entry.Name())
+		}
+		key := fmt.Sprintf("%d.%s", version, match[3])
+		if previous, ok := scripts[key]; ok {
+			return nil, fmt.Errorf("migration: version %d has more than one %s script (%q and %q)", version, match[3], previous, entry.Name())
+		}
+		scripts[key] = entry.Name()
+
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("migration: version %d has more than one name (%q and %q)", version, m.Name, match[2])
+		}
+		if match[3] == "up" {
+			m.Up = string(content)
+			sum := sha256.Sum256(content)
+			m.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" {
+			return nil, fmt.Errorf("migration: version %d has no up script", m.Version)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// loadSeeds lê os scripts de dados de exemplo de dir em fsys, ordenados por nome.
+func loadSeeds(fsys fs.FS, dir string) ([]seedScript, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	seeds := make([]seedScript, 0, len(entries))
+	for _, entry := range entries {
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		seeds = append(seeds, seedScript{Name: entry.Name(), SQL: string(content)})
+	}
+	return seeds, nil
+}
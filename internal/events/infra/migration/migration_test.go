@@ -0,0 +1,246 @@
+package migration
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	fsys := fstest.MapFS{
+		"migrations/0010_add_index.up.sql":    {Data: []byte("CREATE INDEX idx ON events (date);")},
+		"migrations/0010_add_index.down.sql":  {Data: []byte("DROP INDEX idx ON events;")},
+		"migrations/0002_add_column.up.sql":   {Data: []byte("ALTER TABLE events ADD COLUMN rating VARCHAR(3);")},
+		"migrations/0001_initial.up.sql":      {Data: []byte("CREATE TABLE events (id VARCHAR(36));")},
+		"migrations/0001_initial.down.sql":    {Data: []byte("DROP TABLE events;")},
+		"migrations/0002_add_column.down.sql": {Data: []byte("ALTER TABLE events DROP COLUMN rating;")},
+		"migrations/0011_irreversible.up.sql": {Data: []byte("DELETE FROM events;")},
+	}
+
+	migrations, err := loadMigrations(fsys, "migrations")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var versions []int64
+	for _, m := range migrations {
+		versions = append(versions, m.Version)
+	}
+	// Ordenadas numericamente: 10 vem depois de 2.
+	if want := []int64{1, 2, 10, 11}; !reflect.DeepEqual(versions, want) {
+		t.Fatalf("versions = %v, want %v", versions, want)
+	}
+
+	first := migrations[0]
+	sum := sha256.Sum256([]byte("CREATE TABLE events (id VARCHAR(36));"))
+	want := Migration{
+		Version:  1,
+		Name:     "initial",
+		Up:       "CREATE TABLE events (id VARCHAR(36));",
+		Down:     "DROP TABLE events;",
+		Checksum: hex.EncodeToString(sum[:]),
+	}
+	if first != want {
+		t.Errorf("migration 1 = %+v, want %+v", first, want)
+	}
+	// O script down é opcional; Migrator.Down recusa desfazer a migração (ErrNoDownScript).
+	if irreversible := migrations[3]; irreversible.Down != "" || irreversible.Name != "irreversible" {
+		t.Errorf("migration 11 = %+v, want no down script", irreversible)
+	}
+}
+
+func TestLoadMigrationsErrors(t *testing.T) {
+	up := &fstest.MapFile{Data: []byte("SELECT 1;")}
+	tests := []struct {
+		name  string
+		files []string
+		want  string
+	}{
+		{name: "missing up script", files: []string{"0001_initial.up.sql", "0002_next.down.sql"}, want: "version 2 has no up script"},
+		{name: "two names for a version", files: []string{"0001_initial.up.sql", "0001_other.down.sql"}, want: "more than one name"},
+		{name: "same version with different padding", files: []string{"0001_initial.up.sql", "1_initial.up.sql"}, want: "version 1 has more than one up script"},
+		{name: "duplicated down script", files: []string{"0001_initial.up.sql", "0001_initial.down.sql", "01_initial.down.sql"}, want: "more than one down script"},
+		{name: "version zero", files: []string{"0000_initial.up.sql"}, want: "invalid version"},
+		{name: "version out of range", files: []string{"99999999999999999999_initial.up.sql"}, want: "invalid version"},
+		{name: "uppercase name", files: []string{"0001_Initial.up.sql"}, want: "unexpected file name"},
+		{name: "unknown direction", files: []string{"0001_initial.sql"}, want: "unexpected file name"},
+		{name: "stray file", files: []string{"0001_initial.up.sql", "README.md"}, want: "unexpected file name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsys := fstest.MapFS{}
+			for _, name := range tt.files {
+				fsys["migrations/"+name] = up
+			}
+			_, err := loadMigrations(fsys, "migrations")
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("loadMigrations error = %v, want one mentioning %q", err, tt.want)
+			}
+		})
+	}
+
+	t.Run("missing directory", func(t *testing.T) {
+		if _, err := loadMigrations(fstest.MapFS{}, "migrations"); err == nil {
+			t.Error("loadMigrations of a missing directory succeeded")
+		}
+	})
+}
+
+// TestEmbeddedMigrations garante que as migrações distribuídas no binário carregam, em sequência,
+// e podem ser desfeitas.
+func TestEmbeddedMigrations(t *testing.T) {
+	migrations, err := loadMigrations(migrationFiles, "migrations")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, m := range migrations {
+		if m.Version != int64(i+1) {
+			t.Errorf("migration %d has version %d, want %d", i, m.Version, i+1)
+		}
+		if strings.TrimSpace(m.Down) == "" {
+			t.Errorf("migration %04d_%s has no down script", m.Version, m.Name)
+		}
+		if len(splitStatements(m.Up)) == 0 {
+			t.Errorf("migration %04d_%s has no statements", m.Version, m.Name)
+		}
+	}
+
+	seeds, err := loadSeeds(seedFiles, "seed")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(seeds) == 0 {
+		t.Error("no seed scripts embedded")
+	}
+}
+
+func TestMigratorVerify(t *testing.T) {
+	m := &Migrator{migrations: []Migration{
+		{Version: 1, Name: "initial", Checksum: "aaa"},
+		{Version: 2, Name: "venues", Checksum: "bbb"},
+	}}
+	tests := []struct {
+		name  string
+		state map[int64]appliedMigration
+		want  []error
+	}{
+		{name: "nothing applied", state: map[int64]appliedMigration{}},
+		{name: "partially applied", state: map[int64]appliedMigration{1: {Name: "initial", Checksum: "aaa"}}},
+		{name: "modified after applied", state: map[int64]appliedMigration{1: {Name: "initial", Checksum: "zzz"}}, want: []error{ErrChecksumMismatch}},
+		{name: "unknown migration", state: map[int64]appliedMigration{3: {Name: "from_a_newer_binary", Checksum: "ccc"}}, want: []error{ErrUnknownMigration}},
+		{name: "reports every problem", state: map[int64]appliedMigration{
+			1: {Name: "initial", Checksum: "aaa"},
+			2: {Name: "venues", Checksum: "zzz"},
+			3: {Name: "from_a_newer_binary", Checksum: "ccc"},
+		}, want: []error{ErrChecksumMismatch, ErrUnknownMigration}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := m.verify(tt.state)
+			if len(tt.want) == 0 && err != nil {
+				t.Fatalf("verify = %v, want nil", err)
+			}
+			for _, want := range tt.want {
+				if !errors.Is(err, want) {
+					t.Errorf("verify = %v, want %v", err, want)
+				}
+			}
+		})
+	}
+
+	err := m.verify(map[int64]appliedMigration{3: {Name: "from_a_newer_binary"}})
+	if err == nil || !strings.Contains(err.Error(), "0003_from_a_newer_binary") {
+		t.Errorf("verify = %v, want it to name the unknown migration", err)
+	}
+}
+
+// TestInitialSchemaIsTheBaseline garante que 0001 continua sendo o esquema do antigo
+// mysql-init/init.sql, que `migrate baseline 1` considera aplicado: as mudanças posteriores
+// precisam de migrações próprias.
+func TestInitialSchemaIsTheBaseline(t *testing.T) {
+	migrations, err := loadMigrations(migrationFiles, "migrations")
+	if err != nil {
+		t.Fatal(err)
+	}
+	initial := migrations[0]
+	statements := splitStatements(initial.Up)
+	if len(statements) != 3 {
+		t.Fatalf("0001 has %d statements, want the 3 CREATE TABLE of the baseline", len(statements))
+	}
+	for i, table := range []string{"events", "spots", "tickets"} {
+		if !strings.HasPrefix(statements[i], "CREATE TABLE "+table+" (") {
+			t.Errorf("statement %d = %.40q..., want CREATE TABLE %s", i, statements[i], table)
+		}
+	}
+	for _, later := range []string{"price_amount", "currency", "hold_owner", "version", "checkout_sagas", "idempotency_keys", "INDEX"} {
+		if strings.Contains(initial.Up, later) {
+			t.Errorf("0001 mentions %q, which the baseline schema does not have", later)
+		}
+	}
+}
+
+// TestMoneyAmountsBackfillsBeforeDropping garante que 0007 converte os preços existentes antes
+// de remover as colunas price, nas duas direções.
+func TestMoneyAmountsBackfillsBeforeDropping(t *testing.T) {
+	migrations, err := loadMigrations(migrationFiles, "migrations")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var money Migration
+	for _, m := range migrations {
+		if m.Name == "money_amounts" {
+			money = m
+		}
+	}
+	if money.Version == 0 {
+		t.Fatal("money_amounts migration not found")
+	}
+
+	tests := []struct {
+		name     string
+		script   string
+		backfill map[string]string // tabela -> UPDATE que preenche a nova coluna
+		drop     string            // coluna removida depois do UPDATE
+	}{
+		{
+			name:   "up",
+			script: money.Up,
+			backfill: map[string]string{
+				"events":  "UPDATE events SET price_amount = ROUND(price * 100)",
+				"tickets": "UPDATE tickets SET price_amount = ROUND(price * 100)",
+			},
+			drop: "DROP COLUMN price,",
+		},
+		{
+			name:   "down",
+			script: money.Down,
+			backfill: map[string]string{
+				"events":  "UPDATE events SET price = price_amount / 100",
+				"tickets": "UPDATE tickets SET price = price_amount / 100",
+			},
+			drop: "DROP COLUMN price_amount",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statements := splitStatements(tt.script)
+			for table, update := range tt.backfill {
+				updated, dropped := -1, -1
+				for i, statement := range statements {
+					switch {
+					case statement == update:
+						updated = i
+					case strings.HasPrefix(statement, "ALTER TABLE "+table+"\n") && strings.Contains(statement, tt.drop):
+						dropped = i
+					}
+				}
+				if updated < 0 || dropped < 0 || updated > dropped {
+					t.Errorf("%s: UPDATE at statement %d and DROP at %d, want the backfill before the drop", table, updated, dropped)
+				}
+			}
+		})
+	}
+}
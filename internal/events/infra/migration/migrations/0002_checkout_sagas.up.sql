@@ -0,0 +1,18 @@
+-- Sagas do checkout: registram as reservas feitas no parceiro para que sejam compensadas
+-- quando a compra não é concluída localmente.
+
+CREATE TABLE checkout_sagas (
+  id VARCHAR(36) NOT NULL PRIMARY KEY,
+  event_id VARCHAR(36) NOT NULL,
+  partner_id INT NOT NULL,
+  spots TEXT NOT NULL,
+  ticket_kind VARCHAR(10) NOT NULL,
+  email VARCHAR(255) NOT NULL,
+  reservation_ids TEXT NOT NULL,
+  status VARCHAR(20) NOT NULL,
+  attempts INT NOT NULL DEFAULT 0,
+  last_error TEXT,
+  created_at DATETIME NOT NULL,
+  updated_at DATETIME NOT NULL,
+  INDEX idx_checkout_sagas_status (status, updated_at)
+);
@@ -0,0 +1,4 @@
+ALTER TABLE spots
+  DROP INDEX idx_spots_hold,
+  DROP COLUMN hold_expires_at,
+  DROP COLUMN hold_owner;
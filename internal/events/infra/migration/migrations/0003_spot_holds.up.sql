@@ -0,0 +1,6 @@
+-- Retenção temporária de spots por uma sessão até hold_expires_at.
+
+ALTER TABLE spots
+  ADD COLUMN hold_owner VARCHAR(64),
+  ADD COLUMN hold_expires_at DATETIME,
+  ADD INDEX idx_spots_hold (status, hold_expires_at);
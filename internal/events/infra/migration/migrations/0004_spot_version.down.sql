@@ -0,0 +1,2 @@
+ALTER TABLE spots
+  DROP COLUMN version;
@@ -0,0 +1,4 @@
+-- Versão do spot para a concorrência otimista da reserva.
+
+ALTER TABLE spots
+  ADD COLUMN version INT NOT NULL DEFAULT 0;
@@ -0,0 +1,11 @@
+-- Chaves de idempotência do POST /checkout e a resposta gravada de cada uma.
+
+CREATE TABLE idempotency_keys (
+  idempotency_key VARCHAR(255) NOT NULL PRIMARY KEY,
+  fingerprint CHAR(64) NOT NULL,
+  status VARCHAR(20) NOT NULL,
+  response TEXT,
+  created_at DATETIME NOT NULL,
+  expires_at DATETIME NOT NULL,
+  INDEX idx_idempotency_keys_expires_at (expires_at)
+);
@@ -0,0 +1,7 @@
+ALTER TABLE spots
+  DROP INDEX idx_spots_event_status;
+
+ALTER TABLE events
+  DROP INDEX idx_events_name,
+  DROP INDEX idx_events_price,
+  DROP INDEX idx_events_date;
@@ -0,0 +1,10 @@
+-- Índices da listagem de eventos: um por ordenação, com o ID como desempate do cursor, e o da
+-- disponibilidade de spots.
+
+ALTER TABLE events
+  ADD INDEX idx_events_date (date, id),
+  ADD INDEX idx_events_price (price, id),
+  ADD INDEX idx_events_name (name, id);
+
+ALTER TABLE spots
+  ADD INDEX idx_spots_event_status (event_id, status);
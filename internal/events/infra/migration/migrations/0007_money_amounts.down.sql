@@ -0,0 +1,23 @@
+-- Volta a price FLOAT em unidades maiores; a moeda é descartada.
+
+ALTER TABLE tickets
+  ADD COLUMN price FLOAT NULL AFTER ticket_kind;
+
+UPDATE tickets SET price = price_amount / 100;
+
+ALTER TABLE tickets
+  DROP COLUMN currency,
+  DROP COLUMN price_amount,
+  MODIFY price FLOAT NOT NULL;
+
+ALTER TABLE events
+  ADD COLUMN price FLOAT NULL AFTER capacity;
+
+UPDATE events SET price = price_amount / 100;
+
+ALTER TABLE events
+  DROP INDEX idx_events_price,
+  DROP COLUMN currency,
+  DROP COLUMN price_amount,
+  MODIFY price FLOAT NOT NULL,
+  ADD INDEX idx_events_price (price, id);
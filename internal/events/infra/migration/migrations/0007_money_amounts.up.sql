@@ -0,0 +1,26 @@
+-- Preços exatos: price FLOAT passa a ser price_amount BIGINT, em unidades menores da moeda
+-- (centavos), acompanhado de currency. Os preços existentes eram em reais.
+
+ALTER TABLE events
+  ADD COLUMN price_amount BIGINT NULL AFTER price,
+  ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'BRL' AFTER price_amount;
+
+UPDATE events SET price_amount = ROUND(price * 100);
+
+ALTER TABLE events
+  DROP INDEX idx_events_price,
+  DROP COLUMN price,
+  MODIFY price_amount BIGINT NOT NULL, -- em unidades menores da moeda (centavos)
+  ALTER COLUMN currency DROP DEFAULT,
+  ADD INDEX idx_events_price (price_amount, id);
+
+ALTER TABLE tickets
+  ADD COLUMN price_amount BIGINT NULL AFTER price,
+  ADD COLUMN currency CHAR(3) NOT NULL DEFAULT 'BRL' AFTER price_amount;
+
+UPDATE tickets SET price_amount = ROUND(price * 100);
+
+ALTER TABLE tickets
+  DROP COLUMN price,
+  MODIFY price_amount BIGINT NOT NULL, -- em unidades menores da moeda (centavos)
+  ALTER COLUMN currency DROP DEFAULT;
@@ -0,0 +1,7 @@
+ALTER TABLE tickets
+  DROP COLUMN status;
+
+ALTER TABLE events
+  DROP INDEX idx_events_status,
+  DROP COLUMN version,
+  DROP COLUMN status;
@@ -0,0 +1,10 @@
+-- Ciclo de vida do evento e status dos ingressos.
+-- Os eventos existentes já vendiam ingressos, por isso recebem o status sales_open.
+
+ALTER TABLE events
+  ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'sales_open',
+  ADD COLUMN version INT NOT NULL DEFAULT 0,
+  ADD INDEX idx_events_status (status);
+
+ALTER TABLE tickets
+  ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'issued';
@@ -0,0 +1,12 @@
+ALTER TABLE spots
+  DROP FOREIGN KEY fk_spots_section,
+  DROP COLUMN section_id;
+
+ALTER TABLE events
+  DROP FOREIGN KEY fk_events_venue,
+  DROP COLUMN venue_id;
+
+DROP TABLE venue_seats;
+DROP TABLE venue_rows;
+DROP TABLE venue_sections;
+DROP TABLE venues;
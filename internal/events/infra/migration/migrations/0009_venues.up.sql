@@ -0,0 +1,42 @@
+-- Locais com mapa de assentos reutilizável. Os eventos podem referenciar um local e os
+-- spots materializados a partir do mapa guardam o setor do assento.
+
+CREATE TABLE venues (
+  id VARCHAR(36) NOT NULL PRIMARY KEY,
+  name VARCHAR(255) NOT NULL,
+  address VARCHAR(255) NOT NULL,
+  INDEX idx_venues_name (name, id)
+);
+
+CREATE TABLE venue_sections (
+  id VARCHAR(36) NOT NULL PRIMARY KEY,
+  venue_id VARCHAR(36) NOT NULL,
+  name VARCHAR(100) NOT NULL,
+  position INT NOT NULL,
+  FOREIGN KEY (venue_id) REFERENCES venues(id),
+  UNIQUE KEY uq_venue_sections_name (venue_id, name)
+);
+
+CREATE TABLE venue_rows (
+  id VARCHAR(36) NOT NULL PRIMARY KEY,
+  section_id VARCHAR(36) NOT NULL,
+  label VARCHAR(10) NOT NULL,
+  position INT NOT NULL,
+  FOREIGN KEY (section_id) REFERENCES venue_sections(id)
+);
+
+CREATE TABLE venue_seats (
+  id VARCHAR(36) NOT NULL PRIMARY KEY,
+  row_id VARCHAR(36) NOT NULL,
+  number INT NOT NULL,
+  FOREIGN KEY (row_id) REFERENCES venue_rows(id),
+  UNIQUE KEY uq_venue_seats_number (row_id, number)
+);
+
+ALTER TABLE events
+  ADD COLUMN venue_id VARCHAR(36) NULL,
+  ADD CONSTRAINT fk_events_venue FOREIGN KEY (venue_id) REFERENCES venues(id);
+
+ALTER TABLE spots
+  ADD COLUMN section_id VARCHAR(36) NULL,
+  ADD CONSTRAINT fk_spots_section FOREIGN KEY (section_id) REFERENCES venue_sections(id);
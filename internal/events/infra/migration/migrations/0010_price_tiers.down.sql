@@ -0,0 +1,5 @@
+ALTER TABLE spots
+  DROP FOREIGN KEY fk_spots_tier,
+  DROP COLUMN tier_id;
+
+DROP TABLE event_price_tiers;
@@ -0,0 +1,18 @@
+-- Categorias de preço por evento (VIP, Pista, Camarote). Os spots sem categoria continuam
+-- custando events.price_amount.
+
+CREATE TABLE event_price_tiers (
+  seq BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY, -- ordem de criação
+  id VARCHAR(36) NOT NULL,
+  event_id VARCHAR(36) NOT NULL,
+  name VARCHAR(50) NOT NULL,
+  price_amount BIGINT NOT NULL, -- em unidades menores da moeda (centavos)
+  currency CHAR(3) NOT NULL,
+  FOREIGN KEY (event_id) REFERENCES events(id),
+  UNIQUE KEY uq_event_price_tiers_id (id),
+  UNIQUE KEY uq_event_price_tiers_name (event_id, name)
+);
+
+ALTER TABLE spots
+  ADD COLUMN tier_id VARCHAR(36) NULL,
+  ADD CONSTRAINT fk_spots_tier FOREIGN KEY (tier_id) REFERENCES event_price_tiers(id);
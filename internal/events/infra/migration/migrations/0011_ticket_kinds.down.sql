@@ -0,0 +1,10 @@
+-- Falha se algum ingresso ou saga usar um tipo com mais de 10 caracteres.
+
+ALTER TABLE checkout_sagas
+  MODIFY ticket_kind VARCHAR(10) NOT NULL;
+
+ALTER TABLE tickets
+  DROP COLUMN eligibility_document,
+  MODIFY ticket_kind VARCHAR(10) NOT NULL;
+
+DROP TABLE event_ticket_kinds;
@@ -0,0 +1,22 @@
+-- Tipos de ingresso configuráveis por evento (meia-entrada de estudante, idoso, PCD, cortesia).
+-- Os eventos sem linhas em event_ticket_kinds continuam oferecendo apenas full e half.
+
+CREATE TABLE event_ticket_kinds (
+  seq BIGINT NOT NULL AUTO_INCREMENT PRIMARY KEY, -- ordem de criação
+  event_id VARCHAR(36) NOT NULL,
+  kind VARCHAR(20) NOT NULL,
+  name VARCHAR(100) NOT NULL,
+  discount_bps INT NOT NULL, -- pontos-base: 5000 = 50%
+  quota_percent INT NOT NULL, -- 0 = sem cota
+  document VARCHAR(30) NOT NULL, -- vazio quando o tipo não exige comprovante
+  companion_seats INT NOT NULL,
+  FOREIGN KEY (event_id) REFERENCES events(id),
+  UNIQUE KEY uq_event_ticket_kinds_kind (event_id, kind)
+);
+
+ALTER TABLE tickets
+  MODIFY ticket_kind VARCHAR(20) NOT NULL,
+  ADD COLUMN eligibility_document VARCHAR(100) NULL;
+
+ALTER TABLE checkout_sagas
+  MODIFY ticket_kind VARCHAR(20) NOT NULL;
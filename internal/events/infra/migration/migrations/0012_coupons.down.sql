@@ -0,0 +1,5 @@
+ALTER TABLE tickets
+  DROP COLUMN coupon_discount_amount;
+
+DROP TABLE coupon_redemptions;
+DROP TABLE coupons;
@@ -0,0 +1,38 @@
+-- Cupons promocionais aplicados no checkout e os seus resgates, um por ingresso.
+
+CREATE TABLE coupons (
+  id VARCHAR(36) NOT NULL PRIMARY KEY,
+  code VARCHAR(32) NOT NULL, -- em maiúsculas
+  discount_type VARCHAR(20) NOT NULL, -- percentage ou fixed
+  percent_bps INT NOT NULL, -- pontos-base, para percentage
+  amount BIGINT NOT NULL, -- em unidades menores da moeda, para fixed
+  currency CHAR(3) NULL, -- apenas para fixed
+  valid_from DATETIME NOT NULL,
+  valid_until DATETIME NULL, -- NULL = sem data de término
+  event_id VARCHAR(36) NULL, -- NULL = todos os eventos da organização
+  organization VARCHAR(255) NOT NULL,
+  max_redemptions INT NOT NULL, -- 0 = sem limite
+  max_redemptions_per_email INT NOT NULL, -- 0 = sem limite
+  stacks_with_coupons BOOLEAN NOT NULL,
+  stacks_with_ticket_kinds BOOLEAN NOT NULL,
+  created_at DATETIME NOT NULL,
+  FOREIGN KEY (event_id) REFERENCES events(id),
+  UNIQUE KEY uq_coupons_code (code)
+);
+
+CREATE TABLE coupon_redemptions (
+  id VARCHAR(36) NOT NULL PRIMARY KEY,
+  coupon_id VARCHAR(36) NOT NULL,
+  checkout_id VARCHAR(36) NOT NULL, -- checkout_sagas.id; os limites de uso contam compras
+  ticket_id VARCHAR(36) NOT NULL,
+  email VARCHAR(255) NOT NULL, -- em minúsculas
+  discount_amount BIGINT NOT NULL, -- em unidades menores da moeda
+  currency CHAR(3) NOT NULL,
+  created_at DATETIME NOT NULL,
+  FOREIGN KEY (coupon_id) REFERENCES coupons(id),
+  FOREIGN KEY (ticket_id) REFERENCES tickets(id),
+  INDEX idx_coupon_redemptions_coupon (coupon_id, email)
+);
+
+ALTER TABLE tickets
+  ADD COLUMN coupon_discount_amount BIGINT NOT NULL DEFAULT 0; -- desconto dos cupons, já abatido de price_amount
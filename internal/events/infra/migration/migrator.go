@@ -0,0 +1,272 @@
+package migration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+)
+
+// lockName é o nome do lock do MySQL (GET_LOCK) que serializa migrações de instâncias concorrentes.
+const lockName = "events_schema_migrations"
+
+// lockTimeout é quanto uma instância espera pelo lock antes de desistir, em segundos.
+const lockTimeout = 60
+
+const createMigrationsTable = `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT NOT NULL PRIMARY KEY,
+		name VARCHAR(255) NOT NULL,
+		checksum CHAR(64) NOT NULL,
+		applied_at DATETIME NOT NULL
+	)
+`
+
+// Migrator aplica e desfaz as migrações embutidas no binário.
+//
+// O MySQL confirma implicitamente os comandos DDL, então uma migração não é atômica: se um
+// comando falhar, os anteriores permanecem e a versão não é registrada. Por isso cada
+// migração deve ser pequena e, sempre que possível, tolerante a ser executada novamente.
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+	seeds      []seedScript
+}
+
+// NewMigrator carrega as migrações e os scripts de seed embutidos.
+func NewMigrator(db *sql.DB) (*Migrator, error) {
+	migrations, err := loadMigrations(migrationFiles, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	seeds, err := loadSeeds(seedFiles, "seed")
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations, seeds: seeds}, nil
+}
+
+// appliedMigration é uma linha de schema_migrations.
+type appliedMigration struct {
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+}
+
+// Up aplica, em ordem, as migrações pendentes e retorna quantas foram aplicadas.
+// Falha sem aplicar nada se uma migração já aplicada foi alterada ou se o banco
+// tem migrações que este binário não conhece (por exemplo, após um rollback do deploy).
+func (m *Migrator) Up(ctx context.Context) (int, error) {
+	applied := 0
+	err := m.withLock(ctx, func(conn *sql.Conn, state map[int64]appliedMigration) error {
+		if err := m.verify(state); err != nil {
+			return err
+		}
+		for _, migration := range m.migrations {
+			if _, ok := state[migration.Version]; ok {
+				continue
+			}
+			if err := execScript(ctx, conn, migration.Up); err != nil {
+				return fmt.Errorf("migration: applying %04d_%s: %w", migration.Version, migration.Name, err)
+			}
+			if err := recordMigration(ctx, conn, migration); err != nil {
+				return err
+			}
+			log.Printf("Migração %04d_%s aplicada\n", migration.Version, migration.Name)
+			applied++
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down desfaz as últimas steps migrações aplicadas, da mais recente para a mais antiga,
+// e retorna quantas foram desfeitas.
+func (m *Migrator) Down(ctx context.Context, steps int) (int, error) {
+	reverted := 0
+	err := m.withLock(ctx, func(conn *sql.Conn, state map[int64]appliedMigration) error {
+		if err := m.verify(state); err != nil {
+			return err
+		}
+		for i := len(m.migrations) - 1; i >= 0 && reverted < steps; i-- {
+			migration := m.migrations[i]
+			if _, ok := state[migration.Version]; !ok {
+				continue
+			}
+			if migration.Down == "" {
+				return fmt.Errorf("%w: %04d_%s", ErrNoDownScript, migration.Version, migration.Name)
+			}
+			if err := execScript(ctx, conn, migration.Down); err != nil {
+				return fmt.Errorf("migration: reverting %04d_%s: %w", migration.Version, migration.Name, err)
+			}
+			if _, err := conn.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version = ?", migration.Version); err != nil {
+				return err
+			}
+			log.Printf("Migração %04d_%s desfeita\n", migration.Version, migration.Name)
+			reverted++
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Baseline registra como aplicadas, sem executá-las, as migrações até version (inclusive).
+// Serve para adotar um banco criado antes das migrações, cujo esquema já corresponde a elas.
+func (m *Migrator) Baseline(ctx context.Context, version int64) (int, error) {
+	recorded := 0
+	err := m.withLock(ctx, func(conn *sql.Conn, state map[int64]appliedMigration) error {
+		for _, migration := range m.migrations {
+			if migration.Version > version {
+				break
+			}
+			if _, ok := state[migration.Version]; ok {
+				continue
+			}
+			if err := recordMigration(ctx, conn, migration); err != nil {
+				return err
+			}
+			recorded++
+		}
+		return nil
+	})
+	return recorded, err
+}
+
+// Status lista as migrações conhecidas e as registradas no banco, ordenadas por versão.
+func (m *Migrator) Status(ctx context.Context) ([]Status, error) {
+	var statuses []Status
+	err := m.withLock(ctx, func(conn *sql.Conn, state map[int64]appliedMigration) error {
+		known := make(map[int64]bool, len(m.migrations))
+		for _, migration := range m.migrations {
+			known[migration.Version] = true
+			status := Status{Version: migration.Version, Name: migration.Name}
+			if applied, ok := state[migration.Version]; ok {
+				appliedAt := applied.AppliedAt
+				status.AppliedAt = &appliedAt
+				status.Modified = applied.Checksum != migration.Checksum
+			}
+			statuses = append(statuses, status)
+		}
+		for version, applied := range state {
+			if !known[version] {
+				appliedAt := applied.AppliedAt
+				statuses = append(statuses, Status{Version: version, Name: applied.Name, AppliedAt: &appliedAt, Unknown: true})
+			}
+		}
+		return nil
+	})
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Version < statuses[j].Version })
+	return statuses, err
+}
+
+// Seed executa os scripts de dados de exemplo. Os scripts usam INSERT IGNORE e podem ser
+// executados mais de uma vez; exigem que todas as migrações já tenham sido aplicadas.
+func (m *Migrator) Seed(ctx context.Context) error {
+	return m.withLock(ctx, func(conn *sql.Conn, state map[int64]appliedMigration) error {
+		for _, migration := range m.migrations {
+			if _, ok := state[migration.Version]; !ok {
+				return fmt.Errorf("migration: cannot seed: migration %04d_%s is pending", migration.Version, migration.Name)
+			}
+		}
+		for _, seed := range m.seeds {
+			if err := execScript(ctx, conn, seed.SQL); err != nil {
+				return fmt.Errorf("migration: seeding %s: %w", seed.Name, err)
+			}
+			log.Printf("Seed %s aplicado\n", seed.Name)
+		}
+		return nil
+	})
+}
+
+// verify compara as migrações registradas no banco com as embutidas no binário.
+func (m *Migrator) verify(state map[int64]appliedMigration) error {
+	known := make(map[int64]Migration, len(m.migrations))
+	for _, migration := range m.migrations {
+		known[migration.Version] = migration
+	}
+
+	var errs []error
+	for version, applied := range state {
+		migration, ok := known[version]
+		switch {
+		case !ok:
+			errs = append(errs, fmt.Errorf("%w: %04d_%s", ErrUnknownMigration, version, applied.Name))
+		case applied.Checksum != migration.Checksum:
+			errs = append(errs, fmt.Errorf("%w: %04d_%s", ErrChecksumMismatch, version, migration.Name))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// withLock executa fn em uma conexão dedicada que detém o lock de migração, com a tabela
+// schema_migrations criada e o seu conteúdo já lido.
+func (m *Migrator) withLock(ctx context.Context, fn func(conn *sql.Conn, state map[int64]appliedMigration) error) error {
+	// GET_LOCK pertence à sessão, então todos os comandos precisam usar a mesma conexão.
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var locked sql.NullInt64
+	if err := conn.QueryRowContext(ctx, "SELECT GET_LOCK(?, ?)", lockName, lockTimeout).Scan(&locked); err != nil {
+		return err
+	}
+	if locked.Int64 != 1 {
+		return fmt.Errorf("migration: timed out waiting for lock %q", lockName)
+	}
+	defer conn.ExecContext(context.WithoutCancel(ctx), "DO RELEASE_LOCK(?)", lockName)
+
+	if _, err := conn.ExecContext(ctx, createMigrationsTable); err != nil {
+		return err
+	}
+	state, err := readApplied(ctx, conn)
+	if err != nil {
+		return err
+	}
+	return fn(conn, state)
+}
+
+func readApplied(ctx context.Context, conn *sql.Conn) (map[int64]appliedMigration, error) {
+	rows, err := conn.QueryContext(ctx, "SELECT version, name, checksum, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	state := make(map[int64]appliedMigration)
+	for rows.Next() {
+		var version int64
+		var applied appliedMigration
+		var appliedAt string
+		if err := rows.Scan(&version, &applied.Name, &applied.Checksum, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied.AppliedAt, err = time.Parse("2006-01-02 15:04:05", appliedAt)
+		if err != nil {
+			return nil, err
+		}
+		state[version] = applied
+	}
+	return state, rows.Err()
+}
+
+func recordMigration(ctx context.Context, conn *sql.Conn, migration Migration) error {
+	_, err := conn.ExecContext(ctx,
+		"INSERT INTO schema_migrations (version, name, checksum, applied_at) VALUES (?, ?, ?, ?)",
+		migration.Version, migration.Name, migration.Checksum, time.Now().UTC().Format("2006-01-02 15:04:05"),
+	)
+	return err
+}
+
+func execScript(ctx context.Context, conn *sql.Conn, script string) error {
+	for _, statement := range splitStatements(script) {
+		if _, err := conn.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -1,49 +1,13 @@
-DROP DATABASE IF EXISTS test_db;
+-- Eventos e spots de exemplo para desenvolvimento. INSERT IGNORE torna o seed idempotente.
 
-CREATE DATABASE test_db;
-
-USE test_db;
-
-CREATE TABLE events (
-  id VARCHAR(36) NOT NULL PRIMARY KEY,
-  name VARCHAR(255) NOT NULL,
-  location VARCHAR(255) NOT NULL,
-  organization VARCHAR(255) NOT NULL,
-  rating VARCHAR(10) NOT NULL,
-  date DATETIME NOT NULL,
-  image_url VARCHAR(255) NOT NULL,
-  capacity INT NOT NULL,
-  price FLOAT NOT NULL,
-  partner_id INT NOT NULL
-);
-
-CREATE TABLE spots (
-  id VARCHAR(36) NOT NULL PRIMARY KEY,
-  event_id VARCHAR(36) NOT NULL,
-  name VARCHAR(10) NOT NULL,
-  status VARCHAR(10) NOT NULL,
-  ticket_id VARCHAR(36),
-  FOREIGN KEY (event_id) REFERENCES events(id)
-);
-
-CREATE TABLE tickets (
-  id VARCHAR(36) NOT NULL PRIMARY KEY,
-  event_id VARCHAR(36) NOT NULL,
-  spot_id VARCHAR(36) NOT NULL,
-  ticket_kind VARCHAR(10) NOT NULL,
-  price FLOAT NOT NULL,
-  FOREIGN KEY (event_id) REFERENCES events(id),
-  FOREIGN KEY (spot_id) REFERENCES spots(id)
-);
-
-INSERT INTO events (id, name, location, organization, rating, date, image_url, capacity, price, partner_id) VALUES
-  ('10853e59-dc5b-4d7b-a028-01513ef50d76', 'Event 001 - Partner1', 'São Paulo, SP', 'Partner 1', 'L14', '2021-10-10 10:00:00', 'https://images.unsplash.com/photo-1470229722913-7c0e2dbbafd3', 10, 100, 1),
-  ('e0352b32-7698-4805-b029-28302b3a911f', 'Event 002 - Partner1', 'Rio de Janeiro, RJ', 'Partner 1', 'L14', '2021-10-10 12:00:00', 'https://images.unsplash.com/photo-1459749411175-04bf5292ceea', 10, 200, 1),
-  ('5b79831a-a9d3-4538-8fb5-569494bd17a5', 'Event 003 - Partner2', 'Belo Horizonte, MG', 'Partner 2', 'L12', '2024-10-10 10:00:00', 'https://images.unsplash.com/photo-1540039155733-5bb30b53aa14', 10, 400, 2),
-  ('8beff8fd-39e4-49ea-ae5e-a0ec9af888c5', 'Event 004 - Partner2', 'Uberlândia, MG', 'Partner 2', 'L16', '2024-10-10 12:00:00', 'https://images.unsplash.com/photo-1493225457124-a3eb161ffa5f', 10, 500, 2)
+INSERT IGNORE INTO events (id, name, location, organization, rating, date, image_url, capacity, price_amount, currency, partner_id) VALUES
+  ('10853e59-dc5b-4d7b-a028-01513ef50d76', 'Event 001 - Partner1', 'São Paulo, SP', 'Partner 1', 'L14', '2021-10-10 10:00:00', 'https://images.unsplash.com/photo-1470229722913-7c0e2dbbafd3', 10, 10000, 'BRL', 1),
+  ('e0352b32-7698-4805-b029-28302b3a911f', 'Event 002 - Partner1', 'Rio de Janeiro, RJ', 'Partner 1', 'L14', '2021-10-10 12:00:00', 'https://images.unsplash.com/photo-1459749411175-04bf5292ceea', 10, 20000, 'BRL', 1),
+  ('5b79831a-a9d3-4538-8fb5-569494bd17a5', 'Event 003 - Partner2', 'Belo Horizonte, MG', 'Partner 2', 'L12', '2024-10-10 10:00:00', 'https://images.unsplash.com/photo-1540039155733-5bb30b53aa14', 10, 40000, 'BRL', 2),
+  ('8beff8fd-39e4-49ea-ae5e-a0ec9af888c5', 'Event 004 - Partner2', 'Uberlândia, MG', 'Partner 2', 'L16', '2024-10-10 12:00:00', 'https://images.unsplash.com/photo-1493225457124-a3eb161ffa5f', 10, 50000, 'BRL', 2)
 ;
 
-INSERT INTO spots (id, event_id, name, status, ticket_id) VALUES
+INSERT IGNORE INTO spots (id, event_id, name, status, ticket_id) VALUES
   ('f1b1b1b1-1b1b-1b1b-1b1b-1b1b1b1b1b1b', '10853e59-dc5b-4d7b-a028-01513ef50d76', 'A1', 'available', ""),
   ('f2b2b2b2-2b2b-2b2b-2b2b-2b2b2b2b2b2b', '10853e59-dc5b-4d7b-a028-01513ef50d76', 'A2', 'available', ""),
   ('f3b3b3b3-3b3b-3b3b-3b3b-3b3b3b3b3b3b', '10853e59-dc5b-4d7b-a028-01513ef50d76', 'A3', 'available', ""),
@@ -62,7 +26,7 @@ INSERT INTO spots (id, event_id, name, status, ticket_id) VALUES
   ('6c7bdf8d-9146-43df-8b0b-3ae3d4c18cba', 'e0352b32-7698-4805-b029-28302b3a911f', 'B1', 'available', ""),
   ('e4e4e4e4-4e4e-4e4e-4e4e-4e4e4e4e4e4e', 'e0352b32-7698-4805-b029-28302b3a911f', 'B2', 'available', ""),
   ('e5e5e5e5-5e5e-5e5e-5e5e-5e5e5e5e5e5e', 'e0352b32-7698-4805-b029-28302b3a911f', 'B3', 'available', ""),
-  ('e6e6e6e6-6e6e-6e6e-6e6e-6e6e6e6e6e6e', 'e0352b32-7698-4805-b029-28302b3a911f', 'B4', 'available', ""), 
+  ('e6e6e6e6-6e6e-6e6e-6e6e-6e6e6e6e6e6e', 'e0352b32-7698-4805-b029-28302b3a911f', 'B4', 'available', ""),
   ('e7e7e7e7-7e7e-7e7e-7e7e-7e7e7e7e7e7e', 'e0352b32-7698-4805-b029-28302b3a911f', 'B5', 'available', ""),
   ('e8e8e8e8-8e8e-8e8e-8e8e-8e8e8e8e8e8e', '5b79831a-a9d3-4538-8fb5-569494bd17a5', 'A1', 'available', ""),
   ('e9e9e9e9-9e9e-9e9e-9e9e-9e9e9e9e9e9e', '5b79831a-a9d3-4538-8fb5-569494bd17a5', 'A2', 'available', ""),
@@ -85,8 +49,3 @@ INSERT INTO spots (id, event_id, name, status, ticket_id) VALUES
   ('f9f9f9f9-9f9f-9f9f-9f9f-9f9f9f9f9f9f', '8beff8fd-39e4-49ea-ae5e-a0ec9af888c5', 'B4', 'available', ""),
   ('g0g0g0g0-0g0g-0g0g-0g0g-0g0g0g0g0g0g', '8beff8fd-39e4-49ea-ae5e-a0ec9af888c5', 'B5', 'available', "")
 ;
-  
-  
-  
-  
-  
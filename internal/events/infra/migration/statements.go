@@ -0,0 +1,74 @@
+package migration
+
+import "strings"
+
+// splitStatements separa um script em comandos terminados por ";", ignorando os ";" dentro
+// de strings, identificadores entre crases e comentários. O driver do MySQL executa um
+// comando por chamada, a menos que a DSN habilite multiStatements.
+func splitStatements(script string) []string {
+	var statements []string
+	var current strings.Builder
+
+	flush := func() {
+		if statement := strings.TrimSpace(current.String()); statement != "" {
+			statements = append(statements, statement)
+		}
+		current.Reset()
+	}
+
+	for i := 0; i < len(script); i++ {
+		c := script[i]
+		switch {
+		case c == '\'' || c == '"' || c == '`':
+			// Copia a string inteira, respeitando escapes com barra invertida e aspas duplicadas.
+			end := i + 1
+			for end < len(script) {
+				if script[end] == '\\' && c != '`' {
+					end += 2
+					continue
+				}
+				if script[end] == c {
+					if end+1 < len(script) && script[end+1] == c {
+						end += 2
+						continue
+					}
+					break
+				}
+				end++
+			}
+			end = min(end, len(script)-1)
+			current.WriteString(script[i : end+1])
+			i = end
+		case isLineComment(script[i:]):
+			// Comentário de linha: descartado até o fim da linha.
+			end := strings.IndexByte(script[i:], '\n')
+			if end < 0 {
+				i = len(script)
+			} else {
+				i += end
+				current.WriteByte('\n')
+			}
+		case c == '/' && strings.HasPrefix(script[i:], "/*"):
+			end := strings.Index(script[i+2:], "*/")
+			if end < 0 {
+				i = len(script)
+			} else {
+				i += end + 3
+			}
+		case c == ';':
+			flush()
+		default:
+			current.WriteByte(c)
+		}
+	}
+	flush()
+	return statements
+}
+
+// isLineComment reconhece "#" e "-- " (o MySQL exige um espaço ou controle após os dois traços).
+func isLineComment(rest string) bool {
+	if rest[0] == '#' {
+		return true
+	}
+	return strings.HasPrefix(rest, "--") && (len(rest) == 2 || rest[2] <= ' ')
+}
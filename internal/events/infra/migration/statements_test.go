@@ -0,0 +1,41 @@
+package migration
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSplitStatements(t *testing.T) {
+	tests := []struct {
+		name   string
+		script string
+		want   []string
+	}{
+		{name: "empty", script: "", want: nil},
+		{name: "only whitespace and separators", script: " ;\n; ;", want: nil},
+		{name: "two statements", script: "CREATE TABLE a (id INT);\nCREATE TABLE b (id INT);\n", want: []string{"CREATE TABLE a (id INT)", "CREATE TABLE b (id INT)"}},
+		{name: "last statement without separator", script: "SELECT 1; SELECT 2", want: []string{"SELECT 1", "SELECT 2"}},
+		{name: "separator in a single-quoted string", script: "INSERT INTO t VALUES ('a;b'); SELECT 1", want: []string{"INSERT INTO t VALUES ('a;b')", "SELECT 1"}},
+		{name: "separator in a double-quoted string", script: `INSERT INTO t VALUES ("a;b");`, want: []string{`INSERT INTO t VALUES ("a;b")`}},
+		{name: "separator in a backtick identifier", script: "SELECT 1 AS `a;b`;", want: []string{"SELECT 1 AS `a;b`"}},
+		{name: "backslash escape", script: `INSERT INTO t VALUES ('it\'s; fine'); SELECT 1`, want: []string{`INSERT INTO t VALUES ('it\'s; fine')`, "SELECT 1"}},
+		{name: "doubled quote", script: "INSERT INTO t VALUES ('it''s; fine'); SELECT 1", want: []string{"INSERT INTO t VALUES ('it''s; fine')", "SELECT 1"}},
+		{name: "backslash does not escape backticks", script: "SELECT 1 AS `a\\`; SELECT 2", want: []string{"SELECT 1 AS `a\\`", "SELECT 2"}},
+		{name: "hash comment", script: "# drop; everything\nSELECT 1;", want: []string{"SELECT 1"}},
+		{name: "dash comment", script: "SELECT 1; -- not; a statement\nSELECT 2;", want: []string{"SELECT 1", "SELECT 2"}},
+		{name: "comment at the end without newline", script: "SELECT 1; -- the end;", want: []string{"SELECT 1"}},
+		{name: "double dash without space is an operator", script: "SELECT 1--1;", want: []string{"SELECT 1--1"}},
+		{name: "block comment", script: "SELECT /* a; b */ 1; /* trailing; */", want: []string{"SELECT  1"}},
+		{name: "unterminated block comment", script: "SELECT 1; /* a; b", want: []string{"SELECT 1"}},
+		{name: "comment markers inside strings", script: "INSERT INTO t VALUES ('# -- /*'); SELECT 1", want: []string{"INSERT INTO t VALUES ('# -- /*')", "SELECT 1"}},
+		{name: "unterminated string", script: "SELECT 'a; b", want: []string{"SELECT 'a; b"}},
+		{name: "trailing backslash in an unterminated string", script: `SELECT 'a\`, want: []string{`SELECT 'a\`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := splitStatements(tt.script); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitStatements(%q) = %q, want %q", tt.script, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+)
+
+// CreateCheckoutSaga insere o registro de uma nova saga de compra.
+func (r *mysqlEventRepository) CreateCheckoutSaga(ctx context.Context, saga *domain.CheckoutSaga) error {
+	spots, err := json.Marshal(saga.Spots)
+	if err != nil {
+		return err
+	}
+	reservationIDs, err := json.Marshal(saga.ReservationIDs)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO checkout_sagas (id, event_id, partner_id, spots, ticket_kind, email, reservation_ids, status, attempts, last_error, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err = r.db.ExecContext(ctx, query,
+		saga.ID, saga.EventID, saga.PartnerID, string(spots), saga.TicketKind, saga.Email, string(reservationIDs),
+		saga.Status, saga.Attempts, saga.LastError,
+		formatDateTime(saga.CreatedAt), formatDateTime(saga.UpdatedAt),
+	)
+	return err
+}
+
+// UpdateCheckoutSaga atualiza o estado de uma saga de compra.
+func (r *mysqlEventRepository) UpdateCheckoutSaga(ctx context.Context, saga *domain.CheckoutSaga) error {
+	reservationIDs, err := json.Marshal(saga.ReservationIDs)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE checkout_sagas
+		SET reservation_ids = ?, status = ?, attempts = ?, last_error = ?, updated_at = ?
+		WHERE id = ?
+	`
+	_, err = r.db.ExecContext(ctx, query, string(reservationIDs), saga.Status, saga.Attempts, saga.LastError, formatDateTime(saga.UpdatedAt), saga.ID)
+	return err
+}
+
+// FindPendingCheckoutSagas busca as sagas que ainda precisam de compensação.
+func (r *mysqlEventRepository) FindPendingCheckoutSagas(ctx context.Context, staleBefore time.Time) ([]*domain.CheckoutSaga, error) {
+	query := `
+		SELECT id, event_id, partner_id, spots, ticket_kind, email, reservation_ids, status, attempts, last_error, created_at, updated_at
+		FROM checkout_sagas
+		WHERE status = ? OR (status IN (?, ?) AND updated_at < ?)
+		ORDER BY created_at
+	`
+	rows, err := r.db.QueryContext(ctx, query,
+		domain.CheckoutSagaCompensating,
+		domain.CheckoutSagaStarted, domain.CheckoutSagaReserved, formatDateTime(staleBefore),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var sagas []*domain.CheckoutSaga
+	for rows.Next() {
+		var saga domain.CheckoutSaga
+		var spots, reservationIDs, createdAt, updatedAt string
+		var lastError sql.NullString
+		if err := rows.Scan(
+			&saga.ID, &saga.EventID, &saga.PartnerID, &spots, &saga.TicketKind, &saga.Email, &reservationIDs,
+			&saga.Status, &saga.Attempts, &lastError, &createdAt, &updatedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal([]byte(spots), &saga.Spots); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(reservationIDs), &saga.ReservationIDs); err != nil {
+			return nil, err
+		}
+		saga.LastError = lastError.String
+		if saga.CreatedAt, err = parseDateTime(createdAt); err != nil {
+			return nil, err
+		}
+		if saga.UpdatedAt, err = parseDateTime(updatedAt); err != nil {
+			return nil, err
+		}
+		sagas = append(sagas, &saga)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sagas, nil
+}
@@ -0,0 +1,675 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/infra/migration"
+	_ "github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
+)
+
+func TestMemoryEventRepositoryConformance(t *testing.T) {
+	runEventRepositoryConformance(t, func(t *testing.T) domain.Repositories {
+		return NewMemoryEventRepository()
+	})
+}
+
+// TestMysqlEventRepositoryConformance roda contra o banco de EVENTS_TEST_MYSQL_DSN, aplicando as
+// migrações. Cada teste usa IDs e códigos novos, então o banco não precisa estar vazio.
+func TestMysqlEventRepositoryConformance(t *testing.T) {
+	dsn := os.Getenv("EVENTS_TEST_MYSQL_DSN")
+	if dsn == "" {
+		t.Skip("EVENTS_TEST_MYSQL_DSN not set")
+	}
+
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	migrator, err := migration.NewMigrator(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	runEventRepositoryConformance(t, func(t *testing.T) domain.Repositories {
+		repo, err := NewMysqlEventRepository(db)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return repo
+	})
+
+	t.Run("coupon limit holds across concurrent checkouts", func(t *testing.T) {
+		repo, err := NewMysqlEventRepository(db)
+		if err != nil {
+			t.Fatal(err)
+		}
+		testConcurrentCouponRedemptions(t, repo)
+	})
+}
+
+// testConcurrentCouponRedemptions resgata um cupom da organização, limitado a um uso, em compras
+// simultâneas de eventos diferentes (que não se bloqueiam pelo evento). Cada transação faz uma
+// leitura comum antes de bloquear o cupom, como o checkout, e todas esperam as demais chegarem a
+// esse ponto: a contagem precisa enxergar o resgate confirmado depois desse snapshot. Só roda no
+// MySQL, já que RunInTx em memória executa uma transação por vez e a espera nunca terminaria.
+func testConcurrentCouponRedemptions(t *testing.T, repo domain.Repositories) {
+	ctx := context.Background()
+	const buyers = 3
+	events := make([]*domain.Event, buyers)
+	for i := range events {
+		events[i] = createConformanceEvent(t, repo, "A1")
+	}
+	now := time.Now().UTC().Truncate(time.Second)
+	coupon, err := domain.NewCoupon(domain.Coupon{
+		Code:           conformanceCouponCode(),
+		DiscountType:   domain.CouponDiscountFixed,
+		Amount:         domain.NewMoney(1500, events[0].Price.Currency),
+		ValidFrom:      now.Add(-time.Hour),
+		Organization:   events[0].Organization,
+		MaxRedemptions: 1,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	coupon.CreatedAt = now
+	if err := repo.CreateCoupon(ctx, coupon); err != nil {
+		t.Fatal(err)
+	}
+
+	var snapshots sync.WaitGroup
+	snapshots.Add(buyers)
+	errs := make([]error, buyers)
+	var wg sync.WaitGroup
+	for i := range events {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = repo.RunInTx(ctx, func(tx domain.Repositories) error {
+				event, err := tx.FindEventByID(ctx, events[i].ID)
+				snapshots.Done()
+				if err != nil {
+					return err
+				}
+				snapshots.Wait()
+
+				locked, err := tx.FindCouponByCode(ctx, coupon.Code)
+				if err != nil {
+					return err
+				}
+				email := fmt.Sprintf("buyer%d@example.com", i)
+				usage, err := tx.CountCouponRedemptions(ctx, locked.ID, email)
+				if err != nil {
+					return err
+				}
+				if err := locked.CheckUsage(usage); err != nil {
+					return err
+				}
+
+				spot, err := tx.FindSpotByName(ctx, event.ID, "A1")
+				if err != nil {
+					return err
+				}
+				ticket, err := domain.NewTicket(event, spot, domain.TicketKindFull, "")
+				if err != nil {
+					return err
+				}
+				if err := tx.CreateTicket(ctx, ticket); err != nil {
+					return err
+				}
+				return tx.CreateCouponRedemption(ctx, domain.NewCouponRedemption(locked, uuid.New().String(), ticket, email, locked.Amount))
+			})
+		}()
+	}
+	wg.Wait()
+
+	redeemed := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			redeemed++
+		case !errors.Is(err, domain.ErrCouponExhausted):
+			t.Errorf("redemption error = %v, want %v", err, domain.ErrCouponExhausted)
+		}
+	}
+	if redeemed != 1 {
+		t.Errorf("successful redemptions = %d, want 1", redeemed)
+	}
+	usage, err := repo.CountCouponRedemptions(ctx, coupon.ID, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if usage.Total != 1 {
+		t.Errorf("stored redemptions = %d, want 1", usage.Total)
+	}
+}
+
+// runEventRepositoryConformance verifica o contrato de domain.EventRepository que os casos de uso
+// assumem, para que as implementações em memória e MySQL se comportem da mesma forma.
+func runEventRepositoryConformance(t *testing.T, newRepo func(t *testing.T) domain.Repositories) {
+	ctx := context.Background()
+
+	t.Run("FindEventByID returns ErrEventNotFound", func(t *testing.T) {
+		repo := newRepo(t)
+		if _, err := repo.FindEventByID(ctx, uuid.New().String()); !errors.Is(err, domain.ErrEventNotFound) {
+			t.Errorf("error = %v, want %v", err, domain.ErrEventNotFound)
+		}
+	})
+
+	t.Run("FindSpotByName returns ErrSpotNotFound", func(t *testing.T) {
+		repo := newRepo(t)
+		event := createConformanceEvent(t, repo, "A1")
+		if _, err := repo.FindSpotByName(ctx, event.ID, "Z9"); !errors.Is(err, domain.ErrSpotNotFound) {
+			t.Errorf("error = %v, want %v", err, domain.ErrSpotNotFound)
+		}
+	})
+
+	t.Run("ReserveSpot rejects a stale version", func(t *testing.T) {
+		repo := newRepo(t)
+		event := createConformanceEvent(t, repo, "A1")
+		first := findSpot(t, repo, event, "A1")
+		second := findSpot(t, repo, event, "A1")
+
+		ticket := createConformanceTicket(t, repo, event, first)
+		if err := first.Reserve(ticket.ID); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.ReserveSpot(ctx, first); err != nil {
+			t.Fatalf("first ReserveSpot: %v", err)
+		}
+		if first.Version != second.Version+1 {
+			t.Errorf("version after ReserveSpot = %d, want %d", first.Version, second.Version+1)
+		}
+
+		second.TicketID = ticket.ID
+		if err := repo.ReserveSpot(ctx, second); !errors.Is(err, domain.ErrSpotAlreadyReserved) {
+			t.Errorf("stale ReserveSpot error = %v, want %v", err, domain.ErrSpotAlreadyReserved)
+		}
+
+		stored := findSpot(t, repo, event, "A1")
+		if stored.Status != domain.SpotStatusSold || stored.TicketID != ticket.ID || stored.Version != first.Version {
+			t.Errorf("stored spot = {%s %q %d}, want {%s %q %d}", stored.Status, stored.TicketID, stored.Version, domain.SpotStatusSold, ticket.ID, first.Version)
+		}
+	})
+
+	t.Run("ReserveSpot with one version sells the spot once across concurrent transactions", func(t *testing.T) {
+		repo := newRepo(t)
+		event := createConformanceEvent(t, repo, "A1")
+		const buyers = 4
+		// Todas as compras leram o spot na mesma versão antes de abrir as suas transações.
+		spots := make([]*domain.Spot, buyers)
+		for i := range spots {
+			spots[i] = findSpot(t, repo, event, "A1")
+		}
+
+		errs := make([]error, buyers)
+		var wg sync.WaitGroup
+		for i, spot := range spots {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				errs[i] = repo.RunInTx(ctx, func(tx domain.Repositories) error {
+					ticket, err := domain.NewTicket(event, spot, domain.TicketKindFull, "")
+					if err != nil {
+						return err
+					}
+					if err := tx.CreateTicket(ctx, ticket); err != nil {
+						return err
+					}
+					if err := spot.Reserve(ticket.ID); err != nil {
+						return err
+					}
+					return tx.ReserveSpot(ctx, spot)
+				})
+			}()
+		}
+		wg.Wait()
+
+		sold := 0
+		for _, err := range errs {
+			switch {
+			case err == nil:
+				sold++
+			case !errors.Is(err, domain.ErrSpotAlreadyReserved):
+				t.Errorf("ReserveSpot error = %v, want %v", err, domain.ErrSpotAlreadyReserved)
+			}
+		}
+		if sold != 1 {
+			t.Errorf("successful reservations = %d, want 1", sold)
+		}
+		if tickets := findEvent(t, repo, event.ID).Tickets; len(tickets) != 1 {
+			t.Errorf("tickets = %d, want only the winner's ticket", len(tickets))
+		}
+	})
+
+	t.Run("HoldSpot honours owner and expiry", func(t *testing.T) {
+		repo := newRepo(t)
+		event := createConformanceEvent(t, repo, "A1", "A2")
+		now := time.Now()
+
+		held := holdSpot(t, repo, event, "A1", "alice", now.Add(10*time.Minute))
+		stored := findSpot(t, repo, event, "A1")
+		if !stored.IsHeldBy("alice", now) || stored.Version != held.Version {
+			t.Errorf("stored spot = {%s %q %d}, want held by alice at version %d", stored.Status, stored.HoldOwner, stored.Version, held.Version)
+		}
+
+		// Outra sessão não toma uma retenção ativa; a mesma sessão pode renová-la.
+		other := findSpot(t, repo, event, "A1")
+		other.HoldOwner, other.HoldExpiresAt = "bob", now.Add(10*time.Minute)
+		if err := repo.HoldSpot(ctx, other); !errors.Is(err, domain.ErrSpotHeld) {
+			t.Errorf("HoldSpot by another owner error = %v, want %v", err, domain.ErrSpotHeld)
+		}
+		holdSpot(t, repo, event, "A1", "alice", now.Add(20*time.Minute))
+
+		// Uma retenção expirada pode ser tomada por outra sessão.
+		expired := holdSpot(t, repo, event, "A2", "alice", now.Add(-time.Minute))
+		expired.HoldOwner, expired.HoldExpiresAt = "bob", now.Add(10*time.Minute)
+		if err := repo.HoldSpot(ctx, expired); err != nil {
+			t.Errorf("HoldSpot over an expired hold: %v", err)
+		}
+		if stored := findSpot(t, repo, event, "A2"); !stored.IsHeldBy("bob", now) {
+			t.Errorf("spot A2 held by %q, want bob", stored.HoldOwner)
+		}
+	})
+
+	t.Run("ReleaseExpiredHolds releases only expired holds", func(t *testing.T) {
+		repo := newRepo(t)
+		event := createConformanceEvent(t, repo, "A1", "A2")
+		now := time.Now()
+		holdSpot(t, repo, event, "A1", "alice", now.Add(-time.Minute))
+		holdSpot(t, repo, event, "A2", "bob", now.Add(10*time.Minute))
+
+		if _, err := repo.ReleaseExpiredHolds(ctx, now); err != nil {
+			t.Fatal(err)
+		}
+		if stored := findSpot(t, repo, event, "A1"); stored.Status != domain.SpotStatusAvailable || stored.HoldOwner != "" {
+			t.Errorf("expired spot = {%s %q}, want available without owner", stored.Status, stored.HoldOwner)
+		}
+		if stored := findSpot(t, repo, event, "A2"); !stored.IsHeldBy("bob", now) {
+			t.Errorf("active hold released: spot A2 = {%s %q}", stored.Status, stored.HoldOwner)
+		}
+	})
+
+	t.Run("RunInTx rolls back when fn fails", func(t *testing.T) {
+		repo := newRepo(t)
+		event := createConformanceEvent(t, repo, "A1")
+		failure := errors.New("rollback")
+
+		var created *domain.Event
+		err := repo.RunInTx(ctx, func(tx domain.Repositories) error {
+			created = newConformanceEvent(t)
+			if err := tx.CreateEvent(ctx, created); err != nil {
+				return err
+			}
+			spot := findSpot(t, tx, event, "A1")
+			spot.HoldOwner, spot.HoldExpiresAt = "alice", time.Now().Add(10*time.Minute)
+			if err := tx.HoldSpot(ctx, spot); err != nil {
+				return err
+			}
+			return failure
+		})
+		if !errors.Is(err, failure) {
+			t.Fatalf("RunInTx error = %v, want %v", err, failure)
+		}
+
+		if _, err := repo.FindEventByID(ctx, created.ID); !errors.Is(err, domain.ErrEventNotFound) {
+			t.Errorf("event created in the rolled back transaction: error = %v, want %v", err, domain.ErrEventNotFound)
+		}
+		if stored := findSpot(t, repo, event, "A1"); stored.Status != domain.SpotStatusAvailable || stored.Version != 0 {
+			t.Errorf("spot changed in the rolled back transaction: {%s %d}", stored.Status, stored.Version)
+		}
+	})
+
+	t.Run("venues", func(t *testing.T) {
+		repo := newRepo(t)
+		venue, err := domain.NewVenue("Teatro "+uuid.New().String(), "Rua A, 1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := venue.AddSection("Plateia", []domain.RowPlan{{Label: "A", Seats: 3}, {Label: "B", Seats: 2}}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := venue.AddSection("Camarote", []domain.RowPlan{{Label: "CA", Seats: 1}}); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.CreateVenue(ctx, venue); err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := repo.FindVenueByID(ctx, uuid.New().String()); !errors.Is(err, domain.ErrVenueNotFound) {
+			t.Errorf("unknown venue error = %v, want %v", err, domain.ErrVenueNotFound)
+		}
+		found, err := repo.FindVenueByID(ctx, venue.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(found, venue) {
+			t.Errorf("venue = %+v, want %+v", found, venue)
+		}
+
+		venues, err := repo.ListVenues(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		listed := false
+		for _, v := range venues {
+			if v.ID == venue.ID {
+				listed = true
+				if v.Name != venue.Name || v.Address != venue.Address || len(v.Sections) != 0 {
+					t.Errorf("listed venue = %+v, want name, address and no sections", v)
+				}
+			}
+		}
+		if !listed {
+			t.Error("ListVenues does not include the new venue")
+		}
+	})
+
+	t.Run("price tiers", func(t *testing.T) {
+		repo := newRepo(t)
+		event := newConformanceEvent(t)
+		tier, err := event.AddPriceTier("VIP", domain.NewMoney(25000, event.Price.Currency))
+		if err != nil {
+			t.Fatal(err)
+		}
+		spot, err := event.AddSpot("A1")
+		if err != nil {
+			t.Fatal(err)
+		}
+		spot.TierID = tier.ID
+		if err := repo.CreateEvent(ctx, event); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.CreatePriceTier(ctx, tier); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.CreateSpot(ctx, spot); err != nil {
+			t.Fatal(err)
+		}
+
+		stored := findEvent(t, repo, event.ID)
+		if len(stored.Tiers) != 1 || stored.Tiers[0] != *tier {
+			t.Fatalf("tiers = %+v, want [%+v]", stored.Tiers, *tier)
+		}
+		if price := stored.SpotPrice(findSpot(t, repo, event, "A1")); price != tier.Price {
+			t.Errorf("spot price = %v, want the tier price %v", price, tier.Price)
+		}
+	})
+
+	t.Run("ListEvents filters and sorts by the lowest tier price", func(t *testing.T) {
+		repo := newRepo(t)
+		organization := "conformance-" + uuid.New().String()
+		createIn := func(currency domain.Currency, name string, price int64, tierPrices ...int64) *domain.Event {
+			event := newConformanceEvent(t)
+			event.Name = name
+			event.Organization = organization
+			event.Price = domain.NewMoney(price, currency)
+			if err := repo.CreateEvent(ctx, event); err != nil {
+				t.Fatal(err)
+			}
+			for i, amount := range tierPrices {
+				tier, err := event.AddPriceTier(fmt.Sprintf("Tier %d", i), domain.NewMoney(amount, event.Price.Currency))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if err := repo.CreatePriceTier(ctx, tier); err != nil {
+					t.Fatal(err)
+				}
+			}
+			return event
+		}
+		create := func(name string, price int64, tierPrices ...int64) *domain.Event {
+			return createIn(domain.DefaultCurrency, name, price, tierPrices...)
+		}
+		cheapTier := create("Cheap tier", 10000, 3000, 20000) // menor preço 3000
+		noTiers := create("No tiers", 5000)                   // menor preço 5000
+		dearTier := create("Dear tier", 10000, 25000)         // menor preço 10000
+		// Em outra moeda, o valor menor não o coloca entre os eventos em BRL.
+		dollars := createIn(domain.CurrencyUSD, "Dollars", 1000)
+
+		list := func(q domain.EventQuery) []string {
+			t.Helper()
+			q.Organization = organization
+			q.SortBy = domain.EventSortByPrice
+			q.Limit = 10
+			if q.Currency == "" {
+				q.Currency = domain.DefaultCurrency
+			}
+			events, err := repo.ListEvents(ctx, q)
+			if err != nil {
+				t.Fatal(err)
+			}
+			names := []string{}
+			for _, event := range events {
+				names = append(names, event.Name)
+			}
+			return names
+		}
+
+		want := []string{cheapTier.Name, noTiers.Name, dearTier.Name}
+		if got := list(domain.EventQuery{}); !reflect.DeepEqual(got, want) {
+			t.Errorf("sorted by price = %v, want %v", got, want)
+		}
+		want = []string{cheapTier.Name, noTiers.Name}
+		if got := list(domain.EventQuery{PriceMax: 6000}); !reflect.DeepEqual(got, want) {
+			t.Errorf("price_max 6000 = %v, want %v", got, want)
+		}
+		want = []string{noTiers.Name, dearTier.Name}
+		if got := list(domain.EventQuery{PriceMin: 4000}); !reflect.DeepEqual(got, want) {
+			t.Errorf("price_min 4000 = %v, want %v", got, want)
+		}
+
+		want = []string{dollars.Name}
+		if got := list(domain.EventQuery{Currency: domain.CurrencyUSD, PriceMax: 6000}); !reflect.DeepEqual(got, want) {
+			t.Errorf("USD price_max 60.00 = %v, want %v", got, want)
+		}
+
+		events, err := repo.ListEvents(ctx, domain.EventQuery{Organization: organization, Currency: domain.DefaultCurrency, SortBy: domain.EventSortByPrice, Limit: 1})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(events) != 1 || events[0].Cursor().Price != 3000 {
+			t.Fatalf("first page = %+v, want the event whose lowest price is 3000", events)
+		}
+		cursor := events[0].Cursor()
+		want = []string{noTiers.Name, dearTier.Name}
+		if got := list(domain.EventQuery{After: &cursor}); !reflect.DeepEqual(got, want) {
+			t.Errorf("after the first page = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("ticket kind rules", func(t *testing.T) {
+		repo := newRepo(t)
+		event := createConformanceEvent(t, repo)
+		if kinds := findEvent(t, repo, event.ID).TicketKinds; len(kinds) != 0 {
+			t.Fatalf("ticket kinds of a new event = %+v, want none", kinds)
+		}
+
+		rules := []domain.TicketKindRule{
+			{Kind: "student", Name: "Meia-entrada estudante", Discount: 5000, QuotaPercent: 40, Document: domain.EligibilityDocumentStudentID, CompanionSeats: 1},
+			{Kind: domain.TicketKindFull, Name: "Inteira"},
+		}
+		for _, rule := range rules {
+			if err := event.AddTicketKindRule(rule); err != nil {
+				t.Fatal(err)
+			}
+		}
+		for i := range event.TicketKinds {
+			if err := repo.CreateTicketKindRule(ctx, &event.TicketKinds[i]); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		stored := findEvent(t, repo, event.ID).TicketKinds
+		if len(stored) != len(event.TicketKinds) {
+			t.Fatalf("ticket kinds = %+v, want %+v", stored, event.TicketKinds)
+		}
+		for i := range stored {
+			if stored[i] != event.TicketKinds[i] {
+				t.Errorf("ticket kind %d = %+v, want %+v", i, stored[i], event.TicketKinds[i])
+			}
+		}
+	})
+
+	t.Run("coupons", func(t *testing.T) {
+		repo := newRepo(t)
+		event := createConformanceEvent(t, repo, "A1", "A2")
+		now := time.Now().UTC().Truncate(time.Second)
+
+		coupon, err := domain.NewCoupon(domain.Coupon{
+			Code:                   conformanceCouponCode(),
+			DiscountType:           domain.CouponDiscountFixed,
+			Amount:                 domain.NewMoney(1500, event.Price.Currency),
+			ValidFrom:              now.Add(-time.Hour),
+			ValidUntil:             now.Add(time.Hour),
+			EventID:                event.ID,
+			Organization:           event.Organization,
+			MaxRedemptions:         10,
+			MaxRedemptionsPerEmail: 2,
+			StacksWithTicketKinds:  true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		coupon.CreatedAt = now
+		if err := repo.CreateCoupon(ctx, coupon); err != nil {
+			t.Fatal(err)
+		}
+
+		duplicate := *coupon
+		duplicate.ID = uuid.New().String()
+		if err := repo.CreateCoupon(ctx, &duplicate); !errors.Is(err, domain.ErrCouponCodeTaken) {
+			t.Errorf("duplicate code error = %v, want %v", err, domain.ErrCouponCodeTaken)
+		}
+		if _, err := repo.FindCouponByCode(ctx, conformanceCouponCode()); !errors.Is(err, domain.ErrCouponNotFound) {
+			t.Errorf("unknown code error = %v, want %v", err, domain.ErrCouponNotFound)
+		}
+
+		found, err := repo.FindCouponByCode(ctx, coupon.Code)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !found.ValidFrom.Equal(coupon.ValidFrom) || !found.ValidUntil.Equal(coupon.ValidUntil) || !found.CreatedAt.Equal(coupon.CreatedAt) {
+			t.Errorf("coupon validity = [%v, %v) created %v, want [%v, %v) created %v",
+				found.ValidFrom, found.ValidUntil, found.CreatedAt, coupon.ValidFrom, coupon.ValidUntil, coupon.CreatedAt)
+		}
+		found.ValidFrom, found.ValidUntil, found.CreatedAt = coupon.ValidFrom, coupon.ValidUntil, coupon.CreatedAt
+		if *found != *coupon {
+			t.Errorf("coupon = %+v, want %+v", *found, *coupon)
+		}
+
+		// Dois ingressos na mesma compra contam um uso; outra compra, de outro e-mail, conta mais um.
+		checkout := uuid.New().String()
+		for _, name := range []string{"A1", "A2"} {
+			ticket := createConformanceTicket(t, repo, event, findSpot(t, repo, event, name))
+			redemption := domain.NewCouponRedemption(coupon, checkout, ticket, "Buyer@Example.com", coupon.Amount)
+			if err := repo.CreateCouponRedemption(ctx, redemption); err != nil {
+				t.Fatal(err)
+			}
+		}
+		usage, err := repo.CountCouponRedemptions(ctx, coupon.ID, "buyer@example.com")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if usage != (domain.CouponUsage{Total: 1, ByEmail: 1}) {
+			t.Errorf("usage = %+v, want {Total:1 ByEmail:1}", usage)
+		}
+		usage, err = repo.CountCouponRedemptions(ctx, coupon.ID, "other@example.com")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if usage != (domain.CouponUsage{Total: 1, ByEmail: 0}) {
+			t.Errorf("usage for another email = %+v, want {Total:1 ByEmail:0}", usage)
+		}
+	})
+}
+
+func newConformanceEvent(t *testing.T) *domain.Event {
+	t.Helper()
+	date := time.Now().UTC().Truncate(time.Second).Add(30 * 24 * time.Hour)
+	event, err := domain.NewEvent("Conformance", "Arena", "acme", domain.RatingLivre, date, 10, domain.NewMoney(10000, domain.DefaultCurrency), "", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	event.Status = domain.EventStatusSalesOpen
+	return event
+}
+
+// createConformanceEvent persiste um evento com os spots informados.
+func createConformanceEvent(t *testing.T, repo domain.EventRepository, spots ...string) *domain.Event {
+	t.Helper()
+	event := newConformanceEvent(t)
+	if err := repo.CreateEvent(context.Background(), event); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range spots {
+		spot, err := event.AddSpot(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.CreateSpot(context.Background(), spot); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return event
+}
+
+func createConformanceTicket(t *testing.T, repo domain.EventRepository, event *domain.Event, spot *domain.Spot) *domain.Ticket {
+	t.Helper()
+	ticket, err := domain.NewTicket(event, spot, domain.TicketKindFull, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.CreateTicket(context.Background(), ticket); err != nil {
+		t.Fatal(err)
+	}
+	return ticket
+}
+
+func findEvent(t *testing.T, repo domain.EventRepository, eventID string) *domain.Event {
+	t.Helper()
+	event, err := repo.FindEventByID(context.Background(), eventID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return event
+}
+
+func findSpot(t *testing.T, repo domain.EventRepository, event *domain.Event, name string) *domain.Spot {
+	t.Helper()
+	spot, err := repo.FindSpotByName(context.Background(), event.ID, name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return spot
+}
+
+// holdSpot grava a retenção diretamente, sem as regras de Spot.Hold, para permitir retenções já expiradas.
+func holdSpot(t *testing.T, repo domain.EventRepository, event *domain.Event, name, owner string, expiresAt time.Time) *domain.Spot {
+	t.Helper()
+	spot := findSpot(t, repo, event, name)
+	spot.HoldOwner, spot.HoldExpiresAt = owner, expiresAt
+	if err := repo.HoldSpot(context.Background(), spot); err != nil {
+		t.Fatalf("HoldSpot %s for %s: %v", name, owner, err)
+	}
+	return spot
+}
+
+// conformanceCouponCode gera um código novo, já que o banco MySQL é compartilhado entre execuções.
+func conformanceCouponCode() string {
+	return "T" + strings.ToUpper(strings.ReplaceAll(uuid.New().String(), "-", ""))[:20]
+}
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+)
+
+// CreateCoupon insere o cupom. INSERT IGNORE não falha com código duplicado: nenhuma linha
+// afetada significa que o código já existe.
+func (r *mysqlEventRepository) CreateCoupon(ctx context.Context, coupon *domain.Coupon) error {
+	query := `
+		INSERT IGNORE INTO coupons (
+			id, code, discount_type, percent_bps, amount, currency, valid_from, valid_until, event_id, organization,
+			max_redemptions, max_redemptions_per_email, stacks_with_coupons, stacks_with_ticket_kinds, created_at
+		)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	var validUntil sql.NullString
+	if !coupon.ValidUntil.IsZero() {
+		validUntil = nullString(formatDateTime(coupon.ValidUntil))
+	}
+	result, err := r.db.ExecContext(ctx, query,
+		coupon.ID, coupon.Code, coupon.DiscountType, coupon.Percent, coupon.Amount.Amount, nullString(string(coupon.Amount.Currency)),
+		formatDateTime(coupon.ValidFrom), validUntil, nullString(coupon.EventID), coupon.Organization,
+		coupon.MaxRedemptions, coupon.MaxRedemptionsPerEmail, coupon.StacksWithCoupons, coupon.StacksWithTicketKinds,
+		formatDateTime(coupon.CreatedAt),
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("%w: %s", domain.ErrCouponCodeTaken, coupon.Code)
+	}
+	return nil
+}
+
+// FindCouponByCode lê o cupom com SELECT ... FOR UPDATE; fora de uma transação o lock é liberado ao fim do comando.
+func (r *mysqlEventRepository) FindCouponByCode(ctx context.Context, code string) (*domain.Coupon, error) {
+	query := `
+		SELECT
+			id, code, discount_type, percent_bps, amount, currency, valid_from, valid_until, event_id, organization,
+			max_redemptions, max_redemptions_per_email, stacks_with_coupons, stacks_with_ticket_kinds, created_at
+		FROM coupons
+		WHERE code = ?
+		FOR UPDATE
+	`
+	var coupon domain.Coupon
+	var amount int64
+	var currency, validUntil, eventID sql.NullString
+	var validFrom, createdAt string
+	err := r.db.QueryRowContext(ctx, query, code).Scan(
+		&coupon.ID, &coupon.Code, &coupon.DiscountType, &coupon.Percent, &amount, &currency, &validFrom, &validUntil, &eventID, &coupon.Organization,
+		&coupon.MaxRedemptions, &coupon.MaxRedemptionsPerEmail, &coupon.StacksWithCoupons, &coupon.StacksWithTicketKinds, &createdAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrCouponNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	coupon.Amount = domain.NewMoney(amount, domain.Currency(currency.String))
+	coupon.EventID = eventID.String
+	if coupon.ValidFrom, err = parseDateTime(validFrom); err != nil {
+		return nil, err
+	}
+	if coupon.CreatedAt, err = parseDateTime(createdAt); err != nil {
+		return nil, err
+	}
+	if validUntil.Valid {
+		if coupon.ValidUntil, err = parseDateTime(validUntil.String); err != nil {
+			return nil, err
+		}
+	}
+	return &coupon, nil
+}
+
+// CountCouponRedemptions conta as compras distintas (checkout_id), já que cada ingresso tem a sua linha.
+// A contagem é uma leitura bloqueante (FOR SHARE): em REPEATABLE READ, uma leitura comum usaria o
+// snapshot fixado pela primeira consulta da transação, anterior ao lock do cupom, e não veria os
+// resgates confirmados pela compra que o segurava.
+func (r *mysqlEventRepository) CountCouponRedemptions(ctx context.Context, couponID, email string) (domain.CouponUsage, error) {
+	query := `
+		SELECT COUNT(DISTINCT checkout_id), COUNT(DISTINCT CASE WHEN email = ? THEN checkout_id END)
+		FROM coupon_redemptions
+		WHERE coupon_id = ?
+		FOR SHARE
+	`
+	var usage domain.CouponUsage
+	err := r.db.QueryRowContext(ctx, query, email, couponID).Scan(&usage.Total, &usage.ByEmail)
+	return usage, err
+}
+
+func (r *mysqlEventRepository) CreateCouponRedemption(ctx context.Context, redemption *domain.CouponRedemption) error {
+	query := `
+		INSERT INTO coupon_redemptions (id, coupon_id, checkout_id, ticket_id, email, discount_amount, currency, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err := r.db.ExecContext(ctx, query,
+		redemption.ID, redemption.CouponID, redemption.CheckoutID, redemption.TicketID, redemption.Email,
+		redemption.Discount.Amount, redemption.Discount.Currency, formatDateTime(redemption.CreatedAt),
+	)
+	return err
+}
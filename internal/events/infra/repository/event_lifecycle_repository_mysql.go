@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+)
+
+// UpdateEvent atualiza os dados do evento com controle de concorrência otimista: a atualização
+// só acontece se a versão ainda for a lida pelo chamador; caso contrário retorna ErrEventModified.
+func (r *mysqlEventRepository) UpdateEvent(ctx context.Context, event *domain.Event) error {
+	query := `
+		UPDATE events
+		SET name = ?, location = ?, organization = ?, rating = ?, date = ?, image_url = ?, capacity = ?,
+			price_amount = ?, currency = ?, partner_id = ?, status = ?, version = version + 1
+		WHERE id = ? AND version = ?
+	`
+	result, err := r.db.ExecContext(ctx, query,
+		event.Name, event.Location, event.Organization, event.Rating, formatDateTime(event.Date), event.ImageURL, event.Capacity,
+		event.Price.Amount, event.Price.Currency, event.PartnerID, event.Status,
+		event.ID, event.Version,
+	)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrEventModified
+	}
+
+	event.Version++
+	return nil
+}
+
+// DeleteEvent remove os spots, as categorias de preço, os cupons, os tipos de ingresso e o evento. Os ingressos referenciam spots e eventos por chave
+// estrangeira, então a remoção falha se algum ingresso tiver sido emitido.
+func (r *mysqlEventRepository) DeleteEvent(ctx context.Context, eventID string) error {
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM spots WHERE event_id = ?", eventID); err != nil {
+		return err
+	}
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM event_price_tiers WHERE event_id = ?", eventID); err != nil {
+		return err
+	}
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM coupons WHERE event_id = ?", eventID); err != nil {
+		return err
+	}
+	if _, err := r.db.ExecContext(ctx, "DELETE FROM event_ticket_kinds WHERE event_id = ?", eventID); err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, "DELETE FROM events WHERE id = ?", eventID)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrEventNotFound
+	}
+	return nil
+}
+
+// DeleteSpot remove o spot com controle de concorrência otimista: se ele foi segurado ou
+// vendido depois da leitura, a versão não confere e retorna ErrSpotModified.
+func (r *mysqlEventRepository) DeleteSpot(ctx context.Context, spot *domain.Spot) error {
+	result, err := r.db.ExecContext(ctx, "DELETE FROM spots WHERE id = ? AND version = ?", spot.ID, spot.Version)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return domain.ErrSpotModified
+	}
+	return nil
+}
+
+// UpdateEventStatus muda o status apenas se o evento ainda estiver em from.
+func (r *mysqlEventRepository) UpdateEventStatus(ctx context.Context, eventID string, from, to domain.EventStatus) (bool, error) {
+	query := `
+		UPDATE events
+		SET status = ?, version = version + 1
+		WHERE id = ? AND status = ?
+	`
+	result, err := r.db.ExecContext(ctx, query, to, eventID, from)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// LockEventStatus lê o status com SELECT ... FOR UPDATE; fora de uma transação o lock é liberado ao fim do comando.
+func (r *mysqlEventRepository) LockEventStatus(ctx context.Context, eventID string) (domain.EventStatus, error) {
+	var status domain.EventStatus
+	err := r.db.QueryRowContext(ctx, "SELECT status FROM events WHERE id = ? FOR UPDATE", eventID).Scan(&status)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", domain.ErrEventNotFound
+	}
+	return status, err
+}
+
+// CountUnsoldSpots conta os spots disponíveis ou retidos do evento.
+func (r *mysqlEventRepository) CountUnsoldSpots(ctx context.Context, eventID string) (int, error) {
+	var count int
+	err := r.db.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM spots WHERE event_id = ? AND status <> ?",
+		eventID, domain.SpotStatusSold,
+	).Scan(&count)
+	return count, err
+}
+
+// MarkEventTicketsForRefund marca os ingressos emitidos do evento como aguardando reembolso.
+func (r *mysqlEventRepository) MarkEventTicketsForRefund(ctx context.Context, eventID string) (int64, error) {
+	result, err := r.db.ExecContext(ctx,
+		"UPDATE tickets SET status = ? WHERE event_id = ? AND status = ?",
+		domain.TicketStatusRefundPending, eventID, domain.TicketStatusIssued,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+)
+
+// eventSortColumns associa cada campo de ordenação à sua coluna. Apenas estes valores
+// são interpolados na query; todo o resto é passado como parâmetro.
+var eventSortColumns = map[domain.EventSortField]string{
+	domain.EventSortByDate:  "e.date",
+	domain.EventSortByPrice: eventLowestPrice,
+	domain.EventSortByName:  "e.name",
+}
+
+// eventLowestPrice é o menor preço cheio do evento (domain.Event.LowestPrice): o do evento ou
+// o da categoria de preço mais barata. Usado nos filtros de preço, na ordenação e no cursor.
+const eventLowestPrice = `LEAST(e.price_amount, COALESCE(
+	(SELECT MIN(t.price_amount) FROM event_price_tiers t WHERE t.event_id = e.id), e.price_amount
+))`
+
+// ListEvents consulta apenas a tabela de eventos: a disponibilidade é verificada com uma
+// subconsulta EXISTS, que para no primeiro spot encontrado, em vez de um JOIN com spots e tickets.
+func (r *mysqlEventRepository) ListEvents(ctx context.Context, q domain.EventQuery) ([]domain.Event, error) {
+	sortColumn, ok := eventSortColumns[q.SortBy]
+	if !ok {
+		return nil, fmt.Errorf("unsupported sort field %q", q.SortBy)
+	}
+
+	var conditions []string
+	var args []any
+
+	if !q.DateFrom.IsZero() {
+		conditions = append(conditions, "e.date >= ?")
+		args = append(args, formatDateTime(q.DateFrom))
+	}
+	if !q.DateTo.IsZero() {
+		conditions = append(conditions, "e.date <= ?")
+		args = append(args, formatDateTime(q.DateTo))
+	}
+	if q.Location != "" {
+		// A collation da coluna já ignora maiúsculas.
+		conditions = append(conditions, "e.location LIKE ?")
+		args = append(args, "%"+escapeLike(q.Location)+"%")
+	}
+	if q.Organization != "" {
+		conditions = append(conditions, "e.organization = ?")
+		args = append(args, q.Organization)
+	}
+	if q.Rating != "" {
+		conditions = append(conditions, "e.rating = ?")
+		args = append(args, q.Rating)
+	}
+	if q.PartnerID != 0 {
+		conditions = append(conditions, "e.partner_id = ?")
+		args = append(args, q.PartnerID)
+	}
+	if q.Currency != "" {
+		conditions = append(conditions, "e.currency = ?")
+		args = append(args, q.Currency)
+	}
+	if len(q.Statuses) > 0 {
+		placeholders := make([]string, len(q.Statuses))
+		for i, status := range q.Statuses {
+			placeholders[i] = "?"
+			args = append(args, status)
+		}
+		conditions = append(conditions, "e.status IN ("+strings.Join(placeholders, ", ")+")")
+	}
+	if q.PriceMin > 0 {
+		conditions = append(conditions, eventLowestPrice+" >= ?")
+		args = append(args, q.PriceMin)
+	}
+	if q.PriceMax > 0 {
+		conditions = append(conditions, eventLowestPrice+" <= ?")
+		args = append(args, q.PriceMax)
+	}
+	if q.HasAvailability {
+		// Um spot com retenção expirada pode ser comprado, mesmo antes de ser liberado pelo worker.
+		conditions = append(conditions, `EXISTS (
+			SELECT 1 FROM spots s
+			WHERE s.event_id = e.id AND (s.status = ? OR (s.status = ? AND s.hold_expires_at <= ?))
+		)`)
+		args = append(args, domain.SpotStatusAvailable, domain.SpotStatusReserved, formatDateTime(q.Now))
+	}
+
+	direction, comparison := "ASC", ">"
+	if q.SortDesc {
+		direction, comparison = "DESC", "<"
+	}
+	if q.After != nil {
+		var value any
+		switch q.SortBy {
+		case domain.EventSortByDate:
+			value = formatDateTime(q.After.Date)
+		case domain.EventSortByPrice:
+			value = q.After.Price
+		case domain.EventSortByName:
+			value = q.After.Name
+		}
+		conditions = append(conditions, fmt.Sprintf("(%[1]s %[2]s ? OR (%[1]s = ? AND e.id %[2]s ?))", sortColumn, comparison))
+		args = append(args, value, value, q.After.ID)
+	}
+
+	query := `
+		SELECT e.id, e.name, e.location, e.organization, e.rating, e.date, e.image_url, e.capacity, e.price_amount, e.currency, e.partner_id, e.venue_id, e.status, e.version
+		FROM events e
+	`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += fmt.Sprintf(" ORDER BY %[1]s %[2]s, e.id %[2]s LIMIT ?", sortColumn, direction)
+	args = append(args, q.Limit)
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	events := []domain.Event{}
+	for rows.Next() {
+		var event domain.Event
+		var eventDate string
+		var partnerID sql.NullInt32
+		var venueID sql.NullString
+		if err := rows.Scan(
+			&event.ID, &event.Name, &event.Location, &event.Organization, &event.Rating, &eventDate,
+			&event.ImageURL, &event.Capacity, &event.Price.Amount, &event.Price.Currency, &partnerID,
+			&venueID, &event.Status, &event.Version,
+		); err != nil {
+			return nil, err
+		}
+		event.Date, err = parseDateTime(eventDate)
+		if err != nil {
+			return nil, err
+		}
+		event.PartnerID = int(partnerID.Int32)
+		event.VenueID = venueID.String
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	// As categorias são carregadas para que Event.Cursor use o mesmo menor preço da ordenação.
+	eventIDs := make([]string, len(events))
+	for i := range events {
+		eventIDs[i] = events[i].ID
+	}
+	tiers, err := r.findEventsPriceTiers(ctx, eventIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range events {
+		events[i].Tiers = tiers[events[i].ID]
+	}
+	return events, nil
+}
+
+// escapeLike escapa os curingas do LIKE para que o valor seja procurado literalmente.
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(value)
+}
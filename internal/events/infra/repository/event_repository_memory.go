@@ -0,0 +1,789 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+)
+
+// memoryData guarda o estado do repositório em memória.
+// Os slices de ordem preservam a ordem de inserção nas listagens.
+type memoryData struct {
+	events      map[string]domain.Event // sem Spots/Tickets/Tiers, montados na leitura
+	eventOrder  []string
+	tiers       map[string]domain.PriceTier
+	tierOrder   []string
+	ticketKinds map[string]domain.TicketKindRule // chave: ticketKindKey
+	kindOrder   []string
+	spots       map[string]domain.Spot
+	spotOrder   []string
+	tickets     map[string]domain.Ticket // Ticket.Spot é nil; o spot fica em ticketSpots
+	ticketSpots map[string]string
+	ticketOrder []string
+	sagas       map[string]domain.CheckoutSaga
+	sagaOrder   []string
+	idempotency map[string]domain.IdempotencyKey
+	venues      map[string]domain.Venue
+	coupons     map[string]domain.Coupon // chave: Coupon.Code
+	redemptions []domain.CouponRedemption
+}
+
+func newMemoryData() *memoryData {
+	return &memoryData{
+		events:      make(map[string]domain.Event),
+		tiers:       make(map[string]domain.PriceTier),
+		ticketKinds: make(map[string]domain.TicketKindRule),
+		spots:       make(map[string]domain.Spot),
+		tickets:     make(map[string]domain.Ticket),
+		ticketSpots: make(map[string]string),
+		sagas:       make(map[string]domain.CheckoutSaga),
+		idempotency: make(map[string]domain.IdempotencyKey),
+		venues:      make(map[string]domain.Venue),
+		coupons:     make(map[string]domain.Coupon),
+	}
+}
+
+// clone copia o estado para que uma transação possa trabalhar sem afetar o original até o commit.
+func (d *memoryData) clone() *memoryData {
+	c := &memoryData{
+		events:      make(map[string]domain.Event, len(d.events)),
+		eventOrder:  append([]string(nil), d.eventOrder...),
+		tiers:       make(map[string]domain.PriceTier, len(d.tiers)),
+		tierOrder:   append([]string(nil), d.tierOrder...),
+		ticketKinds: make(map[string]domain.TicketKindRule, len(d.ticketKinds)),
+		kindOrder:   append([]string(nil), d.kindOrder...),
+		spots:       make(map[string]domain.Spot, len(d.spots)),
+		spotOrder:   append([]string(nil), d.spotOrder...),
+		tickets:     make(map[string]domain.Ticket, len(d.tickets)),
+		ticketSpots: make(map[string]string, len(d.ticketSpots)),
+		ticketOrder: append([]string(nil), d.ticketOrder...),
+		sagas:       make(map[string]domain.CheckoutSaga, len(d.sagas)),
+		sagaOrder:   append([]string(nil), d.sagaOrder...),
+		idempotency: make(map[string]domain.IdempotencyKey, len(d.idempotency)),
+		venues:      make(map[string]domain.Venue, len(d.venues)),
+		coupons:     make(map[string]domain.Coupon, len(d.coupons)),
+		redemptions: append([]domain.CouponRedemption(nil), d.redemptions...),
+	}
+	for k, v := range d.events {
+		c.events[k] = v
+	}
+	for k, v := range d.tiers {
+		c.tiers[k] = v
+	}
+	for k, v := range d.ticketKinds {
+		c.ticketKinds[k] = v
+	}
+	for k, v := range d.spots {
+		c.spots[k] = v
+	}
+	for k, v := range d.tickets {
+		c.tickets[k] = v
+	}
+	for k, v := range d.ticketSpots {
+		c.ticketSpots[k] = v
+	}
+	for k, v := range d.sagas {
+		c.sagas[k] = v
+	}
+	for k, v := range d.idempotency {
+		c.idempotency[k] = v
+	}
+	// O mapa de um local não muda depois de criado, então a cópia rasa é suficiente.
+	for k, v := range d.venues {
+		c.venues[k] = v
+	}
+	for k, v := range d.coupons {
+		c.coupons[k] = v
+	}
+	return c
+}
+
+// memoryEventRepository é uma implementação do repositório de eventos em memória,
+// segura para uso concorrente. Útil para testes e desenvolvimento local sem MySQL.
+type memoryEventRepository struct {
+	mu   *sync.RWMutex
+	data *memoryData
+	inTx bool // true quando o repositório é a visão de uma transação (o lock já está adquirido)
+}
+
+func NewMemoryEventRepository() domain.Repositories {
+	return &memoryEventRepository{mu: &sync.RWMutex{}, data: newMemoryData()}
+}
+
+func (r *memoryEventRepository) lock() func() {
+	if r.inTx {
+		return func() {}
+	}
+	r.mu.Lock()
+	return r.mu.Unlock
+}
+
+func (r *memoryEventRepository) rlock() func() {
+	if r.inTx {
+		return func() {}
+	}
+	r.mu.RLock()
+	return r.mu.RUnlock
+}
+
+// RunInTx executa fn sobre uma cópia do estado e só a publica se fn não retornar erro.
+// As transações são serializadas: enquanto fn executa, as demais operações aguardam.
+func (r *memoryEventRepository) RunInTx(ctx context.Context, fn func(tx domain.Repositories) error) error {
+	if r.inTx {
+		return fn(r)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// Assim como BeginTx, não inicia a transação se o contexto já foi cancelado.
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	tx := &memoryEventRepository{mu: r.mu, data: r.data.clone(), inTx: true}
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	*r.data = *tx.data
+	return nil
+}
+
+func (r *memoryEventRepository) ListEvents(ctx context.Context, q domain.EventQuery) ([]domain.Event, error) {
+	defer r.rlock()()
+
+	events := []domain.Event{}
+	for _, id := range r.data.eventOrder {
+		event := r.data.events[id]
+		event.Tiers = r.data.eventTiers(id)
+		if r.data.matchesEventQuery(&event, q) {
+			events = append(events, event)
+		}
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		return compareEventCursors(events[i].Cursor(), events[j].Cursor(), q) < 0
+	})
+
+	if q.After != nil {
+		start := sort.Search(len(events), func(i int) bool {
+			return compareEventCursors(events[i].Cursor(), *q.After, q) > 0
+		})
+		events = events[start:]
+	}
+	if len(events) > q.Limit {
+		events = events[:q.Limit]
+	}
+	return events, nil
+}
+
+func (d *memoryData) matchesEventQuery(event *domain.Event, q domain.EventQuery) bool {
+	switch {
+	case !q.DateFrom.IsZero() && event.Date.Before(q.DateFrom),
+		!q.DateTo.IsZero() && event.Date.After(q.DateTo),
+		q.Location != "" && !strings.Contains(strings.ToLower(event.Location), strings.ToLower(q.Location)),
+		q.Organization != "" && event.Organization != q.Organization,
+		q.Rating != "" && event.Rating != q.Rating,
+		q.PartnerID != 0 && event.PartnerID != q.PartnerID,
+		q.Currency != "" && event.Price.Currency != q.Currency,
+		len(q.Statuses) > 0 && !slices.Contains(q.Statuses, event.Status),
+		q.PriceMin > 0 && event.LowestPrice().Amount < q.PriceMin,
+		q.PriceMax > 0 && event.LowestPrice().Amount > q.PriceMax:
+		return false
+	}
+	if !q.HasAvailability {
+		return true
+	}
+	for _, spot := range d.spots {
+		if spot.EventID == event.ID && (spot.Status == domain.SpotStatusAvailable || (spot.Status == domain.SpotStatusReserved && !spot.IsHeld(q.Now))) {
+			return true
+		}
+	}
+	return false
+}
+
+// compareEventCursors compara duas posições na ordenação de q, desempatando pelo ID.
+func compareEventCursors(a, b domain.EventCursor, q domain.EventQuery) int {
+	var c int
+	switch q.SortBy {
+	case domain.EventSortByDate:
+		c = a.Date.Compare(b.Date)
+	case domain.EventSortByPrice:
+		c = cmp.Compare(a.Price, b.Price)
+	case domain.EventSortByName:
+		c = strings.Compare(a.Name, b.Name)
+	}
+	if c == 0 {
+		c = strings.Compare(a.ID, b.ID)
+	}
+	if q.SortDesc {
+		return -c
+	}
+	return c
+}
+
+func (r *memoryEventRepository) FindEventByID(ctx context.Context, eventID string) (*domain.Event, error) {
+	defer r.rlock()()
+
+	if _, ok := r.data.events[eventID]; !ok {
+		return nil, domain.ErrEventNotFound
+	}
+	return r.data.buildEvent(eventID), nil
+}
+
+// buildEvent monta o evento com seus spots e tickets.
+func (d *memoryData) buildEvent(eventID string) *domain.Event {
+	event := d.events[eventID]
+	event.Spots = []domain.Spot{}
+	event.Tickets = []domain.Ticket{}
+	event.Tiers = d.eventTiers(eventID)
+	event.TicketKinds = []domain.TicketKindRule{}
+
+	for _, key := range d.kindOrder {
+		if rule := d.ticketKinds[key]; rule.EventID == eventID {
+			event.TicketKinds = append(event.TicketKinds, rule)
+		}
+	}
+	for _, id := range d.spotOrder {
+		if spot := d.spots[id]; spot.EventID == eventID {
+			event.Spots = append(event.Spots, spot)
+		}
+	}
+	for _, id := range d.ticketOrder {
+		if ticket := d.tickets[id]; ticket.EventID == eventID {
+			spot := d.spots[d.ticketSpots[id]]
+			ticket.Spot = &spot
+			event.Tickets = append(event.Tickets, ticket)
+		}
+	}
+	return &event
+}
+
+// eventTiers retorna as categorias de preço do evento, na ordem de criação.
+func (d *memoryData) eventTiers(eventID string) []domain.PriceTier {
+	tiers := []domain.PriceTier{}
+	for _, id := range d.tierOrder {
+		if tier := d.tiers[id]; tier.EventID == eventID {
+			tiers = append(tiers, tier)
+		}
+	}
+	return tiers
+}
+
+func (r *memoryEventRepository) FindSpotsByEventID(ctx context.Context, eventID string) ([]*domain.Spot, error) {
+	defer r.rlock()()
+
+	var spots []*domain.Spot
+	for _, id := range r.data.spotOrder {
+		if spot := r.data.spots[id]; spot.EventID == eventID {
+			spots = append(spots, &spot)
+		}
+	}
+	return spots, nil
+}
+
+func (r *memoryEventRepository) FindSpotByName(ctx context.Context, eventID, spotName string) (*domain.Spot, error) {
+	defer r.rlock()()
+
+	for _, id := range r.data.spotOrder {
+		if spot := r.data.spots[id]; spot.EventID == eventID && spot.Name == spotName {
+			return &spot, nil
+		}
+	}
+	return nil, domain.ErrSpotNotFound
+}
+
+func (r *memoryEventRepository) CreateEvent(ctx context.Context, event *domain.Event) error {
+	defer r.lock()()
+
+	stored := *event
+	stored.Spots = nil
+	stored.Tickets = nil
+	stored.Tiers = nil
+	stored.TicketKinds = nil
+	if _, exists := r.data.events[event.ID]; !exists {
+		r.data.eventOrder = append(r.data.eventOrder, event.ID)
+	}
+	r.data.events[event.ID] = stored
+	return nil
+}
+
+// UpdateEvent segue a mesma regra de concorrência otimista da implementação MySQL.
+func (r *memoryEventRepository) UpdateEvent(ctx context.Context, event *domain.Event) error {
+	defer r.lock()()
+
+	stored, ok := r.data.events[event.ID]
+	if !ok || stored.Version != event.Version {
+		return domain.ErrEventModified
+	}
+
+	updated := *event
+	updated.Spots = nil
+	updated.Tickets = nil
+	updated.Tiers = nil
+	updated.TicketKinds = nil
+	updated.Version++
+	r.data.events[event.ID] = updated
+
+	event.Version = updated.Version
+	return nil
+}
+
+func (r *memoryEventRepository) DeleteEvent(ctx context.Context, eventID string) error {
+	defer r.lock()()
+
+	if _, ok := r.data.events[eventID]; !ok {
+		return domain.ErrEventNotFound
+	}
+	for _, ticket := range r.data.tickets {
+		if ticket.EventID == eventID {
+			return domain.ErrEventHasTickets
+		}
+	}
+
+	r.data.spotOrder = slices.DeleteFunc(r.data.spotOrder, func(id string) bool {
+		if r.data.spots[id].EventID != eventID {
+			return false
+		}
+		delete(r.data.spots, id)
+		return true
+	})
+	r.data.tierOrder = slices.DeleteFunc(r.data.tierOrder, func(id string) bool {
+		if r.data.tiers[id].EventID != eventID {
+			return false
+		}
+		delete(r.data.tiers, id)
+		return true
+	})
+	r.data.kindOrder = slices.DeleteFunc(r.data.kindOrder, func(key string) bool {
+		if r.data.ticketKinds[key].EventID != eventID {
+			return false
+		}
+		delete(r.data.ticketKinds, key)
+		return true
+	})
+	// Sem ingressos, os cupons do evento não têm resgates.
+	for code, coupon := range r.data.coupons {
+		if coupon.EventID == eventID {
+			delete(r.data.coupons, code)
+		}
+	}
+	r.data.eventOrder = slices.DeleteFunc(r.data.eventOrder, func(id string) bool { return id == eventID })
+	delete(r.data.events, eventID)
+	return nil
+}
+
+// DeleteSpot segue a mesma regra de concorrência otimista da implementação MySQL.
+func (r *memoryEventRepository) DeleteSpot(ctx context.Context, spot *domain.Spot) error {
+	defer r.lock()()
+
+	stored, ok := r.data.spots[spot.ID]
+	if !ok || stored.Version != spot.Version {
+		return domain.ErrSpotModified
+	}
+	r.data.spotOrder = slices.DeleteFunc(r.data.spotOrder, func(id string) bool { return id == spot.ID })
+	delete(r.data.spots, spot.ID)
+	return nil
+}
+
+func (r *memoryEventRepository) UpdateEventStatus(ctx context.Context, eventID string, from, to domain.EventStatus) (bool, error) {
+	defer r.lock()()
+
+	stored, ok := r.data.events[eventID]
+	if !ok || stored.Status != from {
+		return false, nil
+	}
+	stored.Status = to
+	stored.Version++
+	r.data.events[eventID] = stored
+	return true, nil
+}
+
+// LockEventStatus não precisa bloquear nada além do lock do repositório: as transações em memória são serializadas.
+func (r *memoryEventRepository) LockEventStatus(ctx context.Context, eventID string) (domain.EventStatus, error) {
+	defer r.rlock()()
+
+	stored, ok := r.data.events[eventID]
+	if !ok {
+		return "", domain.ErrEventNotFound
+	}
+	return stored.Status, nil
+}
+
+func (r *memoryEventRepository) CountUnsoldSpots(ctx context.Context, eventID string) (int, error) {
+	defer r.rlock()()
+
+	count := 0
+	for _, spot := range r.data.spots {
+		if spot.EventID == eventID && spot.Status != domain.SpotStatusSold {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *memoryEventRepository) MarkEventTicketsForRefund(ctx context.Context, eventID string) (int64, error) {
+	defer r.lock()()
+
+	var marked int64
+	for id, ticket := range r.data.tickets {
+		if ticket.EventID == eventID && ticket.Status == domain.TicketStatusIssued {
+			ticket.Status = domain.TicketStatusRefundPending
+			r.data.tickets[id] = ticket
+			marked++
+		}
+	}
+	return marked, nil
+}
+
+func (r *memoryEventRepository) CreateSpot(ctx context.Context, spot *domain.Spot) error {
+	defer r.lock()()
+
+	if _, ok := r.data.events[spot.EventID]; !ok {
+		return domain.ErrEventNotFound
+	}
+	if _, exists := r.data.spots[spot.ID]; !exists {
+		r.data.spotOrder = append(r.data.spotOrder, spot.ID)
+	}
+	r.data.spots[spot.ID] = *spot
+	return nil
+}
+
+func (r *memoryEventRepository) CreateTicket(ctx context.Context, ticket *domain.Ticket) error {
+	defer r.lock()()
+
+	if _, ok := r.data.events[ticket.EventID]; !ok {
+		return domain.ErrEventNotFound
+	}
+	if _, ok := r.data.spots[ticket.Spot.ID]; !ok {
+		return domain.ErrSpotNotFound
+	}
+
+	stored := *ticket
+	stored.Spot = nil
+	if _, exists := r.data.tickets[ticket.ID]; !exists {
+		r.data.ticketOrder = append(r.data.ticketOrder, ticket.ID)
+	}
+	r.data.tickets[ticket.ID] = stored
+	r.data.ticketSpots[ticket.ID] = ticket.Spot.ID
+	return nil
+}
+
+// ReserveSpot segue a mesma regra de concorrência otimista da implementação MySQL.
+func (r *memoryEventRepository) ReserveSpot(ctx context.Context, spot *domain.Spot) error {
+	defer r.lock()()
+
+	stored, ok := r.data.spots[spot.ID]
+	if !ok {
+		return domain.ErrSpotNotFound
+	}
+	if stored.Version != spot.Version {
+		return domain.ErrSpotAlreadyReserved
+	}
+
+	stored.Status = domain.SpotStatusSold
+	stored.TicketID = spot.TicketID
+	stored.HoldOwner = ""
+	stored.HoldExpiresAt = time.Time{}
+	stored.Version++
+	r.data.spots[spot.ID] = stored
+
+	spot.Version = stored.Version
+	return nil
+}
+
+// HoldSpot segue a mesma regra da implementação MySQL: só segura spots disponíveis,
+// da mesma sessão ou com a retenção expirada.
+func (r *memoryEventRepository) HoldSpot(ctx context.Context, spot *domain.Spot) error {
+	defer r.lock()()
+
+	stored, ok := r.data.spots[spot.ID]
+	if !ok {
+		return domain.ErrSpotNotFound
+	}
+
+	holdable := stored.Status == domain.SpotStatusAvailable ||
+		(stored.Status == domain.SpotStatusReserved && (stored.HoldOwner == spot.HoldOwner || !time.Now().Before(stored.HoldExpiresAt)))
+	if !holdable {
+		return domain.ErrSpotHeld
+	}
+
+	stored.Status = domain.SpotStatusReserved
+	stored.HoldOwner = spot.HoldOwner
+	stored.HoldExpiresAt = spot.HoldExpiresAt
+	stored.Version++
+	r.data.spots[spot.ID] = stored
+
+	spot.Version = stored.Version
+	return nil
+}
+
+func (r *memoryEventRepository) ReleaseExpiredHolds(ctx context.Context, now time.Time) (int64, error) {
+	defer r.lock()()
+
+	var released int64
+	for id, spot := range r.data.spots {
+		if spot.Status != domain.SpotStatusReserved || now.Before(spot.HoldExpiresAt) {
+			continue
+		}
+		spot.ReleaseHold()
+		spot.Version++
+		r.data.spots[id] = spot
+		released++
+	}
+	return released, nil
+}
+
+func (r *memoryEventRepository) CreateCheckoutSaga(ctx context.Context, saga *domain.CheckoutSaga) error {
+	defer r.lock()()
+
+	if _, exists := r.data.sagas[saga.ID]; !exists {
+		r.data.sagaOrder = append(r.data.sagaOrder, saga.ID)
+	}
+	r.data.sagas[saga.ID] = copyCheckoutSaga(saga)
+	return nil
+}
+
+func (r *memoryEventRepository) UpdateCheckoutSaga(ctx context.Context, saga *domain.CheckoutSaga) error {
+	defer r.lock()()
+
+	stored, ok := r.data.sagas[saga.ID]
+	if !ok {
+		return nil
+	}
+	updated := copyCheckoutSaga(saga)
+	// Assim como no MySQL, apenas os campos de andamento são atualizados.
+	updated.EventID = stored.EventID
+	updated.PartnerID = stored.PartnerID
+	updated.Spots = stored.Spots
+	updated.TicketKind = stored.TicketKind
+	updated.Email = stored.Email
+	updated.CreatedAt = stored.CreatedAt
+	r.data.sagas[saga.ID] = updated
+	return nil
+}
+
+func (r *memoryEventRepository) FindPendingCheckoutSagas(ctx context.Context, staleBefore time.Time) ([]*domain.CheckoutSaga, error) {
+	defer r.rlock()()
+
+	var sagas []*domain.CheckoutSaga
+	for _, id := range r.data.sagaOrder {
+		saga := r.data.sagas[id]
+		stale := (saga.Status == domain.CheckoutSagaStarted || saga.Status == domain.CheckoutSagaReserved) && saga.UpdatedAt.Before(staleBefore)
+		if saga.Status == domain.CheckoutSagaCompensating || stale {
+			found := copyCheckoutSaga(&saga)
+			sagas = append(sagas, &found)
+		}
+	}
+	return sagas, nil
+}
+
+// copyCheckoutSaga evita que o chamador altere os slices armazenados.
+func copyCheckoutSaga(saga *domain.CheckoutSaga) domain.CheckoutSaga {
+	c := *saga
+	c.Spots = append([]string(nil), saga.Spots...)
+	c.ReservationIDs = append([]string(nil), saga.ReservationIDs...)
+	return c
+}
+
+// CreateIdempotencyKey segue a mesma regra da implementação MySQL: chaves expiradas são substituídas.
+func (r *memoryEventRepository) CreateIdempotencyKey(ctx context.Context, key *domain.IdempotencyKey) error {
+	defer r.lock()()
+
+	if stored, exists := r.data.idempotency[key.Key]; exists && !stored.IsExpired(time.Now()) {
+		return domain.ErrIdempotencyKeyExists
+	}
+	r.data.idempotency[key.Key] = copyIdempotencyKey(key)
+	return nil
+}
+
+func (r *memoryEventRepository) FindIdempotencyKey(ctx context.Context, key string) (*domain.IdempotencyKey, error) {
+	defer r.rlock()()
+
+	stored, ok := r.data.idempotency[key]
+	if !ok {
+		return nil, domain.ErrIdempotencyKeyNotFound
+	}
+	found := copyIdempotencyKey(&stored)
+	return &found, nil
+}
+
+func (r *memoryEventRepository) CompleteIdempotencyKey(ctx context.Context, key *domain.IdempotencyKey) error {
+	defer r.lock()()
+
+	stored, ok := r.data.idempotency[key.Key]
+	if !ok {
+		return nil
+	}
+	stored.Status = key.Status
+	stored.Response = append([]byte(nil), key.Response...)
+	stored.ExpiresAt = key.ExpiresAt
+	r.data.idempotency[key.Key] = stored
+	return nil
+}
+
+func (r *memoryEventRepository) DeleteIdempotencyKey(ctx context.Context, key string) error {
+	defer r.lock()()
+
+	if stored, ok := r.data.idempotency[key]; ok && stored.Status == domain.IdempotencyKeyInProgress {
+		delete(r.data.idempotency, key)
+	}
+	return nil
+}
+
+func (r *memoryEventRepository) DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error) {
+	defer r.lock()()
+
+	var deleted int64
+	for k, stored := range r.data.idempotency {
+		if stored.IsExpired(now) {
+			delete(r.data.idempotency, k)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// copyIdempotencyKey evita que o chamador altere a resposta armazenada.
+func copyIdempotencyKey(key *domain.IdempotencyKey) domain.IdempotencyKey {
+	c := *key
+	c.Response = append([]byte(nil), key.Response...)
+	return c
+}
+
+func (r *memoryEventRepository) CreateVenue(ctx context.Context, venue *domain.Venue) error {
+	defer r.lock()()
+
+	r.data.venues[venue.ID] = copyVenue(venue)
+	return nil
+}
+
+func (r *memoryEventRepository) FindVenueByID(ctx context.Context, venueID string) (*domain.Venue, error) {
+	defer r.rlock()()
+
+	stored, ok := r.data.venues[venueID]
+	if !ok {
+		return nil, domain.ErrVenueNotFound
+	}
+	venue := copyVenue(&stored)
+	return &venue, nil
+}
+
+func (r *memoryEventRepository) ListVenues(ctx context.Context) ([]domain.Venue, error) {
+	defer r.rlock()()
+
+	venues := make([]domain.Venue, 0, len(r.data.venues))
+	for _, venue := range r.data.venues {
+		venue.Sections = nil
+		venues = append(venues, venue)
+	}
+	sort.Slice(venues, func(i, j int) bool {
+		if venues[i].Name != venues[j].Name {
+			return venues[i].Name < venues[j].Name
+		}
+		return venues[i].ID < venues[j].ID
+	})
+	return venues, nil
+}
+
+// copyVenue copia o mapa do local, para que o chamador não altere o estado armazenado.
+func copyVenue(venue *domain.Venue) domain.Venue {
+	c := *venue
+	c.Sections = make([]domain.Section, len(venue.Sections))
+	for i, section := range venue.Sections {
+		section.Rows = make([]domain.Row, len(venue.Sections[i].Rows))
+		for j, row := range venue.Sections[i].Rows {
+			row.Seats = append([]domain.Seat(nil), row.Seats...)
+			section.Rows[j] = row
+		}
+		c.Sections[i] = section
+	}
+	return c
+}
+
+func (r *memoryEventRepository) CreatePriceTier(ctx context.Context, tier *domain.PriceTier) error {
+	defer r.lock()()
+
+	if _, ok := r.data.events[tier.EventID]; !ok {
+		return domain.ErrEventNotFound
+	}
+	if _, exists := r.data.tiers[tier.ID]; !exists {
+		r.data.tierOrder = append(r.data.tierOrder, tier.ID)
+	}
+	r.data.tiers[tier.ID] = *tier
+	return nil
+}
+
+// CreateTicketKindRule segue a chave primária da implementação MySQL: (event_id, kind).
+func (r *memoryEventRepository) CreateTicketKindRule(ctx context.Context, rule *domain.TicketKindRule) error {
+	defer r.lock()()
+
+	if _, ok := r.data.events[rule.EventID]; !ok {
+		return domain.ErrEventNotFound
+	}
+	key := ticketKindKey(rule.EventID, rule.Kind)
+	if _, exists := r.data.ticketKinds[key]; exists {
+		return fmt.Errorf("%w: %s is duplicated", domain.ErrInvalidTicketKindRule, rule.Kind)
+	}
+	r.data.kindOrder = append(r.data.kindOrder, key)
+	r.data.ticketKinds[key] = *rule
+	return nil
+}
+
+func ticketKindKey(eventID string, kind domain.TicketKind) string {
+	return eventID + "/" + string(kind)
+}
+
+func (r *memoryEventRepository) CreateCoupon(ctx context.Context, coupon *domain.Coupon) error {
+	defer r.lock()()
+
+	if _, exists := r.data.coupons[coupon.Code]; exists {
+		return fmt.Errorf("%w: %s", domain.ErrCouponCodeTaken, coupon.Code)
+	}
+	r.data.coupons[coupon.Code] = *coupon
+	return nil
+}
+
+// FindCouponByCode não precisa bloquear nada além do lock do repositório: as transações em memória são serializadas.
+func (r *memoryEventRepository) FindCouponByCode(ctx context.Context, code string) (*domain.Coupon, error) {
+	defer r.rlock()()
+
+	coupon, ok := r.data.coupons[code]
+	if !ok {
+		return nil, domain.ErrCouponNotFound
+	}
+	return &coupon, nil
+}
+
+// CountCouponRedemptions conta as compras distintas (CheckoutID), como a implementação MySQL.
+func (r *memoryEventRepository) CountCouponRedemptions(ctx context.Context, couponID, email string) (domain.CouponUsage, error) {
+	defer r.rlock()()
+
+	checkouts := make(map[string]bool)
+	byEmail := make(map[string]bool)
+	for _, redemption := range r.data.redemptions {
+		if redemption.CouponID != couponID {
+			continue
+		}
+		checkouts[redemption.CheckoutID] = true
+		if redemption.Email == email {
+			byEmail[redemption.CheckoutID] = true
+		}
+	}
+	return domain.CouponUsage{Total: len(checkouts), ByEmail: len(byEmail)}, nil
+}
+
+func (r *memoryEventRepository) CreateCouponRedemption(ctx context.Context, redemption *domain.CouponRedemption) error {
+	defer r.lock()()
+
+	r.data.redemptions = append(r.data.redemptions, *redemption)
+	return nil
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"time"
@@ -12,65 +13,165 @@ import (
 // sqlc - O SQLC é uma ferramenta que facilita a geração de código Go a partir de consultas SQL.
 // GORM - O GORM é um Object-Relational Mapping (ORM) para Go que facilita a interação com bancos de dados relacionais.
 
+// dbtx abstrai *sql.DB e *sql.Tx, permitindo que as mesmas queries rodem dentro ou fora de uma transação.
+type dbtx interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
 // mysqlEventRepository é uma implementação do repositório de eventos que usa o banco de dados MySQL.
 type mysqlEventRepository struct {
-	db *sql.DB // A conexão com o banco de dados.
+	db   dbtx    // A conexão (ou transação) usada pelas queries.
+	conn *sql.DB // A conexão com o banco de dados; nil quando o repositório já está dentro de uma transação.
 }
 
-func NewMysqlEventRepository(db *sql.DB) (domain.EventRepository, error) {
-	return &mysqlEventRepository{db: db}, nil
+func NewMysqlEventRepository(db *sql.DB) (domain.Repositories, error) {
+	return &mysqlEventRepository{db: db, conn: db}, nil
+}
+
+// RunInTx executa fn dentro de uma transação do banco de dados.
+// O repositório recebido por fn compartilha a transação: se fn retornar erro, tudo é desfeito (rollback);
+// caso contrário, a transação é confirmada (commit). Chamadas aninhadas reutilizam a transação corrente.
+func (r *mysqlEventRepository) RunInTx(ctx context.Context, fn func(tx domain.Repositories) error) error {
+	if r.conn == nil {
+		return fn(r)
+	}
+
+	tx, err := r.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	// Garante o rollback caso fn entre em pânico.
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+			panic(p)
+		}
+	}()
+
+	if err := fn(&mysqlEventRepository{db: tx}); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return errors.Join(err, rbErr)
+		}
+		return err
+	}
+
+	return tx.Commit()
 }
 
 // CreateSpot insere um novo spot (assento/lugar) no banco de dados.
 // Recebe um ponteiro para um objeto Spot do domínio.
-func (r *mysqlEventRepository) CreateSpot(spot *domain.Spot) error {
+func (r *mysqlEventRepository) CreateSpot(ctx context.Context, spot *domain.Spot) error {
 
 	query := `
-		INSERT INTO spots (id, event_id, name, status, ticket_id)
-		VALUES (?, ?, ?, ?, ?)
+		INSERT INTO spots (id, event_id, section_id, tier_id, name, status, ticket_id, version)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
-	_, err := r.db.Exec(query, spot.ID, spot.EventID, spot.Name, spot.Status, spot.TicketID)
+	_, err := r.db.ExecContext(ctx, query, spot.ID, spot.EventID, nullString(spot.SectionID), nullString(spot.TierID), spot.Name, spot.Status, spot.TicketID, spot.Version)
 	return err
 }
 
-// ReserveSpot atualiza o status de um spot para reservado e associa um ticket a ele.
-// Recebe os IDs do spot e do ticket.
-func (r *mysqlEventRepository) ReserveSpot(spotID, ticketID string) error {
+// ReserveSpot atualiza o status de um spot para vendido e associa o ticket a ele.
+// Usa controle de concorrência otimista: a atualização só acontece se a versão do spot
+// ainda for a lida pelo chamador; caso contrário outra compra venceu e retorna ErrSpotAlreadyReserved.
+func (r *mysqlEventRepository) ReserveSpot(ctx context.Context, spot *domain.Spot) error {
 	query := `
 		UPDATE spots
-		SET status = ?, ticket_id = ?
-		WHERE id= ? 
+		SET status = ?, ticket_id = ?, hold_owner = NULL, hold_expires_at = NULL, version = version + 1
+		WHERE id = ? AND version = ?
 	`
 
-	_, err := r.db.Exec(query, domain.SpotStatusSold, ticketID, spotID)
-	return err
+	result, err := r.db.ExecContext(ctx, query, domain.SpotStatusSold, spot.TicketID, spot.ID, spot.Version)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	// Nenhuma linha alterada: o spot foi modificado (vendido ou segurado) depois da leitura.
+	if affected == 0 {
+		return domain.ErrSpotAlreadyReserved
+	}
+
+	spot.Version++
+	return nil
+}
+
+// HoldSpot marca um spot como segurado pela sessão informada até o horário de expiração.
+// A atualização só acontece se o spot estiver disponível, já for da mesma sessão ou tiver a retenção expirada.
+func (r *mysqlEventRepository) HoldSpot(ctx context.Context, spot *domain.Spot) error {
+	query := `
+		UPDATE spots
+		SET status = ?, hold_owner = ?, hold_expires_at = ?, version = version + 1
+		WHERE id = ? AND (status = ? OR (status = ? AND (hold_owner = ? OR hold_expires_at <= ?)))
+	`
+
+	result, err := r.db.ExecContext(ctx, query,
+		domain.SpotStatusReserved, spot.HoldOwner, formatDateTime(spot.HoldExpiresAt),
+		spot.ID, domain.SpotStatusAvailable, domain.SpotStatusReserved, spot.HoldOwner, formatDateTime(time.Now()),
+	)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	// Nenhuma linha alterada: outra sessão segurou (ou comprou) o spot antes.
+	if affected == 0 {
+		return domain.ErrSpotHeld
+	}
+
+	spot.Version++
+	return nil
+}
+
+// ReleaseExpiredHolds devolve para disponível os spots cuja retenção expirou.
+// Retorna a quantidade de spots liberados.
+func (r *mysqlEventRepository) ReleaseExpiredHolds(ctx context.Context, now time.Time) (int64, error) {
+	query := `
+		UPDATE spots
+		SET status = ?, hold_owner = NULL, hold_expires_at = NULL, version = version + 1
+		WHERE status = ? AND hold_expires_at <= ?
+	`
+
+	result, err := r.db.ExecContext(ctx, query, domain.SpotStatusAvailable, domain.SpotStatusReserved, formatDateTime(now))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
 }
 
 // CreateTicket insere um novo ticket no banco de dados.
 // Recebe um ponteiro para um objeto Ticket do domínio.
-func (r *mysqlEventRepository) CreateTicket(ticket *domain.Ticket) error {
+func (r *mysqlEventRepository) CreateTicket(ctx context.Context, ticket *domain.Ticket) error {
 	query := `
-		INSERT INTO tickets (id, event_id, spot_id, ticket_kind, price)
-		VALUES(?, ?, ?, ?, ?)
+		INSERT INTO tickets (id, event_id, spot_id, ticket_kind, price_amount, currency, status, eligibility_document, coupon_discount_amount)
+		VALUES(?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
-	_, err := r.db.Exec(query, ticket.ID, ticket.EventID, ticket.Spot.ID, ticket.TicketKind, ticket.Price)
+	_, err := r.db.ExecContext(ctx, query, ticket.ID, ticket.EventID, ticket.Spot.ID, ticket.TicketKind, ticket.Price.Amount, ticket.Price.Currency, ticket.Status, nullString(ticket.EligibilityDocument), ticket.CouponDiscount.Amount)
 	return err
 }
 
 // FindEventByID returns an event by its ID, including associated spots and tickets.
-func (r *mysqlEventRepository) FindEventByID(eventID string) (*domain.Event, error) {
+func (r *mysqlEventRepository) FindEventByID(ctx context.Context, eventID string) (*domain.Event, error) {
 	query := `
 		SELECT 
-			e.id, e.name, e.location, e.organization, e.rating, e.date, e.image_url, e.capacity, e.price, e.partner_id,
-			s.id, s.event_id, s.name, s.status, s.ticket_id,
-			t.id, t.event_id, t.spot_id, t.ticket_kind, t.price
+			e.id, e.name, e.location, e.organization, e.rating, e.date, e.image_url, e.capacity, e.price_amount, e.currency, e.partner_id, e.venue_id, e.status, e.version,
+			s.id, s.event_id, s.section_id, s.tier_id, s.name, s.status, s.ticket_id,
+			t.id, t.event_id, t.spot_id, t.ticket_kind, t.price_amount, t.currency, t.status, t.eligibility_document, t.coupon_discount_amount
 		FROM events e
 		LEFT JOIN spots s ON e.id = s.event_id
 		LEFT JOIN tickets t ON s.id = t.spot_id
 		WHERE e.id = ?
 	`
-	rows, err := r.db.Query(query, eventID)
+	rows, err := r.db.QueryContext(ctx, query, eventID)
 	if err != nil {
 		return nil, err
 	}
@@ -79,15 +180,15 @@ func (r *mysqlEventRepository) FindEventByID(eventID string) (*domain.Event, err
 	var event *domain.Event
 	for rows.Next() {
 		var eventIDStr, eventName, eventLocation, eventOrganization, eventRating, eventImageURL, spotID, spotEventID, spotName, spotStatus, spotTicketID, ticketID, ticketEventID, ticketSpotID, ticketKind sql.NullString
-		var eventDate sql.NullString
-		var eventCapacity int
-		var eventPrice, ticketPrice sql.NullFloat64
+		var eventDate, eventCurrency, eventVenueID, eventStatus, spotSectionID, spotTierID, ticketCurrency, ticketStatus, ticketDocument sql.NullString
+		var eventCapacity, eventVersion int
+		var eventPrice, ticketPrice, ticketCouponDiscount sql.NullInt64
 		var partnerID sql.NullInt32
 
 		err := rows.Scan(
-			&eventIDStr, &eventName, &eventLocation, &eventOrganization, &eventRating, &eventDate, &eventImageURL, &eventCapacity, &eventPrice, &partnerID,
-			&spotID, &spotEventID, &spotName, &spotStatus, &spotTicketID,
-			&ticketID, &ticketEventID, &ticketSpotID, &ticketKind, &ticketPrice,
+			&eventIDStr, &eventName, &eventLocation, &eventOrganization, &eventRating, &eventDate, &eventImageURL, &eventCapacity, &eventPrice, &eventCurrency, &partnerID, &eventVenueID, &eventStatus, &eventVersion,
+			&spotID, &spotEventID, &spotSectionID, &spotTierID, &spotName, &spotStatus, &spotTicketID,
+			&ticketID, &ticketEventID, &ticketSpotID, &ticketKind, &ticketPrice, &ticketCurrency, &ticketStatus, &ticketDocument, &ticketCouponDiscount,
 		)
 		if err != nil {
 			if errors.Is(err, sql.ErrNoRows) {
@@ -101,7 +202,7 @@ func (r *mysqlEventRepository) FindEventByID(eventID string) (*domain.Event, err
 		}
 
 		if event == nil {
-			eventDateParsed, err := time.Parse("2006-01-02 15:04:05", eventDate.String)
+			eventDateParsed, err := parseDateTime(eventDate.String)
 			if err != nil {
 				return nil, err
 			}
@@ -114,8 +215,11 @@ func (r *mysqlEventRepository) FindEventByID(eventID string) (*domain.Event, err
 				Date:         eventDateParsed,
 				ImageURL:     eventImageURL.String,
 				Capacity:     eventCapacity,
-				Price:        eventPrice.Float64,
+				Price:        domain.NewMoney(eventPrice.Int64, domain.Currency(eventCurrency.String)),
 				PartnerID:    int(partnerID.Int32),
+				VenueID:      eventVenueID.String,
+				Status:       domain.EventStatus(eventStatus.String),
+				Version:      eventVersion,
 				Spots:        []domain.Spot{},
 				Tickets:      []domain.Ticket{},
 			}
@@ -123,11 +227,13 @@ func (r *mysqlEventRepository) FindEventByID(eventID string) (*domain.Event, err
 
 		if spotID.Valid {
 			spot := domain.Spot{
-				ID:       spotID.String,
-				EventID:  spotEventID.String,
-				Name:     spotName.String,
-				Status:   domain.SpotStatus(spotStatus.String),
-				TicketID: spotTicketID.String,
+				ID:        spotID.String,
+				EventID:   spotEventID.String,
+				SectionID: spotSectionID.String,
+				TierID:    spotTierID.String,
+				Name:      spotName.String,
+				Status:    domain.SpotStatus(spotStatus.String),
+				TicketID:  spotTicketID.String,
 			}
 			event.Spots = append(event.Spots, spot)
 
@@ -137,7 +243,11 @@ func (r *mysqlEventRepository) FindEventByID(eventID string) (*domain.Event, err
 					EventID:    ticketEventID.String,
 					Spot:       &spot,
 					TicketKind: domain.TicketKind(ticketKind.String),
-					Price:      ticketPrice.Float64,
+					Price:      domain.NewMoney(ticketPrice.Int64, domain.Currency(ticketCurrency.String)),
+					Status:     domain.TicketStatus(ticketStatus.String),
+
+					EligibilityDocument: ticketDocument.String,
+					CouponDiscount:      domain.NewMoney(ticketCouponDiscount.Int64, domain.Currency(ticketCurrency.String)),
 				}
 				event.Tickets = append(event.Tickets, ticket)
 			}
@@ -152,19 +262,26 @@ func (r *mysqlEventRepository) FindEventByID(eventID string) (*domain.Event, err
 		return nil, domain.ErrEventNotFound
 	}
 
+	if event.Tiers, err = r.findPriceTiers(ctx, event.ID); err != nil {
+		return nil, err
+	}
+	if event.TicketKinds, err = r.findTicketKindRules(ctx, event.ID); err != nil {
+		return nil, err
+	}
+
 	return event, nil
 }
 
 // FindSpotsByEventID busca os spots de um evento no banco de dados pelo ID do evento.
 // Retorna um slice de ponteiros para objetos Spot e um possível erro.
-func (r *mysqlEventRepository) FindSpotsByEventID(eventID string) ([]*domain.Spot, error) {
+func (r *mysqlEventRepository) FindSpotsByEventID(ctx context.Context, eventID string) ([]*domain.Spot, error) {
 	query := `
-		SELECT id, event_id, name, status, ticket_id
+		SELECT id, event_id, section_id, tier_id, name, status, ticket_id, hold_owner, hold_expires_at, version
 		FROM spots
 		WHERE event_id = ?
 	`
 
-	rows, err := r.db.Query(query, eventID)
+	rows, err := r.db.QueryContext(ctx, query, eventID)
 	if err != nil {
 		return nil, err
 	}
@@ -174,15 +291,26 @@ func (r *mysqlEventRepository) FindSpotsByEventID(eventID string) ([]*domain.Spo
 	// Itera sobre os resultados da query e popula o slice de spots.
 	for rows.Next() {
 		var spot domain.Spot
+		var sectionID, tierID, holdOwner, holdExpiresAt sql.NullString
 		if err := rows.Scan(
 			&spot.ID,
 			&spot.EventID,
+			&sectionID,
+			&tierID,
 			&spot.Name,
 			&spot.Status,
 			&spot.TicketID,
+			&holdOwner,
+			&holdExpiresAt,
+			&spot.Version,
 		); err != nil {
 			return nil, err
 		}
+		spot.SectionID = sectionID.String
+		spot.TierID = tierID.String
+		if err := parseSpotHold(&spot, holdOwner, holdExpiresAt); err != nil {
+			return nil, err
+		}
 		spots = append(spots, &spot)
 	}
 
@@ -196,28 +324,29 @@ func (r *mysqlEventRepository) FindSpotsByEventID(eventID string) ([]*domain.Spo
 
 // FindSpotByName busca um spot específico pelo nome e ID do evento no banco de dados.
 // Retorna um ponteiro para o objeto Spot e um possível erro.
-func (r *mysqlEventRepository) FindSpotByName(eventID, name string) (*domain.Spot, error) {
+func (r *mysqlEventRepository) FindSpotByName(ctx context.Context, eventID, name string) (*domain.Spot, error) {
 	query := `
 	SELECT
-		s.id, s.event_id, s.name, s.status, s.ticket_id,
-		t.id, t.event_id, t.spot_id, t.ticket_type, t.price
+		s.id, s.event_id, s.section_id, s.tier_id, s.name, s.status, s.ticket_id, s.hold_owner, s.hold_expires_at, s.version,
+		t.id, t.event_id, t.spot_id, t.ticket_kind, t.price_amount, t.currency
 	FROM spots s
 	LEFT JOIN tickets t ON s.id = t.spot_id
 	WHERE s.event_id = ? AND s.name = ?
 	`
 	// Executa a query de busca com o ID do evento e o nome do spot.
-	row := r.db.QueryRow(query, eventID, name)
+	row := r.db.QueryRowContext(ctx, query, eventID, name)
 
 	var spot domain.Spot
 	var ticket domain.Ticket
 	// Variáveis para armazenar os valores retornados da query.
-	var ticketID, ticketEventID, ticketSpotID, ticketKind sql.NullString
-	var ticketPrice sql.NullFloat64
+	var sectionID, tierID, holdOwner, holdExpiresAt sql.NullString
+	var ticketID, ticketEventID, ticketSpotID, ticketKind, ticketCurrency sql.NullString
+	var ticketPrice sql.NullInt64
 
 	// Faz a leitura do resultado da query para os objetos Spot e Ticket.
 	err := row.Scan(
-		&spot.ID, &spot.EventID, &spot.Name, &spot.Status, &spot.TicketID,
-		&ticketID, &ticketEventID, &ticketSpotID, &ticketKind, &ticketPrice,
+		&spot.ID, &spot.EventID, &sectionID, &tierID, &spot.Name, &spot.Status, &spot.TicketID, &holdOwner, &holdExpiresAt, &spot.Version,
+		&ticketID, &ticketEventID, &ticketSpotID, &ticketKind, &ticketPrice, &ticketCurrency,
 	)
 
 	if err != nil {
@@ -228,125 +357,62 @@ func (r *mysqlEventRepository) FindSpotByName(eventID, name string) (*domain.Spo
 
 	}
 
+	spot.SectionID = sectionID.String
+	spot.TierID = tierID.String
+	if err := parseSpotHold(&spot, holdOwner, holdExpiresAt); err != nil {
+		return nil, err
+	}
+
 	// Verifica se o ticket associado ao spot é válido.
 	if ticketID.Valid {
 		ticket.ID = ticketID.String
 		ticket.EventID = ticketEventID.String
 		ticket.Spot = &spot
 		ticket.TicketKind = domain.TicketKind(ticketKind.String)
-		ticket.Price = ticketPrice.Float64
+		ticket.Price = domain.NewMoney(ticketPrice.Int64, domain.Currency(ticketCurrency.String))
 		spot.TicketID = ticket.ID
 	}
 
 	return &spot, nil
 }
 
-// ListEvents retorna uma lista de todos os eventos.
-func (r *mysqlEventRepository) ListEvents() ([]domain.Event, error) {
+func (r *mysqlEventRepository) CreateEvent(ctx context.Context, event *domain.Event) error {
 	query := `
-		SELECT 
-			e.id, e.name, e.location, e.organization, e.rating, e.date, e.image_url, e.capacity, e.price, e.partner_id,
-			s.id, s.event_id, s.name, s.status, s.ticket_id,
-			t.id, t.event_id, t.spot_id, t.ticket_kind, t.price
-		FROM events e
-		LEFT JOIN spots s ON e.id = s.event_id
-		LEFT JOIN tickets t ON s.id = t.spot_id
+		INSERT INTO events (id, name, location, organization, rating, date, image_url, capacity, price_amount, currency, partner_id, venue_id, status, version)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
-	rows, err := r.db.Query(query)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-
-	eventMap := make(map[string]*domain.Event)
-	spotMap := make(map[string]*domain.Spot)
-	for rows.Next() {
-		var eventID, eventName, eventLocation, eventOrganization, eventRating, eventImageURL, spotID, spotEventID, spotName, spotStatus, spotTicketID, ticketID, ticketEventID, ticketSpotID, ticketKind sql.NullString
-		var eventDate sql.NullString
-		var eventCapacity int
-		var eventPrice, ticketPrice sql.NullFloat64
-		var partnerID sql.NullInt32
-
-		err := rows.Scan(
-			&eventID, &eventName, &eventLocation, &eventOrganization, &eventRating, &eventDate, &eventImageURL, &eventCapacity, &eventPrice, &partnerID,
-			&spotID, &spotEventID, &spotName, &spotStatus, &spotTicketID,
-			&ticketID, &ticketEventID, &ticketSpotID, &ticketKind, &ticketPrice,
-		)
-		if err != nil {
-			return nil, err
-		}
+	_, err := r.db.ExecContext(ctx, query, event.ID, event.Name, event.Location, event.Organization, event.Rating, formatDateTime(event.Date), event.ImageURL, event.Capacity, event.Price.Amount, event.Price.Currency, event.PartnerID, nullString(event.VenueID), event.Status, event.Version)
+	return err
+}
 
-		if !eventID.Valid || !eventName.Valid || !eventLocation.Valid || !eventOrganization.Valid || !eventRating.Valid || !eventDate.Valid || !eventImageURL.Valid || !eventPrice.Valid || !partnerID.Valid {
-			continue
-		}
+// nullString grava strings vazias como NULL, para as colunas anuláveis com chave estrangeira.
+func nullString(value string) sql.NullString {
+	return sql.NullString{String: value, Valid: value != ""}
+}
 
-		event, exists := eventMap[eventID.String]
-		if !exists {
-			eventDateParsed, err := time.Parse("2006-01-02 15:04:05", eventDate.String)
-			if err != nil {
-				return nil, err
-			}
-			event = &domain.Event{
-				ID:           eventID.String,
-				Name:         eventName.String,
-				Location:     eventLocation.String,
-				Organization: eventOrganization.String,
-				Rating:       domain.Rating(eventRating.String),
-				Date:         eventDateParsed,
-				ImageURL:     eventImageURL.String,
-				Capacity:     eventCapacity,
-				Price:        eventPrice.Float64,
-				PartnerID:    int(partnerID.Int32),
-				Spots:        []domain.Spot{},
-				Tickets:      []domain.Ticket{},
-			}
-			eventMap[eventID.String] = event
-		}
+// dateTimeLayout é o formato das colunas de data e hora. Os instantes são sempre gravados em UTC,
+// independentemente do fuso do servidor, e lidos de volta como UTC.
+const dateTimeLayout = "2006-01-02 15:04:05"
 
-		if spotID.Valid {
-			spot, spotExists := spotMap[spotID.String]
-			if !spotExists {
-				spot = &domain.Spot{
-					ID:       spotID.String,
-					EventID:  spotEventID.String,
-					Name:     spotName.String,
-					Status:   domain.SpotStatus(spotStatus.String),
-					TicketID: spotTicketID.String,
-				}
-				event.Spots = append(event.Spots, *spot)
-				spotMap[spotID.String] = spot
-			}
+func formatDateTime(t time.Time) string {
+	return t.UTC().Format(dateTimeLayout)
+}
 
-			if ticketID.Valid {
-				ticket := domain.Ticket{
-					ID:         ticketID.String,
-					EventID:    ticketEventID.String,
-					Spot:       spot,
-					TicketKind: domain.TicketKind(ticketKind.String),
-					Price:      ticketPrice.Float64,
-				}
-				event.Tickets = append(event.Tickets, ticket)
-			}
-		}
-	}
+func parseDateTime(value string) (time.Time, error) {
+	return time.Parse(dateTimeLayout, value)
+}
 
-	events := make([]domain.Event, 0, len(eventMap))
-	for _, event := range eventMap {
-		events = append(events, *event)
+// parseSpotHold preenche os dados de retenção do spot a partir das colunas anuláveis hold_owner e hold_expires_at.
+func parseSpotHold(spot *domain.Spot, holdOwner, holdExpiresAt sql.NullString) error {
+	spot.HoldOwner = holdOwner.String
+	if !holdExpiresAt.Valid {
+		return nil
 	}
 
-	if err := rows.Err(); err != nil {
-		return nil, err
+	expiresAt, err := parseDateTime(holdExpiresAt.String)
+	if err != nil {
+		return err
 	}
-
-	return events, nil
-}
-
-func (r *mysqlEventRepository) CreateEvent(event *domain.Event) error {
-	query := `
-		INSERT INTO events (id, name, location, organization, rating, date, image_url, capacity, price, partner_id)
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
-	`
-	_, err := r.db.Exec(query, event.ID, event.Name, event.Location, event.Organization, event.Rating, event.Date.Format("2006-01-02 15:04:05"), event.ImageURL, event.Capacity, event.Price, event.PartnerID)
-	return err
+	spot.HoldExpiresAt = expiresAt
+	return nil
 }
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+)
+
+// CreateIdempotencyKey insere a chave. Chaves expiradas são substituídas;
+// se outra requisição já registrou a chave, retorna domain.ErrIdempotencyKeyExists.
+func (r *mysqlEventRepository) CreateIdempotencyKey(ctx context.Context, key *domain.IdempotencyKey) error {
+	// Descarta uma chave expirada com o mesmo valor para que o INSERT possa reutilizá-la.
+	deleteQuery := `
+		DELETE FROM idempotency_keys
+		WHERE idempotency_key = ? AND expires_at <= ?
+	`
+	if _, err := r.db.ExecContext(ctx, deleteQuery, key.Key, formatDateTime(time.Now())); err != nil {
+		return err
+	}
+
+	// INSERT IGNORE não falha com chave duplicada: nenhuma linha afetada significa que ela já existe.
+	query := `
+		INSERT IGNORE INTO idempotency_keys (idempotency_key, fingerprint, status, response, created_at, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`
+	result, err := r.db.ExecContext(ctx, query,
+		key.Key, key.Fingerprint, key.Status, nullableResponse(key.Response),
+		formatDateTime(key.CreatedAt), formatDateTime(key.ExpiresAt),
+	)
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return domain.ErrIdempotencyKeyExists
+	}
+	return nil
+}
+
+// FindIdempotencyKey busca uma chave de idempotência.
+func (r *mysqlEventRepository) FindIdempotencyKey(ctx context.Context, key string) (*domain.IdempotencyKey, error) {
+	query := `
+		SELECT idempotency_key, fingerprint, status, response, created_at, expires_at
+		FROM idempotency_keys
+		WHERE idempotency_key = ?
+	`
+	var found domain.IdempotencyKey
+	var response sql.NullString
+	var createdAt, expiresAt string
+	err := r.db.QueryRowContext(ctx, query, key).Scan(
+		&found.Key, &found.Fingerprint, &found.Status, &response, &createdAt, &expiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrIdempotencyKeyNotFound
+		}
+		return nil, err
+	}
+
+	if response.Valid {
+		found.Response = []byte(response.String)
+	}
+	if found.CreatedAt, err = parseDateTime(createdAt); err != nil {
+		return nil, err
+	}
+	if found.ExpiresAt, err = parseDateTime(expiresAt); err != nil {
+		return nil, err
+	}
+	return &found, nil
+}
+
+// CompleteIdempotencyKey armazena a resposta da chave.
+func (r *mysqlEventRepository) CompleteIdempotencyKey(ctx context.Context, key *domain.IdempotencyKey) error {
+	query := `
+		UPDATE idempotency_keys
+		SET status = ?, response = ?, expires_at = ?
+		WHERE idempotency_key = ?
+	`
+	_, err := r.db.ExecContext(ctx, query, key.Status, nullableResponse(key.Response), formatDateTime(key.ExpiresAt), key.Key)
+	return err
+}
+
+// DeleteIdempotencyKey remove a chave enquanto ela ainda está em execução.
+func (r *mysqlEventRepository) DeleteIdempotencyKey(ctx context.Context, key string) error {
+	query := `
+		DELETE FROM idempotency_keys
+		WHERE idempotency_key = ? AND status = ?
+	`
+	_, err := r.db.ExecContext(ctx, query, key, domain.IdempotencyKeyInProgress)
+	return err
+}
+
+// DeleteExpiredIdempotencyKeys remove as chaves expiradas.
+func (r *mysqlEventRepository) DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error) {
+	query := `
+		DELETE FROM idempotency_keys
+		WHERE expires_at <= ?
+	`
+	result, err := r.db.ExecContext(ctx, query, formatDateTime(now))
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func nullableResponse(response []byte) sql.NullString {
+	return sql.NullString{String: string(response), Valid: response != nil}
+}
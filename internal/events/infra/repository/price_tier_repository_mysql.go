@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+)
+
+func (r *mysqlEventRepository) CreatePriceTier(ctx context.Context, tier *domain.PriceTier) error {
+	query := `
+		INSERT INTO event_price_tiers (id, event_id, name, price_amount, currency)
+		VALUES (?, ?, ?, ?, ?)
+	`
+	_, err := r.db.ExecContext(ctx, query, tier.ID, tier.EventID, tier.Name, tier.Price.Amount, tier.Price.Currency)
+	return err
+}
+
+// findPriceTiers carrega as categorias de preço do evento, na ordem de criação.
+func (r *mysqlEventRepository) findPriceTiers(ctx context.Context, eventID string) ([]domain.PriceTier, error) {
+	tiers, err := r.findEventsPriceTiers(ctx, []string{eventID})
+	if err != nil {
+		return nil, err
+	}
+	return tiers[eventID], nil
+}
+
+// findEventsPriceTiers carrega em uma única consulta as categorias de preço de cada evento,
+// na ordem de criação. Todo evento informado tem uma entrada, vazia se ele não tiver categorias.
+func (r *mysqlEventRepository) findEventsPriceTiers(ctx context.Context, eventIDs []string) (map[string][]domain.PriceTier, error) {
+	tiers := make(map[string][]domain.PriceTier, len(eventIDs))
+	if len(eventIDs) == 0 {
+		return tiers, nil
+	}
+	placeholders := make([]string, len(eventIDs))
+	args := make([]any, len(eventIDs))
+	for i, id := range eventIDs {
+		tiers[id] = []domain.PriceTier{}
+		placeholders[i] = "?"
+		args[i] = id
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, event_id, name, price_amount, currency
+		FROM event_price_tiers
+		WHERE event_id IN (`+strings.Join(placeholders, ", ")+`)
+		ORDER BY seq
+	`, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var tier domain.PriceTier
+		var amount int64
+		var currency string
+		if err := rows.Scan(&tier.ID, &tier.EventID, &tier.Name, &amount, &currency); err != nil {
+			return nil, err
+		}
+		tier.Price = domain.NewMoney(amount, domain.Currency(currency))
+		tiers[tier.EventID] = append(tiers[tier.EventID], tier)
+	}
+	return tiers, rows.Err()
+}
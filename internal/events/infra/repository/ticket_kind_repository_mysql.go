@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+)
+
+func (r *mysqlEventRepository) CreateTicketKindRule(ctx context.Context, rule *domain.TicketKindRule) error {
+	query := `
+		INSERT INTO event_ticket_kinds (event_id, kind, name, discount_bps, quota_percent, document, companion_seats)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err := r.db.ExecContext(ctx, query, rule.EventID, rule.Kind, rule.Name, rule.Discount, rule.QuotaPercent, rule.Document, rule.CompanionSeats)
+	return err
+}
+
+// findTicketKindRules carrega os tipos de ingresso do evento, na ordem de criação.
+func (r *mysqlEventRepository) findTicketKindRules(ctx context.Context, eventID string) ([]domain.TicketKindRule, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT kind, name, discount_bps, quota_percent, document, companion_seats
+		FROM event_ticket_kinds
+		WHERE event_id = ?
+		ORDER BY seq
+	`, eventID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	rules := []domain.TicketKindRule{}
+	for rows.Next() {
+		rule := domain.TicketKindRule{EventID: eventID}
+		if err := rows.Scan(&rule.Kind, &rule.Name, &rule.Discount, &rule.QuotaPercent, &rule.Document, &rule.CompanionSeats); err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	return rules, rows.Err()
+}
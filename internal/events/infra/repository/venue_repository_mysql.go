@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+)
+
+// CreateVenue insere o local, os setores, as fileiras e os assentos. Deve ser chamado
+// dentro de RunInTx para que um mapa incompleto nunca fique visível.
+func (r *mysqlEventRepository) CreateVenue(ctx context.Context, venue *domain.Venue) error {
+	if _, err := r.db.ExecContext(ctx, "INSERT INTO venues (id, name, address) VALUES (?, ?, ?)", venue.ID, venue.Name, venue.Address); err != nil {
+		return err
+	}
+
+	for i, section := range venue.Sections {
+		if _, err := r.db.ExecContext(ctx,
+			"INSERT INTO venue_sections (id, venue_id, name, position) VALUES (?, ?, ?, ?)",
+			section.ID, venue.ID, section.Name, i,
+		); err != nil {
+			return err
+		}
+		for j, row := range section.Rows {
+			if _, err := r.db.ExecContext(ctx,
+				"INSERT INTO venue_rows (id, section_id, label, position) VALUES (?, ?, ?, ?)",
+				row.ID, section.ID, row.Label, j,
+			); err != nil {
+				return err
+			}
+			if err := r.insertSeats(ctx, row); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// insertSeats insere os assentos da fileira em um único comando.
+func (r *mysqlEventRepository) insertSeats(ctx context.Context, row domain.Row) error {
+	if len(row.Seats) == 0 {
+		return nil
+	}
+	placeholders := make([]string, len(row.Seats))
+	args := make([]any, 0, len(row.Seats)*3)
+	for i, seat := range row.Seats {
+		placeholders[i] = "(?, ?, ?)"
+		args = append(args, seat.ID, row.ID, seat.Number)
+	}
+	query := "INSERT INTO venue_seats (id, row_id, number) VALUES " + strings.Join(placeholders, ", ")
+	_, err := r.db.ExecContext(ctx, query, args...)
+	return err
+}
+
+// FindVenueByID carrega o local e o seu mapa, com setores e fileiras na ordem de cadastro.
+func (r *mysqlEventRepository) FindVenueByID(ctx context.Context, venueID string) (*domain.Venue, error) {
+	venue := &domain.Venue{ID: venueID, Sections: []domain.Section{}}
+	err := r.db.QueryRowContext(ctx, "SELECT name, address FROM venues WHERE id = ?", venueID).Scan(&venue.Name, &venue.Address)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, domain.ErrVenueNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT sec.id, sec.name, r.id, r.label, seat.id, seat.number
+		FROM venue_sections sec
+		JOIN venue_rows r ON r.section_id = sec.id
+		JOIN venue_seats seat ON seat.row_id = r.id
+		WHERE sec.venue_id = ?
+		ORDER BY sec.position, r.position, seat.number
+	`
+	rows, err := r.db.QueryContext(ctx, query, venueID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sectionID, sectionName, rowID, rowLabel string
+		var seat domain.Seat
+		if err := rows.Scan(&sectionID, &sectionName, &rowID, &rowLabel, &seat.ID, &seat.Number); err != nil {
+			return nil, err
+		}
+
+		// As linhas chegam ordenadas, então um setor ou fileira novo sempre vem depois do anterior.
+		if n := len(venue.Sections); n == 0 || venue.Sections[n-1].ID != sectionID {
+			venue.Sections = append(venue.Sections, domain.Section{ID: sectionID, VenueID: venueID, Name: sectionName, Rows: []domain.Row{}})
+		}
+		section := &venue.Sections[len(venue.Sections)-1]
+		if n := len(section.Rows); n == 0 || section.Rows[n-1].ID != rowID {
+			section.Rows = append(section.Rows, domain.Row{ID: rowID, SectionID: sectionID, Label: rowLabel, Seats: []domain.Seat{}})
+		}
+		row := &section.Rows[len(section.Rows)-1]
+		seat.RowID = rowID
+		row.Seats = append(row.Seats, seat)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return venue, nil
+}
+
+func (r *mysqlEventRepository) ListVenues(ctx context.Context) ([]domain.Venue, error) {
+	rows, err := r.db.QueryContext(ctx, "SELECT id, name, address FROM venues ORDER BY name, id")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	venues := []domain.Venue{}
+	for rows.Next() {
+		var venue domain.Venue
+		if err := rows.Scan(&venue.ID, &venue.Name, &venue.Address); err != nil {
+			return nil, err
+		}
+		venues = append(venues, venue)
+	}
+	return venues, rows.Err()
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// ErrPartnerCircuitOpen é retornado (via CircuitOpenError) enquanto o circuit breaker de um parceiro está aberto.
+var ErrPartnerCircuitOpen = errors.New("partner circuit breaker is open")
+
+// CircuitOpenError indica que a chamada foi recusada sem contatar o parceiro.
+type CircuitOpenError struct {
+	PartnerID int
+	RetryAt   time.Time // A partir de quando uma nova tentativa será permitida.
+}
+
+func (e *CircuitOpenError) Error() string {
+	return fmt.Sprintf("partner %d: circuit breaker is open until %s", e.PartnerID, e.RetryAt.Format(time.RFC3339))
+}
+
+// Is faz o erro corresponder a ErrPartnerCircuitOpen e a ErrRequestNotSent, já que o parceiro não foi contatado.
+func (e *CircuitOpenError) Is(target error) bool {
+	return target == ErrPartnerCircuitOpen || target == ErrRequestNotSent
+}
+
+// CircuitBreakerConfig define quando o circuito abre e por quanto tempo fica aberto.
+type CircuitBreakerConfig struct {
+	FailureThreshold int           `yaml:"failure_threshold"` // Falhas consecutivas que abrem o circuito.
+	OpenTimeout      time.Duration `yaml:"open_timeout"`      // Tempo aberto antes de permitir uma chamada de teste.
+}
+
+// DefaultCircuitBreakerConfig é usada nos campos não configurados de CircuitBreakerConfig.
+var DefaultCircuitBreakerConfig = CircuitBreakerConfig{
+	FailureThreshold: 5,
+	OpenTimeout:      30 * time.Second,
+}
+
+func (c CircuitBreakerConfig) withDefaults() CircuitBreakerConfig {
+	if c.FailureThreshold <= 0 {
+		c.FailureThreshold = DefaultCircuitBreakerConfig.FailureThreshold
+	}
+	if c.OpenTimeout <= 0 {
+		c.OpenTimeout = DefaultCircuitBreakerConfig.OpenTimeout
+	}
+	return c
+}
+
+type BreakerState string
+
+const (
+	BreakerClosed   BreakerState = "closed"    // Chamadas passam normalmente.
+	BreakerOpen     BreakerState = "open"      // Chamadas falham imediatamente com CircuitOpenError.
+	BreakerHalfOpen BreakerState = "half_open" // Uma única chamada de teste decide se o circuito fecha ou reabre.
+)
+
+// BreakerSnapshot é uma fotografia do estado do circuit breaker de um parceiro.
+type BreakerSnapshot struct {
+	PartnerID           int          `json:"partner_id"`
+	State               BreakerState `json:"state"`
+	ConsecutiveFailures int          `json:"consecutive_failures"`
+	OpenedAt            *time.Time   `json:"opened_at,omitempty"`
+	RetryAt             *time.Time   `json:"retry_at,omitempty"`
+}
+
+type callOutcome int
+
+const (
+	outcomeSuccess callOutcome = iota
+	outcomeFailure
+	outcomeIgnored // a chamada não diz nada sobre a saúde do parceiro (ex.: cancelada pelo cliente)
+)
+
+// CircuitBreaker isola um parceiro instável: após FailureThreshold falhas consecutivas as chamadas
+// falham imediatamente por OpenTimeout; depois disso uma chamada de teste decide se o circuito fecha.
+type CircuitBreaker struct {
+	partnerID int
+	cfg       CircuitBreakerConfig
+
+	mu       sync.Mutex
+	state    BreakerState
+	failures int
+	openedAt time.Time
+	probing  bool // há uma chamada de teste em andamento no estado half_open
+	now      func() time.Time
+}
+
+// NewCircuitBreaker cria um circuit breaker fechado para o parceiro partnerID.
+func NewCircuitBreaker(partnerID int, cfg CircuitBreakerConfig) *CircuitBreaker {
+	return &CircuitBreaker{
+		partnerID: partnerID,
+		cfg:       cfg.withDefaults(),
+		state:     BreakerClosed,
+		now:       time.Now,
+	}
+}
+
+// allow retorna CircuitOpenError se a chamada não deve ser feita.
+func (b *CircuitBreaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		retryAt := b.openedAt.Add(b.cfg.OpenTimeout)
+		if b.now().Before(retryAt) {
+			return &CircuitOpenError{PartnerID: b.partnerID, RetryAt: retryAt}
+		}
+		b.transition(BreakerHalfOpen)
+		b.probing = true
+		return nil
+	case BreakerHalfOpen:
+		if b.probing {
+			return &CircuitOpenError{PartnerID: b.partnerID, RetryAt: b.now()}
+		}
+		b.probing = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// record registra o resultado de uma chamada liberada por allow.
+func (b *CircuitBreaker) record(outcome callOutcome) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	wasProbe := b.state == BreakerHalfOpen
+	b.probing = false
+
+	switch outcome {
+	case outcomeSuccess:
+		b.failures = 0
+		if wasProbe {
+			b.transition(BreakerClosed)
+		}
+	case outcomeFailure:
+		b.failures++
+		if wasProbe || (b.state == BreakerClosed && b.failures >= b.cfg.FailureThreshold) {
+			b.openedAt = b.now()
+			b.transition(BreakerOpen)
+		}
+	}
+}
+
+func (b *CircuitBreaker) transition(to BreakerState) {
+	if b.state == to {
+		return
+	}
+	log.Printf("partner %d: circuit breaker %s -> %s (falhas consecutivas: %d)", b.partnerID, b.state, to, b.failures)
+	b.state = to
+}
+
+// Snapshot retorna o estado atual do circuit breaker.
+func (b *CircuitBreaker) Snapshot() BreakerSnapshot {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	snapshot := BreakerSnapshot{
+		PartnerID:           b.partnerID,
+		State:               b.state,
+		ConsecutiveFailures: b.failures,
+	}
+	if b.state != BreakerClosed {
+		openedAt, retryAt := b.openedAt, b.openedAt.Add(b.cfg.OpenTimeout)
+		snapshot.OpenedAt = &openedAt
+		snapshot.RetryAt = &retryAt
+	}
+	return snapshot
+}
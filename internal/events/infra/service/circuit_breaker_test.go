@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func newTestBreaker(now *time.Time) *CircuitBreaker {
+	b := NewCircuitBreaker(1, CircuitBreakerConfig{FailureThreshold: 3, OpenTimeout: time.Minute})
+	b.now = func() time.Time { return *now }
+	return b
+}
+
+func TestCircuitBreakerLifecycle(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	b := newTestBreaker(&now)
+
+	assertState := func(want BreakerState) {
+		t.Helper()
+		if got := b.Snapshot().State; got != want {
+			t.Fatalf("state = %s, want %s", got, want)
+		}
+	}
+	call := func(outcome callOutcome) {
+		t.Helper()
+		if err := b.allow(); err != nil {
+			t.Fatalf("allow = %v, want the call to be allowed", err)
+		}
+		b.record(outcome)
+	}
+
+	// Um sucesso zera as falhas consecutivas; o circuito abre só com FailureThreshold seguidas.
+	call(outcomeFailure)
+	call(outcomeFailure)
+	call(outcomeSuccess)
+	call(outcomeFailure)
+	call(outcomeFailure)
+	call(outcomeIgnored)
+	assertState(BreakerClosed)
+	call(outcomeFailure)
+	assertState(BreakerOpen)
+
+	snapshot := b.Snapshot()
+	if snapshot.ConsecutiveFailures != 3 || !snapshot.OpenedAt.Equal(now) || !snapshot.RetryAt.Equal(now.Add(time.Minute)) {
+		t.Errorf("open snapshot = %+v, want 3 failures, opened now and retry in a minute", snapshot)
+	}
+
+	// Aberto, recusa as chamadas até OpenTimeout.
+	now = now.Add(time.Minute - time.Second)
+	var openErr *CircuitOpenError
+	if err := b.allow(); !errors.As(err, &openErr) || !openErr.RetryAt.Equal(*snapshot.RetryAt) {
+		t.Fatalf("allow while open = %v, want CircuitOpenError until %s", err, snapshot.RetryAt)
+	}
+
+	// Após OpenTimeout, uma chamada de teste que falha reabre o circuito.
+	now = now.Add(time.Second)
+	call(outcomeFailure)
+	assertState(BreakerOpen)
+	if err := b.allow(); !errors.Is(err, ErrPartnerCircuitOpen) {
+		t.Fatalf("allow after the failed probe = %v, want ErrPartnerCircuitOpen", err)
+	}
+
+	// Uma chamada de teste bem-sucedida fecha o circuito.
+	now = now.Add(time.Minute)
+	call(outcomeSuccess)
+	assertState(BreakerClosed)
+	if snapshot := b.Snapshot(); snapshot.ConsecutiveFailures != 0 || snapshot.OpenedAt != nil {
+		t.Errorf("closed snapshot = %+v, want no failures and no opening time", snapshot)
+	}
+}
+
+func TestCircuitBreakerAllowsASingleProbe(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	b := newTestBreaker(&now)
+	for i := 0; i < 3; i++ {
+		b.allow()
+		b.record(outcomeFailure)
+	}
+	now = now.Add(time.Minute)
+
+	if err := b.allow(); err != nil {
+		t.Fatalf("first allow after the timeout = %v, want the probe to be allowed", err)
+	}
+	if state := b.Snapshot().State; state != BreakerHalfOpen {
+		t.Fatalf("state during the probe = %s, want %s", state, BreakerHalfOpen)
+	}
+	for i := 0; i < 3; i++ {
+		if err := b.allow(); !errors.Is(err, ErrPartnerCircuitOpen) {
+			t.Fatalf("allow during the probe = %v, want ErrPartnerCircuitOpen", err)
+		}
+	}
+
+	// Uma chamada de teste cancelada pelo cliente não decide nada; a próxima chamada vira o novo teste.
+	b.record(outcomeIgnored)
+	if state := b.Snapshot().State; state != BreakerHalfOpen {
+		t.Fatalf("state after an ignored probe = %s, want %s", state, BreakerHalfOpen)
+	}
+	if err := b.allow(); err != nil {
+		t.Fatalf("allow after an ignored probe = %v, want a new probe", err)
+	}
+	if err := b.allow(); err == nil {
+		t.Fatal("second concurrent probe allowed")
+	}
+	b.record(outcomeSuccess)
+	if state := b.Snapshot().State; state != BreakerClosed {
+		t.Errorf("state after a successful probe = %s, want %s", state, BreakerClosed)
+	}
+}
+
+func TestClassifyOutcome(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		cancelled bool // o chamador desistiu da chamada
+		want      callOutcome
+	}{
+		{name: "success", err: nil, want: outcomeSuccess},
+		{name: "business rejection", err: errStatus(409), want: outcomeSuccess},
+		{name: "too many requests", err: errStatus(429), want: outcomeFailure},
+		{name: "server error", err: errStatus(500), want: outcomeFailure},
+		{name: "dial failure", err: errDial, want: outcomeFailure},
+		{name: "timeout", err: context.DeadlineExceeded, want: outcomeFailure},
+		{name: "cancelled by the caller", err: context.Canceled, cancelled: true, want: outcomeIgnored},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.cancelled {
+				cancel()
+			}
+			if got := classifyOutcome(ctx, tt.err); got != tt.want {
+				t.Errorf("classifyOutcome(%v) = %d, want %d", tt.err, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,268 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// PartnerKindHTTP é o kind do adaptador genérico, configurado inteiramente por um PartnerMapping.
+const PartnerKindHTTP = "http"
+
+// DefaultPartnerTimeout é o prazo de cada chamada ao parceiro quando a configuração não define um.
+const DefaultPartnerTimeout = 10 * time.Second
+
+func init() {
+	RegisterPartner(PartnerKindHTTP, func(cfg PartnerConfig) (Partner, error) {
+		if cfg.Mapping == nil {
+			return nil, fmt.Errorf("partner with ID %d: kind %q requires a mapping", cfg.ID, PartnerKindHTTP)
+		}
+		return NewHTTPPartner(cfg.BaseURL, *cfg.Mapping, cfg.Timeout)
+	})
+
+	// Partner1 e Partner2 são apenas mapeamentos pré-definidos do adaptador genérico.
+	// Um mapping na configuração sobrescreve o pré-definido.
+	registerMappedPartner("partner1", Partner1Mapping)
+	registerMappedPartner("partner2", Partner2Mapping)
+}
+
+func registerMappedPartner(kind string, mapping PartnerMapping) {
+	RegisterPartner(kind, func(cfg PartnerConfig) (Partner, error) {
+		if cfg.Mapping != nil {
+			return NewHTTPPartner(cfg.BaseURL, *cfg.Mapping, cfg.Timeout)
+		}
+		return NewHTTPPartner(cfg.BaseURL, mapping, cfg.Timeout)
+	})
+}
+
+// HTTPPartner é um adaptador genérico para parceiros que expõem reservas via HTTP/JSON.
+type HTTPPartner struct {
+	BaseURL string         // URL base para a API do parceiro.
+	Mapping PartnerMapping // Como traduzir as requisições e respostas para o formato do parceiro.
+	Timeout time.Duration  // Prazo máximo de cada chamada ao parceiro.
+	client  *http.Client
+}
+
+// NewHTTPPartner cria o adaptador, validando o mapeamento.
+// Um timeout zero usa DefaultPartnerTimeout.
+func NewHTTPPartner(baseURL string, mapping PartnerMapping, timeout time.Duration) (*HTTPPartner, error) {
+	if err := mapping.Validate(); err != nil {
+		return nil, err
+	}
+	if timeout <= 0 {
+		timeout = DefaultPartnerTimeout
+	}
+	return &HTTPPartner{
+		BaseURL: strings.TrimSuffix(baseURL, "/"),
+		Mapping: mapping,
+		Timeout: timeout,
+		client:  &http.Client{},
+	}, nil
+}
+
+// MakeReservation envia uma solicitação de reserva para o parceiro e retorna as respostas da reserva.
+func (p *HTTPPartner) MakeReservation(ctx context.Context, req *ReservationRequest) ([]ReservationResponse, error) {
+	fields := p.Mapping.ReserveRequest
+
+	// Converte a solicitação de reserva genérica para o formato específico do parceiro.
+	body, err := encodeObject([]jsonField{
+		{fields.Spots, req.Spots},
+		{fields.TicketKind, req.TicketKind},
+		{fields.Email, req.Email},
+	})
+	if err != nil {
+		return nil, notSent(err)
+	}
+
+	// O prazo vale para a chamada inteira, incluindo a leitura da resposta.
+	ctx, cancel := context.WithTimeout(ctx, p.Timeout)
+	defer cancel()
+
+	httpResp, err := p.do(ctx, p.Mapping.Reserve, req.EventID, body)
+	if err != nil {
+		return nil, err
+	}
+	// Fecha o corpo da resposta quando a função terminar.
+	defer httpResp.Body.Close()
+
+	// Decodifica a resposta JSON do parceiro; cada item corresponde a um spot reservado.
+	var partnerResp []map[string]any
+	decoder := json.NewDecoder(httpResp.Body)
+	decoder.UseNumber()
+	if err := decoder.Decode(&partnerResp); err != nil {
+		return nil, err
+	}
+
+	// Converte as respostas do parceiro para o formato genérico de ReservationResponse.
+	mapping := p.Mapping.Response
+	responses := make([]ReservationResponse, len(partnerResp))
+	for i, r := range partnerResp {
+		responses[i] = ReservationResponse{
+			ID:         stringField(r, mapping.ID),
+			Email:      stringField(r, mapping.Email),
+			Spot:       stringField(r, mapping.Spot),
+			TicketKind: stringField(r, mapping.TicketKind),
+			Status:     p.status(stringField(r, mapping.Status)),
+			EventID:    stringField(r, mapping.EventID),
+		}
+	}
+
+	return responses, nil
+}
+
+// CancelReservation solicita ao parceiro o cancelamento de uma reserva feita anteriormente.
+func (p *HTTPPartner) CancelReservation(ctx context.Context, req *CancelReservationRequest) error {
+	fields := p.Mapping.CancelRequest
+
+	body, err := encodeObject([]jsonField{
+		{fields.Spots, req.Spots},
+		{fields.ReservationIDs, req.ReservationIDs},
+		{fields.Email, req.Email},
+	})
+	if err != nil {
+		return notSent(err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, p.Timeout)
+	defer cancel()
+
+	httpResp, err := p.do(ctx, p.Mapping.Cancel, req.EventID, body)
+	if err != nil {
+		return err
+	}
+	return httpResp.Body.Close()
+}
+
+// do envia body para a rota descrita por endpoint e valida o código de status da resposta.
+// Em caso de sucesso o chamador é responsável por fechar o corpo da resposta.
+func (p *HTTPPartner) do(ctx context.Context, endpoint EndpointMapping, eventID string, body []byte) (*http.Response, error) {
+	method := endpoint.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+
+	// Constrói a URL da rota, incluindo o ID do evento.
+	path := strings.ReplaceAll(endpoint.Path, "{event_id}", url.PathEscape(eventID))
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, p.BaseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return nil, notSent(err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	httpResp, err := p.client.Do(httpReq)
+	if err != nil {
+		// Sem conexão a requisição certamente não chegou ao parceiro; qualquer outra falha é ambígua.
+		var opErr *net.OpError
+		if errors.As(err, &opErr) && opErr.Op == "dial" {
+			return nil, notSent(err)
+		}
+		return nil, err
+	}
+
+	if !isSuccessStatus(endpoint.SuccessStatus, httpResp.StatusCode) {
+		httpResp.Body.Close()
+		return nil, &PartnerStatusError{StatusCode: httpResp.StatusCode}
+	}
+	return httpResp, nil
+}
+
+// ErrRequestNotSent marca as falhas em que a requisição não chegou a ser enviada ao parceiro
+// (erro ao montar a requisição, falha ao conectar ou circuit breaker aberto).
+var ErrRequestNotSent = errors.New("partner request not sent")
+
+func notSent(err error) error {
+	return fmt.Errorf("%w: %w", ErrRequestNotSent, err)
+}
+
+// IsReservationRejected indica se err garante que o parceiro não fez a reserva: ele a recusou
+// com um código 4xx ou a requisição nem foi enviada. Qualquer outra falha (timeout, 5xx, conexão
+// interrompida, resposta ilegível) é ambígua, pois o parceiro pode ter reservado os lugares.
+func IsReservationRejected(err error) bool {
+	if errors.Is(err, ErrRequestNotSent) {
+		return true
+	}
+	var statusErr *PartnerStatusError
+	return errors.As(err, &statusErr) && statusErr.StatusCode >= 400 && statusErr.StatusCode <= 499
+}
+
+// PartnerStatusError indica que o parceiro respondeu com um código de status inesperado.
+type PartnerStatusError struct {
+	StatusCode int
+}
+
+func (e *PartnerStatusError) Error() string {
+	return fmt.Sprintf("unexpected status code: %d", e.StatusCode)
+}
+
+// status traduz o status do parceiro para o vocabulário interno, quando houver tradução.
+func (p *HTTPPartner) status(partnerStatus string) string {
+	if status, ok := p.Mapping.StatusVocabulary[partnerStatus]; ok {
+		return status
+	}
+	return partnerStatus
+}
+
+func isSuccessStatus(expected, got int) bool {
+	if expected == 0 {
+		return got >= 200 && got <= 299
+	}
+	return got == expected
+}
+
+type jsonField struct {
+	name  string
+	value any
+}
+
+// encodeObject serializa os campos como um objeto JSON, na ordem informada,
+// ignorando os campos sem nome (não enviados para o parceiro).
+func encodeObject(fields []jsonField) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	first := true
+	for _, f := range fields {
+		if f.name == "" {
+			continue
+		}
+		name, err := json.Marshal(f.name)
+		if err != nil {
+			return nil, err
+		}
+		value, err := json.Marshal(f.value)
+		if err != nil {
+			return nil, err
+		}
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(value)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// stringField lê um campo da resposta do parceiro como texto.
+func stringField(obj map[string]any, name string) string {
+	if name == "" {
+		return ""
+	}
+	switch v := obj[name].(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+// partnerExchange é a troca esperada com o parceiro em uma chamada: a requisição que ele deve
+// receber, exatamente como enviada, e a resposta que o servidor de teste devolve.
+type partnerExchange struct {
+	method string
+	path   string
+	body   string
+	status int
+	reply  string
+}
+
+func TestRegisteredPartnersGolden(t *testing.T) {
+	reservation := &ReservationRequest{
+		EventID:    "event 1",
+		Spots:      []string{"A1", "A2"},
+		TicketKind: "half",
+		CardHash:   "card-hash",
+		Email:      "buyer@example.com",
+	}
+	cancellation := &CancelReservationRequest{
+		EventID:        "event 1",
+		Spots:          []string{"A1", "A2"},
+		ReservationIDs: []string{"r-1", "42"},
+		Email:          "buyer@example.com",
+	}
+	tests := []struct {
+		kind    string
+		reserve partnerExchange
+		cancel  partnerExchange
+		want    []ReservationResponse
+	}{
+		{
+			kind: "partner1",
+			reserve: partnerExchange{
+				method: http.MethodPost,
+				path:   "/events/event%201/reserve",
+				body:   `{"spots":["A1","A2"],"ticket_kind":"half","email":"buyer@example.com"}`,
+				status: http.StatusCreated,
+				reply: `[
+					{"id":"r-1","email":"buyer@example.com","spot":"A1","ticket_kind":"half","status":"reserved","event_id":"event 1"},
+					{"id":42,"email":"buyer@example.com","spot":"A2","ticket_kind":"half","status":"reserved","event_id":"event 1","extra":true}
+				]`,
+			},
+			cancel: partnerExchange{
+				method: http.MethodPost,
+				path:   "/events/event%201/cancel",
+				body:   `{"spots":["A1","A2"],"ids":["r-1","42"],"email":"buyer@example.com"}`,
+				status: http.StatusOK,
+			},
+			want: []ReservationResponse{
+				{ID: "r-1", Email: "buyer@example.com", Spot: "A1", TicketKind: "half", Status: "reserved", EventID: "event 1"},
+				{ID: "42", Email: "buyer@example.com", Spot: "A2", TicketKind: "half", Status: "reserved", EventID: "event 1"},
+			},
+		},
+		{
+			kind: "partner2",
+			reserve: partnerExchange{
+				method: http.MethodPost,
+				path:   "/eventos/event%201/reservar",
+				body:   `{"lugares":["A1","A2"],"tipo_ingresso":"half","email":"buyer@example.com"}`,
+				status: http.StatusCreated,
+				reply: `[
+					{"id":"r-1","email":"buyer@example.com","lugar":"A1","tipo_ingresso":"half","estado":"reserved","evento_id":"event 1"},
+					{"id":42,"email":"buyer@example.com","lugar":"A2","tipo_ingresso":"half","estado":"reserved","evento_id":"event 1"}
+				]`,
+			},
+			cancel: partnerExchange{
+				method: http.MethodPost,
+				path:   "/eventos/event%201/cancelar",
+				body:   `{"lugares":["A1","A2"],"reservas":["r-1","42"],"email":"buyer@example.com"}`,
+				status: http.StatusNoContent,
+			},
+			want: []ReservationResponse{
+				{ID: "r-1", Email: "buyer@example.com", Spot: "A1", TicketKind: "half", Status: "reserved", EventID: "event 1"},
+				{ID: "42", Email: "buyer@example.com", Spot: "A2", TicketKind: "half", Status: "reserved", EventID: "event 1"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			var exchange partnerExchange
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+				if r.Method != exchange.method || r.URL.EscapedPath() != exchange.path {
+					t.Errorf("request = %s %s, want %s %s", r.Method, r.URL.EscapedPath(), exchange.method, exchange.path)
+				}
+				if got := r.Header.Get("Content-Type"); got != "application/json" {
+					t.Errorf("Content-Type = %q, want application/json", got)
+				}
+				if string(body) != exchange.body {
+					t.Errorf("request body =\n%s\nwant\n%s", body, exchange.body)
+				}
+				w.WriteHeader(exchange.status)
+				io.WriteString(w, exchange.reply)
+			}))
+			defer server.Close()
+
+			partner, err := DefaultPartnerRegistry.New(PartnerConfig{ID: 1, Kind: tt.kind, BaseURL: server.URL + "/"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			exchange = tt.reserve
+			got, err := partner.MakeReservation(context.Background(), reservation)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MakeReservation =\n%+v\nwant\n%+v", got, tt.want)
+			}
+
+			exchange = tt.cancel
+			if err := partner.CancelReservation(context.Background(), cancellation); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestHTTPPartnerStatusVocabularyAndUnexpectedStatus(t *testing.T) {
+	status := http.StatusCreated
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		io.WriteString(w, `[{"lugar":"A1","estado":"reservado"},{"lugar":"A2","estado":"pendente"}]`)
+	}))
+	defer server.Close()
+
+	mapping := Partner2Mapping
+	mapping.StatusVocabulary = map[string]string{"reservado": "reserved"}
+	partner, err := NewHTTPPartner(server.URL, mapping, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := &ReservationRequest{EventID: "event-1", Spots: []string{"A1", "A2"}}
+
+	got, err := partner.MakeReservation(context.Background(), req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Status sem tradução são repassados como vieram.
+	if got[0].Status != "reserved" || got[1].Status != "pendente" {
+		t.Errorf("statuses = %q, %q, want reserved, pendente", got[0].Status, got[1].Status)
+	}
+
+	// A reserva exige exatamente o código configurado, mesmo que seja outro 2xx.
+	status = http.StatusOK
+	_, err = partner.MakeReservation(context.Background(), req)
+	var statusErr *PartnerStatusError
+	if !errors.As(err, &statusErr) || statusErr.StatusCode != http.StatusOK {
+		t.Errorf("MakeReservation error = %v, want PartnerStatusError 200", err)
+	}
+}
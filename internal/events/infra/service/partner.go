@@ -1,5 +1,7 @@
 package service
 
+import "context"
+
 type ReservationRequest struct {
 	EventID    string   `json:"event_id"`
 	Spots      []string `json:"spots"`
@@ -17,6 +19,16 @@ type ReservationResponse struct {
 	EventID    string `json:"event_id"`
 }
 
+// CancelReservationRequest identifica as reservas que devem ser desfeitas no parceiro.
+type CancelReservationRequest struct {
+	EventID        string   `json:"event_id"`
+	Spots          []string `json:"spots"`
+	ReservationIDs []string `json:"reservation_ids"`
+	Email          string   `json:"email"`
+}
+
 type Partner interface {
-	MakeReservation(req *ReservationRequest) ([]ReservationResponse, error)
+	MakeReservation(ctx context.Context, req *ReservationRequest) ([]ReservationResponse, error)
+	// CancelReservation desfaz uma reserva feita por MakeReservation (compensação da saga de compra).
+	CancelReservation(ctx context.Context, req *CancelReservationRequest) error
 }
@@ -1,6 +1,9 @@
 package service
 
-import "fmt"
+import (
+	"fmt"
+	"sort"
+)
 
 // PartnerFactory é uma interface que define o método para criar um parceiro.
 type PartnerFactory interface {
@@ -8,35 +11,53 @@ type PartnerFactory interface {
 	CreatePartner(partnerID int) (Partner, error)
 }
 
+// BreakerReporter expõe o estado dos circuit breakers dos parceiros.
+type BreakerReporter interface {
+	Breakers() []BreakerSnapshot
+}
+
 // DefaultPartnerFactory é a implementação padrão da interface PartnerFactory.
 type DefaultPartnerFactory struct {
-	partnerBaseURLs map[int]string // Map de IDs de parceiros para suas URLs base.
+	partners map[int]*ResilientPartner // Map de IDs de parceiros para seus adaptadores.
 }
 
 // NewPartnerFactory cria uma nova instância de DefaultPartnerFactory.
-// Recebe um mapa de IDs de parceiros para URLs base e retorna uma interface PartnerFactory.
-func NewPartnerFactory(partnerBaseURLs map[int]string) PartnerFactory {
-	// Retorna a fábrica de parceiros com o mapa de URLs base inicializado.
-	return &DefaultPartnerFactory{partnerBaseURLs: partnerBaseURLs}
+// Os adaptadores são criados a partir do registro já na inicialização, de modo que um kind
+// não registrado ou um mapeamento inválido na configuração falhe imediatamente.
+// Cada adaptador é envolvido por um ResilientPartner com a política de repetições e o circuit breaker do parceiro.
+func NewPartnerFactory(registry *PartnerRegistry, partners []PartnerConfig) (*DefaultPartnerFactory, error) {
+	adapters := make(map[int]*ResilientPartner, len(partners))
+	for _, cfg := range partners {
+		if !registry.Has(cfg.Kind) {
+			return nil, fmt.Errorf("partner with ID %d: kind %q is not registered (available: %v)", cfg.ID, cfg.Kind, registry.Kinds())
+		}
+		partner, err := registry.New(cfg)
+		if err != nil {
+			return nil, err
+		}
+		adapters[cfg.ID] = NewResilientPartner(cfg.ID, partner, cfg.Retry, cfg.CircuitBreaker)
+	}
+
+	return &DefaultPartnerFactory{partners: adapters}, nil
 }
 
-// CreatePartner cria um parceiro com base no partnerID fornecido.
+// CreatePartner retorna o adaptador configurado para o partnerID fornecido.
 // Retorna um erro se o parceiro não for encontrado.
 func (f *DefaultPartnerFactory) CreatePartner(partnerID int) (Partner, error) {
-	// Busca a URL base do parceiro a partir do partnerID.
-	BaseURL, ok := f.partnerBaseURLs[partnerID]
+	partner, ok := f.partners[partnerID]
 	if !ok {
 		return nil, fmt.Errorf("partner with ID %d not found", partnerID)
 	}
 
-	// Cria e retorna a instância do parceiro apropriado com base no partnerID.
-	// Adicione casos adicionais conforme novos parceiros forem introduzidos.
-	switch partnerID {
-	case 1:
-		return &Partner1{BaseURL: BaseURL}, nil
-	case 2:
-		return &Partner1{BaseURL: BaseURL}, nil
-	default:
-		return nil, fmt.Errorf("partner with ID %d not found", partnerID)
+	return partner, nil
+}
+
+// Breakers retorna o estado do circuit breaker de cada parceiro, ordenado pelo ID.
+func (f *DefaultPartnerFactory) Breakers() []BreakerSnapshot {
+	breakers := make([]BreakerSnapshot, 0, len(f.partners))
+	for _, partner := range f.partners {
+		breakers = append(breakers, partner.Breaker())
 	}
+	sort.Slice(breakers, func(i, j int) bool { return breakers[i].PartnerID < breakers[j].PartnerID })
+	return breakers
 }
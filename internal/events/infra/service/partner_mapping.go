@@ -0,0 +1,126 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// PartnerMapping descreve declarativamente como falar com a API de reservas de um parceiro:
+// as rotas, os nomes dos campos de requisição e resposta, os códigos de sucesso e o vocabulário de status.
+// Com ela, a maioria dos parceiros é integrada pelo HTTPPartner sem código Go específico.
+type PartnerMapping struct {
+	Reserve          EndpointMapping      `yaml:"reserve"`
+	Cancel           EndpointMapping      `yaml:"cancel"`
+	ReserveRequest   ReserveRequestFields `yaml:"reserve_request"`
+	CancelRequest    CancelRequestFields  `yaml:"cancel_request"`
+	Response         ResponseFields       `yaml:"response"`
+	StatusVocabulary map[string]string    `yaml:"status_vocabulary"` // Status do parceiro -> status usado internamente. Valores ausentes são repassados como vieram.
+}
+
+// EndpointMapping descreve uma rota do parceiro.
+type EndpointMapping struct {
+	Method        string `yaml:"method"`         // Método HTTP. Padrão: POST.
+	Path          string `yaml:"path"`           // Caminho relativo à URL base; {event_id} é substituído pelo ID do evento.
+	SuccessStatus int    `yaml:"success_status"` // Código esperado em caso de sucesso. Zero aceita qualquer 2xx.
+}
+
+// ReserveRequestFields nomeia os campos JSON enviados na reserva. Campos vazios não são enviados.
+type ReserveRequestFields struct {
+	Spots      string `yaml:"spots"`
+	TicketKind string `yaml:"ticket_kind"`
+	Email      string `yaml:"email"`
+}
+
+// CancelRequestFields nomeia os campos JSON enviados no cancelamento. Campos vazios não são enviados.
+type CancelRequestFields struct {
+	Spots          string `yaml:"spots"`
+	ReservationIDs string `yaml:"reservation_ids"`
+	Email          string `yaml:"email"`
+}
+
+// ResponseFields nomeia os campos JSON de cada item da resposta de reserva (um por spot).
+type ResponseFields struct {
+	ID         string `yaml:"id"`
+	Email      string `yaml:"email"`
+	Spot       string `yaml:"spot"`
+	TicketKind string `yaml:"ticket_kind"`
+	Status     string `yaml:"status"`
+	EventID    string `yaml:"event_id"`
+}
+
+// Partner1Mapping expressa a API do Partner1 (campos em inglês).
+var Partner1Mapping = PartnerMapping{
+	Reserve: EndpointMapping{Method: http.MethodPost, Path: "/events/{event_id}/reserve", SuccessStatus: http.StatusCreated},
+	Cancel:  EndpointMapping{Method: http.MethodPost, Path: "/events/{event_id}/cancel"},
+	ReserveRequest: ReserveRequestFields{
+		Spots:      "spots",
+		TicketKind: "ticket_kind",
+		Email:      "email",
+	},
+	CancelRequest: CancelRequestFields{
+		Spots:          "spots",
+		ReservationIDs: "ids",
+		Email:          "email",
+	},
+	Response: ResponseFields{
+		ID:         "id",
+		Email:      "email",
+		Spot:       "spot",
+		TicketKind: "ticket_kind",
+		Status:     "status",
+		EventID:    "event_id",
+	},
+}
+
+// Partner2Mapping expressa a API do Partner2 (campos em português).
+var Partner2Mapping = PartnerMapping{
+	Reserve: EndpointMapping{Method: http.MethodPost, Path: "/eventos/{event_id}/reservar", SuccessStatus: http.StatusCreated},
+	Cancel:  EndpointMapping{Method: http.MethodPost, Path: "/eventos/{event_id}/cancelar"},
+	ReserveRequest: ReserveRequestFields{
+		Spots:      "lugares",
+		TicketKind: "tipo_ingresso",
+		Email:      "email",
+	},
+	CancelRequest: CancelRequestFields{
+		Spots:          "lugares",
+		ReservationIDs: "reservas",
+		Email:          "email",
+	},
+	Response: ResponseFields{
+		ID:         "id",
+		Email:      "email",
+		Spot:       "lugar",
+		TicketKind: "tipo_ingresso",
+		Status:     "estado",
+		EventID:    "evento_id",
+	},
+}
+
+// Validate verifica se o mapeamento tem o mínimo para reservar e cancelar.
+func (m PartnerMapping) Validate() error {
+	var errs []error
+
+	endpoints := []struct {
+		name     string
+		endpoint EndpointMapping
+	}{{"reserve", m.Reserve}, {"cancel", m.Cancel}}
+	for _, e := range endpoints {
+		name, endpoint := e.name, e.endpoint
+		if endpoint.Path == "" || !strings.HasPrefix(endpoint.Path, "/") {
+			errs = append(errs, fmt.Errorf("mapping.%s.path must start with /", name))
+		}
+		if endpoint.SuccessStatus != 0 && (endpoint.SuccessStatus < 200 || endpoint.SuccessStatus > 299) {
+			errs = append(errs, fmt.Errorf("mapping.%s.success_status must be a 2xx code", name))
+		}
+	}
+	if m.ReserveRequest.Spots == "" {
+		errs = append(errs, errors.New("mapping.reserve_request.spots is required"))
+	}
+	if m.Response.Spot == "" {
+		errs = append(errs, errors.New("mapping.response.spot is required"))
+	}
+
+	return errors.Join(errs...)
+}
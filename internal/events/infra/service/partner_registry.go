@@ -0,0 +1,96 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// PartnerConfig descreve um parceiro configurado: seu ID, o tipo de adaptador, a URL base da API,
+// o prazo de cada chamada, a política de repetições, o circuit breaker e, opcionalmente,
+// o mapeamento declarativo usado pelo adaptador genérico HTTP.
+type PartnerConfig struct {
+	ID             int                  `yaml:"id"`
+	Kind           string               `yaml:"kind"`
+	BaseURL        string               `yaml:"base_url"`
+	Timeout        time.Duration        `yaml:"timeout"`
+	Retry          RetryPolicy          `yaml:"retry"`
+	CircuitBreaker CircuitBreakerConfig `yaml:"circuit_breaker"`
+	Mapping        *PartnerMapping      `yaml:"mapping"`
+}
+
+// PartnerConstructor cria a instância de um adaptador de parceiro a partir da sua configuração.
+type PartnerConstructor func(cfg PartnerConfig) (Partner, error)
+
+// PartnerRegistry associa tipos de adaptador (kind) aos seus construtores.
+// Para integrar um novo parceiro basta registrar o adaptador e apontar o ID do parceiro para o kind na configuração.
+type PartnerRegistry struct {
+	mu           sync.RWMutex
+	constructors map[string]PartnerConstructor
+}
+
+// NewPartnerRegistry cria um registro vazio.
+func NewPartnerRegistry() *PartnerRegistry {
+	return &PartnerRegistry{constructors: make(map[string]PartnerConstructor)}
+}
+
+// DefaultPartnerRegistry é o registro onde os adaptadores deste pacote se registram na inicialização.
+var DefaultPartnerRegistry = NewPartnerRegistry()
+
+// RegisterPartner registra um adaptador no DefaultPartnerRegistry.
+func RegisterPartner(kind string, constructor PartnerConstructor) {
+	DefaultPartnerRegistry.Register(kind, constructor)
+}
+
+// Register associa kind ao construtor. Entra em pânico se o kind for vazio, o construtor for nil
+// ou o kind já estiver registrado, pois isso indica um erro de programação.
+func (r *PartnerRegistry) Register(kind string, constructor PartnerConstructor) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if kind == "" {
+		panic("service: RegisterPartner kind is empty")
+	}
+	if constructor == nil {
+		panic("service: RegisterPartner constructor is nil for kind " + kind)
+	}
+	if _, dup := r.constructors[kind]; dup {
+		panic("service: RegisterPartner called twice for kind " + kind)
+	}
+	r.constructors[kind] = constructor
+}
+
+// New cria o adaptador do kind configurado em cfg.
+func (r *PartnerRegistry) New(cfg PartnerConfig) (Partner, error) {
+	r.mu.RLock()
+	constructor, ok := r.constructors[cfg.Kind]
+	r.mu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("partner kind %q is not registered (partner ID %d)", cfg.Kind, cfg.ID)
+	}
+	return constructor(cfg)
+}
+
+// Kinds retorna os tipos de adaptador registrados, em ordem alfabética.
+func (r *PartnerRegistry) Kinds() []string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	kinds := make([]string, 0, len(r.constructors))
+	for kind := range r.constructors {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// Has indica se kind está registrado.
+func (r *PartnerRegistry) Has(kind string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	_, ok := r.constructors[kind]
+	return ok
+}
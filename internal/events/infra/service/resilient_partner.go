@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy define quantas vezes e com que intervalo uma chamada ao parceiro é repetida.
+type RetryPolicy struct {
+	MaxAttempts int           `yaml:"max_attempts"` // Total de tentativas, incluindo a primeira. 1 desativa as repetições.
+	BaseDelay   time.Duration `yaml:"base_delay"`   // Espera antes da segunda tentativa; dobra a cada nova tentativa.
+	MaxDelay    time.Duration `yaml:"max_delay"`    // Limite da espera entre tentativas.
+}
+
+// DefaultRetryPolicy é usada nos campos não configurados de RetryPolicy.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	BaseDelay:   100 * time.Millisecond,
+	MaxDelay:    2 * time.Second,
+}
+
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = DefaultRetryPolicy.MaxAttempts
+	}
+	if p.BaseDelay <= 0 {
+		p.BaseDelay = DefaultRetryPolicy.BaseDelay
+	}
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = DefaultRetryPolicy.MaxDelay
+	}
+	return p
+}
+
+// backoff retorna a espera após a tentativa attempt: exponencial, limitada a MaxDelay,
+// com jitter para que várias requisições não voltem ao parceiro ao mesmo tempo.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay
+	for i := 1; i < attempt && delay < p.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// ResilientPartner envolve um Partner com repetições e um circuit breaker.
+//
+// Só são repetidas as falhas em que é seguro tentar de novo: para MakeReservation, que não é
+// idempotente, apenas quando o parceiro certamente não processou a requisição (não enviada, falha
+// ao conectar ou 429); para CancelReservation, que é idempotente, também timeouts e erros 5xx.
+// Um 503 não garante que a reserva não foi feita: repeti-lo poderia trocar essa falha ambígua por
+// uma recusa 4xx da nova tentativa, e a compra terminaria sem compensar a primeira reserva.
+type ResilientPartner struct {
+	partnerID int
+	next      Partner
+	retry     RetryPolicy
+	breaker   *CircuitBreaker
+}
+
+// NewResilientPartner cria o wrapper do parceiro partnerID. Campos zerados das configurações usam os padrões.
+func NewResilientPartner(partnerID int, next Partner, retry RetryPolicy, breaker CircuitBreakerConfig) *ResilientPartner {
+	return &ResilientPartner{
+		partnerID: partnerID,
+		next:      next,
+		retry:     retry.withDefaults(),
+		breaker:   NewCircuitBreaker(partnerID, breaker),
+	}
+}
+
+// Breaker retorna o estado atual do circuit breaker do parceiro.
+func (p *ResilientPartner) Breaker() BreakerSnapshot {
+	return p.breaker.Snapshot()
+}
+
+func (p *ResilientPartner) MakeReservation(ctx context.Context, req *ReservationRequest) ([]ReservationResponse, error) {
+	var responses []ReservationResponse
+	err := p.call(ctx, false, func() error {
+		var err error
+		responses, err = p.next.MakeReservation(ctx, req)
+		return err
+	})
+	return responses, err
+}
+
+func (p *ResilientPartner) CancelReservation(ctx context.Context, req *CancelReservationRequest) error {
+	return p.call(ctx, true, func() error {
+		return p.next.CancelReservation(ctx, req)
+	})
+}
+
+// call executa fn respeitando o circuit breaker e a política de repetições.
+func (p *ResilientPartner) call(ctx context.Context, idempotent bool, fn func() error) error {
+	var err error
+	for attempt := 1; ; attempt++ {
+		if openErr := p.breaker.allow(); openErr != nil {
+			// Se o circuito abriu durante as repetições, o erro relevante é o da última tentativa.
+			if err != nil {
+				return err
+			}
+			return openErr
+		}
+
+		err = fn()
+		p.breaker.record(classifyOutcome(ctx, err))
+
+		if err == nil || attempt >= p.retry.MaxAttempts || !isRetryable(ctx, err, idempotent) {
+			return err
+		}
+
+		timer := time.NewTimer(p.retry.backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return err
+		case <-timer.C:
+		}
+	}
+}
+
+// classifyOutcome decide como o resultado de uma chamada conta para o circuit breaker.
+// Respostas de negócio (4xx) mostram que o parceiro está saudável; cancelamentos do chamador não dizem nada sobre ele.
+func classifyOutcome(ctx context.Context, err error) callOutcome {
+	if err == nil {
+		return outcomeSuccess
+	}
+	if ctx.Err() != nil {
+		return outcomeIgnored
+	}
+	var statusErr *PartnerStatusError
+	if errors.As(err, &statusErr) && statusErr.StatusCode < 500 && statusErr.StatusCode != http.StatusTooManyRequests {
+		return outcomeSuccess
+	}
+	return outcomeFailure
+}
+
+// isRetryable indica se é seguro repetir a chamada que falhou com err.
+func isRetryable(ctx context.Context, err error, idempotent bool) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var statusErr *PartnerStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == http.StatusTooManyRequests || (idempotent && statusErr.StatusCode >= 500)
+	}
+
+	// Não enviada ou falha ao conectar: a requisição nunca chegou ao parceiro.
+	var opErr *net.OpError
+	if errors.Is(err, ErrRequestNotSent) || (errors.As(err, &opErr) && opErr.Op == "dial") {
+		return true
+	}
+
+	if idempotent {
+		var netErr net.Error
+		return errors.As(err, &netErr) || errors.Is(err, context.DeadlineExceeded)
+	}
+	return false
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// scriptedPartner devolve, a cada chamada, o próximo erro de errs; depois do último, sucesso.
+type scriptedPartner struct {
+	errs  []error
+	calls int
+}
+
+func (p *scriptedPartner) next() error {
+	p.calls++
+	if p.calls <= len(p.errs) {
+		return p.errs[p.calls-1]
+	}
+	return nil
+}
+
+func (p *scriptedPartner) MakeReservation(ctx context.Context, req *ReservationRequest) ([]ReservationResponse, error) {
+	if err := p.next(); err != nil {
+		return nil, err
+	}
+	return []ReservationResponse{{Spot: "A1"}}, nil
+}
+
+func (p *scriptedPartner) CancelReservation(ctx context.Context, req *CancelReservationRequest) error {
+	return p.next()
+}
+
+var (
+	errDial      = &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	errReadReset = &net.OpError{Op: "read", Net: "tcp", Err: errors.New("connection reset by peer")}
+)
+
+func errStatus(code int) error {
+	return &PartnerStatusError{StatusCode: code}
+}
+
+// fastRetry repete sem esperas perceptíveis, mantendo o circuito fechado durante os testes de repetição.
+var fastRetry = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Microsecond, MaxDelay: time.Microsecond}
+
+func TestResilientPartnerRetries(t *testing.T) {
+	tests := []struct {
+		name      string
+		cancel    bool // testa CancelReservation (idempotente) em vez de MakeReservation
+		errs      []error
+		wantCalls int
+		wantErr   bool
+	}{
+		{name: "success", errs: nil, wantCalls: 1},
+		{name: "reserve retries a dial failure", errs: []error{errDial}, wantCalls: 2},
+		{name: "reserve retries a request that was not sent", errs: []error{fmt.Errorf("%w: %w", ErrRequestNotSent, errDial)}, wantCalls: 2},
+		{name: "reserve retries 429", errs: []error{errStatus(http.StatusTooManyRequests), errStatus(http.StatusTooManyRequests)}, wantCalls: 3},
+		{name: "reserve does not retry 500", errs: []error{errStatus(http.StatusInternalServerError)}, wantCalls: 1, wantErr: true},
+		// O parceiro pode ter reservado antes de responder 503; uma nova tentativa recusada esconderia a reserva.
+		{name: "reserve does not retry 503", errs: []error{errStatus(http.StatusServiceUnavailable), errStatus(http.StatusConflict)}, wantCalls: 1, wantErr: true},
+		{name: "reserve does not retry a rejection", errs: []error{errStatus(http.StatusConflict)}, wantCalls: 1, wantErr: true},
+		{name: "reserve does not retry a broken connection", errs: []error{errReadReset}, wantCalls: 1, wantErr: true},
+		{name: "reserve does not retry a timeout", errs: []error{context.DeadlineExceeded}, wantCalls: 1, wantErr: true},
+		{name: "cancel retries 500", cancel: true, errs: []error{errStatus(http.StatusBadGateway)}, wantCalls: 2},
+		{name: "cancel retries 503", cancel: true, errs: []error{errStatus(http.StatusServiceUnavailable)}, wantCalls: 2},
+		{name: "cancel retries a broken connection and a timeout", cancel: true, errs: []error{errReadReset, context.DeadlineExceeded}, wantCalls: 3},
+		{name: "cancel does not retry a rejection", cancel: true, errs: []error{errStatus(http.StatusNotFound)}, wantCalls: 1, wantErr: true},
+		{name: "attempts are capped", errs: []error{errDial, errDial, errDial, errDial}, wantCalls: 3, wantErr: true},
+		{name: "cancel attempts are capped", cancel: true, errs: []error{errReadReset, errReadReset, errReadReset, errReadReset}, wantCalls: 3, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := &scriptedPartner{errs: tt.errs}
+			partner := NewResilientPartner(1, next, fastRetry, CircuitBreakerConfig{FailureThreshold: 10})
+
+			var err error
+			if tt.cancel {
+				err = partner.CancelReservation(context.Background(), &CancelReservationRequest{})
+			} else {
+				_, err = partner.MakeReservation(context.Background(), &ReservationRequest{})
+			}
+			if next.calls != tt.wantCalls {
+				t.Errorf("calls = %d, want %d", next.calls, tt.wantCalls)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Errorf("error = %v, want error %t", err, tt.wantErr)
+			}
+			// O erro devolvido é sempre o da última tentativa.
+			if tt.wantErr && !errors.Is(err, tt.errs[next.calls-1]) {
+				t.Errorf("error = %v, want %v", err, tt.errs[next.calls-1])
+			}
+		})
+	}
+}
+
+func TestResilientPartnerContextCancellationStopsBackoff(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	next := &scriptedPartner{errs: []error{errDial, errDial}}
+	partner := NewResilientPartner(1, next, RetryPolicy{MaxAttempts: 3, BaseDelay: time.Hour, MaxDelay: time.Hour}, CircuitBreakerConfig{})
+
+	time.AfterFunc(10*time.Millisecond, cancel)
+	done := make(chan error, 1)
+	go func() {
+		_, err := partner.MakeReservation(ctx, &ReservationRequest{})
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, errDial) {
+			t.Errorf("error = %v, want the last attempt's error", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("MakeReservation kept waiting for the backoff after the context was cancelled")
+	}
+	if next.calls != 1 {
+		t.Errorf("calls = %d, want 1", next.calls)
+	}
+}
+
+func TestResilientPartnerStopsRetryingWhenTheCircuitOpens(t *testing.T) {
+	next := &scriptedPartner{errs: []error{errDial, errDial, errDial}}
+	partner := NewResilientPartner(1, next, fastRetry, CircuitBreakerConfig{FailureThreshold: 2, OpenTimeout: time.Hour})
+
+	_, err := partner.MakeReservation(context.Background(), &ReservationRequest{})
+	if next.calls != 2 {
+		t.Errorf("calls = %d, want 2 (the circuit opens after the second failure)", next.calls)
+	}
+	if !errors.Is(err, errDial) {
+		t.Errorf("error = %v, want the last attempt's error", err)
+	}
+
+	_, err = partner.MakeReservation(context.Background(), &ReservationRequest{})
+	if !errors.Is(err, ErrPartnerCircuitOpen) || !IsReservationRejected(err) {
+		t.Errorf("error with the circuit open = %v, want ErrPartnerCircuitOpen (a definite rejection)", err)
+	}
+	if next.calls != 2 {
+		t.Errorf("calls with the circuit open = %d, want 2", next.calls)
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: 300 * time.Millisecond}.withDefaults()
+	tests := []struct {
+		attempt int
+		max     time.Duration // sem jitter; o jitter mantém a espera entre max/2 e max
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 300 * time.Millisecond},
+		{10, 300 * time.Millisecond},
+	}
+	for _, tt := range tests {
+		for i := 0; i < 100; i++ {
+			if got := policy.backoff(tt.attempt); got < tt.max/2 || got > tt.max {
+				t.Fatalf("backoff(%d) = %s, want between %s and %s", tt.attempt, got, tt.max/2, tt.max)
+			}
+		}
+	}
+}
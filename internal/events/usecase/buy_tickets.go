@@ -1,7 +1,13 @@
 package usecase
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log"
+	"slices"
+	"time"
 
 	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
 	"github.com/Eddiesantle/golang-inbound-selling/internal/events/infra/service"
@@ -13,43 +19,157 @@ type BuyTicketsInputDTO struct {
 	TicketKind string   `json:"ticket_kind"`
 	CardHash   string   `json:"card_hash"`
 	Email      string   `json:"email"`
+	SessionID  string   `json:"session_id"`
+	// EligibilityDocument é o número do comprovante exigido por tipos como meia-entrada de estudante.
+	EligibilityDocument string `json:"eligibility_document"`
+	// CouponCodes são os cupons aplicados a todos os ingressos da compra; maiúsculas e minúsculas são equivalentes.
+	CouponCodes []string `json:"coupon_codes"`
+	// IdempotencyKey vem do cabeçalho Idempotency-Key; não faz parte da impressão digital da requisição.
+	IdempotencyKey string `json:"-"`
+}
+
+// maxSpotsPerCheckout limita quantos spots podem ser comprados em uma única requisição.
+const maxSpotsPerCheckout = 20
+
+// maxCouponsPerCheckout limita quantos cupons podem ser combinados em uma compra.
+const maxCouponsPerCheckout = 3
+
+// Validate verifica todos os campos da entrada e retorna domain.ValidationErrors com os problemas encontrados.
+func (input BuyTicketsInputDTO) Validate() error {
+	var v validator
+	v.required(input.EventID, "event_id")
+	v.uniqueNames(input.Spots, maxSpotsPerCheckout, "spots")
+	if v.required(input.TicketKind, "ticket_kind") {
+		v.check(domain.TicketKind(input.TicketKind).IsValid(), "ticket_kind", domain.FieldInvalidFormat, "ticket_kind must be lowercase letters, digits or _ with at most 20 characters")
+	}
+	v.maxLength(input.EligibilityDocument, 100, "eligibility_document")
+	v.check(len(input.CouponCodes) <= maxCouponsPerCheckout, "coupon_codes", domain.FieldOutOfRange, "coupon_codes must have at most %d items", maxCouponsPerCheckout)
+	codes := make(map[string]bool, len(input.CouponCodes))
+	for i, code := range input.CouponCodes {
+		field := fmt.Sprintf("coupon_codes[%d]", i)
+		code = domain.NormalizeCouponCode(code)
+		if v.required(code, field) {
+			v.check(domain.IsValidCouponCode(code), field, domain.FieldInvalidFormat, "%s must have 3 to 32 letters, digits, _ or -", field)
+			v.check(!codes[code], field, domain.FieldDuplicate, "coupon_codes %q is duplicated", code)
+			codes[code] = true
+		}
+	}
+	v.required(input.CardHash, "card_hash")
+	v.email(input.Email, "email")
+	v.required(input.SessionID, "session_id")
+	v.maxLength(input.IdempotencyKey, domain.MaxIdempotencyKeyLength, "Idempotency-Key")
+	return v.err()
+}
+
+// couponCodes retorna os códigos normalizados, em ordem alfabética: os cupons são bloqueados
+// sempre na mesma ordem, evitando deadlocks entre compras concorrentes.
+func (input BuyTicketsInputDTO) couponCodes() []string {
+	codes := make([]string, len(input.CouponCodes))
+	for i, code := range input.CouponCodes {
+		codes[i] = domain.NormalizeCouponCode(code)
+	}
+	slices.Sort(codes)
+	return codes
 }
 
 type BuyTicketsOutputDTO struct {
 	Tickets []TicketDTO `json:"tickets"`
+	// Replayed indica que a resposta é a de uma requisição anterior com a mesma Idempotency-Key.
+	Replayed bool `json:"-"`
 }
 
+// TicketDTO traz o preço final do ingresso, já com os descontos do tipo de ingresso e dos cupons;
+// coupon_discount é a parte do desconto que veio dos cupons.
 type TicketDTO struct {
-	ID         string  `json:"id"`
-	SpotID     string  `json:"spot_id"`
-	TicketKind string  `json:"ticket_kind"`
-	Price      float64 `json:"price"`
+	ID             string      `json:"id"`
+	SpotID         string      `json:"spot_id"`
+	TicketKind     string      `json:"ticket_kind"`
+	Price          json.Number `json:"price" swaggertype:"number" example:"50.00"`
+	Currency       string      `json:"currency" example:"BRL"`
+	CouponDiscount json.Number `json:"coupon_discount" swaggertype:"number" example:"0.00"`
+	Coupons        []string    `json:"coupons,omitempty"`
 }
 
 type BuyTicketsUseCase struct {
-	repo           domain.EventRepository
+	repo           domain.Repositories
 	partnerFactory service.PartnerFactory
 }
 
-func NewBuyTicketsUseCase(repo domain.EventRepository, partnerFactory service.PartnerFactory) *BuyTicketsUseCase {
+func NewBuyTicketsUseCase(repo domain.Repositories, partnerFactory service.PartnerFactory) *BuyTicketsUseCase {
 	return &BuyTicketsUseCase{
 		repo:           repo,
 		partnerFactory: partnerFactory,
 	}
 }
 
-func (uc *BuyTicketsUseCase) Execute(input BuyTicketsInputDTO) (*BuyTicketsOutputDTO, error) {
+// Execute compra os ingressos. Com uma IdempotencyKey, repetições da mesma requisição
+// devolvem a resposta original em vez de comprar novamente.
+func (uc *BuyTicketsUseCase) Execute(ctx context.Context, input BuyTicketsInputDTO) (*BuyTicketsOutputDTO, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	if input.IdempotencyKey == "" {
+		return uc.buy(ctx, input)
+	}
 
-	
+	output, replayed, err := runIdempotent(ctx, uc.repo, input.IdempotencyKey, input, func() (*BuyTicketsOutputDTO, error) {
+		return uc.buy(ctx, input)
+	})
+	if err != nil {
+		return nil, err
+	}
+	output.Replayed = replayed
+	return output, nil
+}
+
+func (uc *BuyTicketsUseCase) buy(ctx context.Context, input BuyTicketsInputDTO) (*BuyTicketsOutputDTO, error) {
 
 	// Verifica o evento
-	event, err := uc.repo.FindEventByID(input.EventID)
+	event, err := uc.repo.FindEventByID(ctx, input.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !event.IsOnSale() {
+		return nil, domain.ErrEventNotOnSale
+	}
+	if err := event.CheckCapacity(len(input.Spots)); err != nil {
+		return nil, err
+	}
+
+	// O tipo de ingresso precisa ser oferecido pelo evento, com comprovante e cota quando exigidos.
+	ticketKind := domain.TicketKind(input.TicketKind)
+	rule, err := event.TicketKindRule(ticketKind)
 	if err != nil {
 		return nil, err
 	}
+	if err := rule.CheckEligibility(input.EligibilityDocument, len(input.Spots)); err != nil {
+		return nil, err
+	}
+	if err := event.CheckTicketKindQuota(rule, len(input.Spots)); err != nil {
+		return nil, err
+	}
+
+	// Só aceita spots segurados pela sessão, do mesmo usuário, que está comprando
+	holdOwner := domain.SpotHoldOwner(ctx, input.SessionID)
+	now := time.Now()
+	spots := make([]*domain.Spot, len(input.Spots))
+	for i, name := range input.Spots {
+		spot, err := uc.repo.FindSpotByName(ctx, event.ID, name)
+		if err != nil {
+			return nil, err
+		}
+		if !spot.IsHeldBy(holdOwner, now) {
+			return nil, domain.ErrSpotNotHeld
+		}
+		spots[i] = spot
+	}
 
-	//? na requisição eventID: 0853e59-dc5b-4d7b-a028-01513ef50d76 esta sendo encontrado
-	fmt.Println("req -- event:", event)
+	// Confere os cupons antes de reservar no parceiro; a transação os confere de novo, bloqueados.
+	if _, err := findCoupons(ctx, uc.repo, event, rule, spots, input); err != nil {
+		return nil, err
+	}
 
 	// Cria a solicitação de reserva
 	req := &service.ReservationRequest{
@@ -66,50 +186,196 @@ func (uc *BuyTicketsUseCase) Execute(input BuyTicketsInputDTO) (*BuyTicketsOutpu
 		return nil, err
 	}
 
+	// Registra a saga antes de falar com o parceiro, para que uma reserva
+	// sem ingressos locais possa ser compensada mesmo após um restart.
+	saga := domain.NewCheckoutSaga(event, input.Spots, input.TicketKind, input.Email)
+	if err := uc.repo.CreateCheckoutSaga(ctx, saga); err != nil {
+		return nil, err
+	}
+
 	// Reserva os lugares usando o serviço do parceiro
-	reservationResponse, err := partnerService.MakeReservation(req)
-	
+	reservationResponse, err := partnerService.MakeReservation(ctx, req)
+
+	// A partir daqui a reserva pode existir no parceiro: o restante do fluxo não é
+	// interrompido se o cliente desconectar, para não deixar a saga pela metade.
+	ctx = context.WithoutCancel(ctx)
 
 	if err != nil {
+		// Só uma recusa certa do parceiro encerra a saga sem compensar. Em qualquer outra falha
+		// (timeout, 5xx, conexão interrompida, resposta ilegível) o parceiro pode ter reservado os lugares.
+		if !service.IsReservationRejected(err) {
+			compensateCheckout(ctx, uc.repo, partnerService, saga, err)
+			return nil, partnerError(err)
+		}
+
+		saga.MarkFailed(err)
+		if updateErr := uc.repo.UpdateCheckoutSaga(ctx, saga); updateErr != nil {
+			log.Printf("checkout saga %s: erro ao registrar falha: %v", saga.ID, updateErr)
+		}
+		return nil, partnerError(err)
+	}
+
+	reservationIDs := make([]string, len(reservationResponse))
+	for i, reservation := range reservationResponse {
+		reservationIDs[i] = reservation.ID
+	}
+	saga.MarkReserved(reservationIDs)
+	if err := uc.repo.UpdateCheckoutSaga(ctx, saga); err != nil {
+		compensateCheckout(ctx, uc.repo, partnerService, saga, err)
 		return nil, err
 	}
 
-	// Salva os ingressos no banco de dados
+	// Salva os ingressos no banco de dados em uma única transação:
+	// ou todos os ingressos e spots são persistidos, ou nenhum.
 	tickets := make([]domain.Ticket, len(reservationResponse))
-	for i, reservation := range reservationResponse {
-		spot, err := uc.repo.FindSpotByName(event.ID, reservation.Spot)
+	err = uc.repo.RunInTx(ctx, func(repo domain.Repositories) error {
+		// O evento pode ter sido cancelado ou adiado durante a chamada ao parceiro.
+		status, err := repo.LockEventStatus(ctx, event.ID)
 		if err != nil {
-			return nil, err
+			return err
+		}
+		if status != domain.EventStatusSalesOpen {
+			return domain.ErrEventNotOnSale
 		}
 
-		ticket, err := domain.NewTicket(event, spot, domain.TicketKind(input.TicketKind))
+		// Com o evento bloqueado, confere a capacidade e a cota do tipo com os ingressos vendidos até agora.
+		current, err := repo.FindEventByID(ctx, event.ID)
 		if err != nil {
-			return nil, err
+			return err
+		}
+		if err := current.CheckCapacity(len(reservationResponse)); err != nil {
+			return err
+		}
+		currentRule, err := current.TicketKindRule(ticketKind)
+		if err != nil {
+			return err
+		}
+		if err := current.CheckTicketKindQuota(currentRule, len(reservationResponse)); err != nil {
+			return err
 		}
 
-		err = uc.repo.CreateTicket(ticket)
+		spots := make([]*domain.Spot, len(reservationResponse))
+		for i, reservation := range reservationResponse {
+			spot, err := repo.FindSpotByName(ctx, event.ID, reservation.Spot)
+			if err != nil {
+				return err
+			}
+
+			// A retenção pode ter expirado durante a chamada ao parceiro e sido tomada por outra sessão
+			if spot.IsHeld(time.Now()) && spot.HoldOwner != holdOwner {
+				return domain.ErrSpotHeld
+			}
+			spots[i] = spot
+		}
+		coupons, err := findCoupons(ctx, repo, current, currentRule, spots, input)
 		if err != nil {
-			return nil, err
+			return err
+		}
+
+		for i, spot := range spots {
+			ticket, err := domain.NewTicket(current, spot, ticketKind, input.EligibilityDocument)
+			if err != nil {
+				return err
+			}
+			discounts := ticket.ApplyCoupons(coupons)
+
+			if err := repo.CreateTicket(ctx, ticket); err != nil {
+				return err
+			}
+			for j, coupon := range coupons {
+				redemption := domain.NewCouponRedemption(coupon, saga.ID, ticket, input.Email, discounts[j])
+				if err := repo.CreateCouponRedemption(ctx, redemption); err != nil {
+					return err
+				}
+			}
+
+			if err := spot.Reserve(ticket.ID); err != nil {
+				return err
+			}
+			if err := repo.ReserveSpot(ctx, spot); err != nil {
+				return err
+			}
+
+			tickets[i] = *ticket
 		}
 
-		spot.Reserve(ticket.ID)
-		err = uc.repo.ReserveSpot(spot.ID, ticket.ID)
+		// Sem spots restantes o evento passa automaticamente para sold_out.
+		unsold, err := repo.CountUnsoldSpots(ctx, event.ID)
 		if err != nil {
-			return nil, err
+			return err
+		}
+		if unsold == 0 {
+			if _, err := repo.UpdateEventStatus(ctx, event.ID, domain.EventStatusSalesOpen, domain.EventStatusSoldOut); err != nil {
+				return err
+			}
 		}
 
-		tickets[i] = *ticket
+		// Conclui a saga na mesma transação dos ingressos.
+		saga.MarkCompleted()
+		return repo.UpdateCheckoutSaga(ctx, saga)
+	})
+	if err != nil {
+		// A reserva existe no parceiro mas não foi registrada localmente: compensa.
+		compensateCheckout(ctx, uc.repo, partnerService, saga, err)
+		return nil, err
 	}
 
 	ticketDTOs := make([]TicketDTO, len(tickets))
 	for i, ticket := range tickets {
 		ticketDTOs[i] = TicketDTO{
-			ID:         ticket.ID,
-			SpotID:     ticket.Spot.ID,
-			TicketKind: string(ticket.TicketKind),
-			Price:      ticket.Price,
+			ID:             ticket.ID,
+			SpotID:         ticket.Spot.ID,
+			TicketKind:     string(ticket.TicketKind),
+			Price:          moneyNumber(ticket.Price),
+			Currency:       string(ticket.Price.Currency),
+			CouponDiscount: moneyNumber(ticket.CouponDiscount),
+			Coupons:        input.couponCodes(),
 		}
 	}
 
 	return &BuyTicketsOutputDTO{Tickets: ticketDTOs}, nil
 }
+
+// findCoupons busca os cupons da compra e verifica se valem para o evento, para o preço de cada
+// spot comprado e para o tipo de ingresso, se podem ser combinados e se ainda têm usos
+// disponíveis para o e-mail do comprador.
+func findCoupons(ctx context.Context, repo domain.CouponRepository, event *domain.Event, rule *domain.TicketKindRule, spots []*domain.Spot, input BuyTicketsInputDTO) ([]*domain.Coupon, error) {
+	now := time.Now()
+	email := domain.NormalizeCouponEmail(input.Email)
+	codes := input.couponCodes()
+	coupons := make([]*domain.Coupon, len(codes))
+	for i, code := range codes {
+		coupon, err := repo.FindCouponByCode(ctx, code)
+		if errors.Is(err, domain.ErrCouponNotFound) {
+			return nil, domain.ValidationErrors{{Field: "coupon_codes", Code: domain.FieldInvalidValue, Message: fmt.Sprintf("coupon %q does not exist", code)}}
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, spot := range spots {
+			if err := coupon.CheckApplicable(event, event.SpotPrice(spot), now); err != nil {
+				return nil, err
+			}
+		}
+		usage, err := repo.CountCouponRedemptions(ctx, coupon.ID, email)
+		if err != nil {
+			return nil, err
+		}
+		if err := coupon.CheckUsage(usage); err != nil {
+			return nil, err
+		}
+		coupons[i] = coupon
+	}
+	if err := domain.CheckCouponStacking(coupons, rule); err != nil {
+		return nil, err
+	}
+	return coupons, nil
+}
+
+// partnerError classifica uma falha do parceiro como erro de domínio, preservando a causa.
+func partnerError(err error) error {
+	if errors.Is(err, service.ErrPartnerCircuitOpen) {
+		return fmt.Errorf("%w: %w", domain.ErrPartnerUnavailable, err)
+	}
+	return fmt.Errorf("%w: %w", domain.ErrPartnerFailed, err)
+}
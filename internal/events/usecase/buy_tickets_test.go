@@ -0,0 +1,490 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/infra/migration"
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/infra/repository"
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/infra/service"
+	_ "github.com/go-sql-driver/mysql"
+)
+
+// testRepository cria um repositório vazio para um teste.
+type testRepository struct {
+	name string
+	new  func(t *testing.T) domain.Repositories
+}
+
+// testRepositories retorna o repositório em memória e, quando EVENTS_TEST_MYSQL_DSN aponta para
+// um banco de testes, o repositório MySQL com as migrações aplicadas.
+func testRepositories() []testRepository {
+	repos := []testRepository{{name: "memory", new: func(t *testing.T) domain.Repositories {
+		return repository.NewMemoryEventRepository()
+	}}}
+	if dsn := os.Getenv("EVENTS_TEST_MYSQL_DSN"); dsn != "" {
+		repos = append(repos, testRepository{name: "mysql", new: func(t *testing.T) domain.Repositories {
+			return newMySQLRepository(t, dsn)
+		}})
+	}
+	return repos
+}
+
+func newMySQLRepository(t *testing.T, dsn string) domain.Repositories {
+	t.Helper()
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	migrator, err := migration.NewMigrator(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	repo, err := repository.NewMysqlEventRepository(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return repo
+}
+
+// fakePartner confirma toda reserva, uma por spot, e registra os cancelamentos.
+type fakePartner struct {
+	mu      sync.Mutex
+	cancels []*service.CancelReservationRequest
+}
+
+func (p *fakePartner) MakeReservation(ctx context.Context, req *service.ReservationRequest) ([]service.ReservationResponse, error) {
+	responses := make([]service.ReservationResponse, len(req.Spots))
+	for i, spot := range req.Spots {
+		responses[i] = service.ReservationResponse{
+			ID:         "reservation-" + spot,
+			Email:      req.Email,
+			Spot:       spot,
+			TicketKind: req.TicketKind,
+			Status:     "reserved",
+			EventID:    req.EventID,
+		}
+	}
+	return responses, nil
+}
+
+func (p *fakePartner) CancelReservation(ctx context.Context, req *service.CancelReservationRequest) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cancels = append(p.cancels, req)
+	return nil
+}
+
+// barrierPartner só confirma as reservas depois que n compras chegaram ao parceiro, para que
+// todas disputem a emissão dos ingressos ao mesmo tempo.
+type barrierPartner struct {
+	fakePartner
+	arrived sync.WaitGroup
+}
+
+func newBarrierPartner(n int) *barrierPartner {
+	p := &barrierPartner{}
+	p.arrived.Add(n)
+	return p
+}
+
+func (p *barrierPartner) MakeReservation(ctx context.Context, req *service.ReservationRequest) ([]service.ReservationResponse, error) {
+	p.arrived.Done()
+	p.arrived.Wait()
+	return p.fakePartner.MakeReservation(ctx, req)
+}
+
+type fakePartnerFactory struct {
+	partner service.Partner
+}
+
+func (f fakePartnerFactory) CreatePartner(partnerID int) (service.Partner, error) {
+	return f.partner, nil
+}
+
+// newOnSaleEvent cria um evento com as vendas abertas e os spots informados.
+func newOnSaleEvent(t *testing.T, repo domain.EventRepository, spots ...string) *domain.Event {
+	t.Helper()
+	ctx := context.Background()
+
+	price, err := domain.ParseMoney("50.00", domain.DefaultCurrency)
+	if err != nil {
+		t.Fatal(err)
+	}
+	event, err := domain.NewEvent("Show", "Arena", "acme", domain.RatingLivre, time.Now().Add(24*time.Hour), 10, price, "", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	event.Status = domain.EventStatusSalesOpen
+	if err := repo.CreateEvent(ctx, event); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range spots {
+		spot, err := event.AddSpot(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.CreateSpot(ctx, spot); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return event
+}
+
+// holdSpots segura os spots para a sessão, como o cliente faz antes do checkout.
+func holdSpots(t *testing.T, repo domain.Repositories, event *domain.Event, sessionID string, spots ...string) {
+	t.Helper()
+	input := HoldSpotsInputDTO{EventID: event.ID, SessionID: sessionID, Spots: spots}
+	if _, err := NewHoldSpotsUseCase(repo).Execute(context.Background(), input); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func buyInput(event *domain.Event, sessionID string, spots ...string) BuyTicketsInputDTO {
+	return BuyTicketsInputDTO{
+		EventID:    event.ID,
+		Spots:      spots,
+		TicketKind: string(domain.TicketKindFull),
+		CardHash:   "card-hash",
+		Email:      "buyer@example.com",
+		SessionID:  sessionID,
+	}
+}
+
+var errInjected = errors.New("injected failure")
+
+// failingRepository falha a chamada failOn de número failAt (a partir de 1), inclusive dentro
+// de RunInTx, e guarda a última versão de cada saga gravada.
+type failingRepository struct {
+	domain.Repositories
+	state *failingState
+}
+
+type failingState struct {
+	mu     sync.Mutex
+	failOn string
+	failAt int
+	calls  map[string]int
+	sagas  map[string]domain.CheckoutSaga
+}
+
+func newFailingRepository(repo domain.Repositories, failOn string, failAt int) *failingRepository {
+	return &failingRepository{
+		Repositories: repo,
+		state: &failingState{
+			failOn: failOn,
+			failAt: failAt,
+			calls:  make(map[string]int),
+			sagas:  make(map[string]domain.CheckoutSaga),
+		},
+	}
+}
+
+func (r *failingRepository) fail(method string) error {
+	r.state.mu.Lock()
+	defer r.state.mu.Unlock()
+	r.state.calls[method]++
+	if method == r.state.failOn && r.state.calls[method] == r.state.failAt {
+		return errInjected
+	}
+	return nil
+}
+
+func (r *failingRepository) RunInTx(ctx context.Context, fn func(repo domain.Repositories) error) error {
+	return r.Repositories.RunInTx(ctx, func(tx domain.Repositories) error {
+		return fn(&failingRepository{Repositories: tx, state: r.state})
+	})
+}
+
+func (r *failingRepository) CreateTicket(ctx context.Context, ticket *domain.Ticket) error {
+	if err := r.fail("CreateTicket"); err != nil {
+		return err
+	}
+	return r.Repositories.CreateTicket(ctx, ticket)
+}
+
+func (r *failingRepository) ReserveSpot(ctx context.Context, spot *domain.Spot) error {
+	if err := r.fail("ReserveSpot"); err != nil {
+		return err
+	}
+	return r.Repositories.ReserveSpot(ctx, spot)
+}
+
+func (r *failingRepository) UpdateCheckoutSaga(ctx context.Context, saga *domain.CheckoutSaga) error {
+	r.state.mu.Lock()
+	r.state.sagas[saga.ID] = *saga
+	r.state.mu.Unlock()
+	return r.Repositories.UpdateCheckoutSaga(ctx, saga)
+}
+
+func TestBuyTicketsIsAtomicWhenPersistenceFails(t *testing.T) {
+	for _, failOn := range []string{"CreateTicket", "ReserveSpot"} {
+		t.Run(failOn, func(t *testing.T) {
+			ctx := context.Background()
+			memory := repository.NewMemoryEventRepository()
+			event := newOnSaleEvent(t, memory, "A1", "A2")
+			holdSpots(t, memory, event, "session-1", "A1", "A2")
+			before, err := memory.FindSpotByName(ctx, event.ID, "A1")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			repo := newFailingRepository(memory, failOn, 2)
+			partner := &fakePartner{}
+			uc := NewBuyTicketsUseCase(repo, fakePartnerFactory{partner: partner})
+
+			_, err = uc.Execute(ctx, buyInput(event, "session-1", "A1", "A2"))
+			if !errors.Is(err, errInjected) {
+				t.Fatalf("Execute error = %v, want %v", err, errInjected)
+			}
+
+			stored, err := memory.FindEventByID(ctx, event.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(stored.Tickets) != 0 {
+				t.Errorf("tickets = %d, want 0", len(stored.Tickets))
+			}
+
+			after, err := memory.FindSpotByName(ctx, event.ID, "A1")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if after.Status == domain.SpotStatusSold || after.TicketID != "" || after.Version != before.Version {
+				t.Errorf("spot A1 = {status %s, ticket %q, version %d}, want {status %s, ticket \"\", version %d}",
+					after.Status, after.TicketID, after.Version, before.Status, before.Version)
+			}
+			if !after.IsHeldBy("session-1", time.Now()) {
+				t.Error("spot A1 is no longer held by the buying session")
+			}
+
+			if len(repo.state.sagas) != 1 {
+				t.Fatalf("sagas = %d, want 1", len(repo.state.sagas))
+			}
+			for _, saga := range repo.state.sagas {
+				if saga.Status != domain.CheckoutSagaCompensated {
+					t.Errorf("saga status = %s, want %s", saga.Status, domain.CheckoutSagaCompensated)
+				}
+			}
+			if len(partner.cancels) != 1 || len(partner.cancels[0].ReservationIDs) != 2 {
+				t.Errorf("partner cancellations = %+v, want one cancelling both reservations", partner.cancels)
+			}
+		})
+	}
+}
+
+// TestBuyTicketsCompensatesUnlessThePartnerRejects usa o adaptador HTTP de verdade, com repetições:
+// só uma recusa 4xx encerra a saga sem cancelar; respostas 5xx ou ilegíveis podem esconder uma
+// reserva feita, mesmo que uma nova tentativa fosse recusada.
+func TestBuyTicketsCompensatesUnlessThePartnerRejects(t *testing.T) {
+	tests := []struct {
+		name     string
+		statuses []int // resposta de cada tentativa de reserva; a última se repete
+		body     string
+		want     domain.CheckoutSagaStatus
+		reserves int32
+		cancels  int32
+	}{
+		{name: "server error", statuses: []int{http.StatusInternalServerError}, want: domain.CheckoutSagaCompensated, reserves: 1, cancels: 1},
+		{name: "undecodable response", statuses: []int{http.StatusCreated}, body: `{"id":`, want: domain.CheckoutSagaCompensated, reserves: 1, cancels: 1},
+		{name: "rejected", statuses: []int{http.StatusConflict}, want: domain.CheckoutSagaFailed, reserves: 1, cancels: 0},
+		{name: "unavailable then rejected", statuses: []int{http.StatusServiceUnavailable, http.StatusConflict}, want: domain.CheckoutSagaCompensated, reserves: 1, cancels: 1},
+		{name: "rate limited then rejected", statuses: []int{http.StatusTooManyRequests, http.StatusConflict}, want: domain.CheckoutSagaFailed, reserves: 2, cancels: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reserves, cancels atomic.Int32
+			mux := http.NewServeMux()
+			mux.HandleFunc("POST /events/{eventID}/reserve", func(w http.ResponseWriter, r *http.Request) {
+				attempt := int(reserves.Add(1))
+				w.WriteHeader(tt.statuses[min(attempt, len(tt.statuses))-1])
+				w.Write([]byte(tt.body))
+			})
+			mux.HandleFunc("POST /events/{eventID}/cancel", func(w http.ResponseWriter, r *http.Request) {
+				cancels.Add(1)
+			})
+			server := httptest.NewServer(mux)
+			defer server.Close()
+
+			httpPartner, err := service.NewHTTPPartner(server.URL, service.Partner1Mapping, time.Second)
+			if err != nil {
+				t.Fatal(err)
+			}
+			retry := service.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Microsecond, MaxDelay: time.Microsecond}
+			partner := service.NewResilientPartner(1, httpPartner, retry, service.CircuitBreakerConfig{FailureThreshold: 10})
+			memory := repository.NewMemoryEventRepository()
+			event := newOnSaleEvent(t, memory, "A1")
+			holdSpots(t, memory, event, "session-1", "A1")
+			repo := newFailingRepository(memory, "", 0)
+
+			_, err = NewBuyTicketsUseCase(repo, fakePartnerFactory{partner: partner}).Execute(context.Background(), buyInput(event, "session-1", "A1"))
+			if !errors.Is(err, domain.ErrPartnerFailed) {
+				t.Fatalf("Execute error = %v, want %v", err, domain.ErrPartnerFailed)
+			}
+
+			if len(repo.state.sagas) != 1 {
+				t.Fatalf("sagas = %d, want 1", len(repo.state.sagas))
+			}
+			for _, saga := range repo.state.sagas {
+				if saga.Status != tt.want {
+					t.Errorf("saga status = %s, want %s", saga.Status, tt.want)
+				}
+			}
+			if got := reserves.Load(); got != tt.reserves {
+				t.Errorf("partner reservations = %d, want %d", got, tt.reserves)
+			}
+			if got := cancels.Load(); got != tt.cancels {
+				t.Errorf("partner cancellations = %d, want %d", got, tt.cancels)
+			}
+		})
+	}
+}
+
+func TestHoldBelongsToTheAuthenticatedPrincipal(t *testing.T) {
+	repo := repository.NewMemoryEventRepository()
+	event := newOnSaleEvent(t, repo, "A1")
+	alice := domain.ContextWithPrincipal(context.Background(), &domain.Principal{Subject: "alice", Roles: []domain.Role{domain.RoleCustomer}})
+	mallory := domain.ContextWithPrincipal(context.Background(), &domain.Principal{Subject: "mallory", Roles: []domain.Role{domain.RoleCustomer}})
+
+	hold, err := NewHoldSpotsUseCase(repo).Execute(alice, HoldSpotsInputDTO{EventID: event.ID, Spots: []string{"A1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Outro usuário com o mesmo session_id não renova a retenção nem compra o spot.
+	_, err = NewHoldSpotsUseCase(repo).Execute(mallory, HoldSpotsInputDTO{EventID: event.ID, SessionID: hold.SessionID, Spots: []string{"A1"}})
+	if !errors.Is(err, domain.ErrSpotHeld) {
+		t.Errorf("hold by another principal: error = %v, want %v", err, domain.ErrSpotHeld)
+	}
+	uc := NewBuyTicketsUseCase(repo, fakePartnerFactory{partner: &fakePartner{}})
+	if _, err := uc.Execute(mallory, buyInput(event, hold.SessionID, "A1")); !errors.Is(err, domain.ErrSpotNotHeld) {
+		t.Errorf("checkout by another principal: error = %v, want %v", err, domain.ErrSpotNotHeld)
+	}
+
+	output, err := uc.Execute(alice, buyInput(event, hold.SessionID, "A1"))
+	if err != nil {
+		t.Fatalf("checkout by the holder: %v", err)
+	}
+	if len(output.Tickets) != 1 {
+		t.Errorf("tickets = %d, want 1", len(output.Tickets))
+	}
+}
+
+func TestBuyTicketsConcurrentCheckoutsSellTheSpotOnce(t *testing.T) {
+	const buyers = 8
+	for _, tr := range testRepositories() {
+		t.Run(tr.name, func(t *testing.T) {
+			ctx := context.Background()
+			repo := tr.new(t)
+			// A2 fica à venda para que o evento não passe a sold_out após a primeira compra.
+			event := newOnSaleEvent(t, repo, "A1", "A2")
+			holdSpots(t, repo, event, "session-1", "A1")
+
+			partner := newBarrierPartner(buyers)
+			uc := NewBuyTicketsUseCase(repo, fakePartnerFactory{partner: partner})
+
+			errs := make([]error, buyers)
+			var wg sync.WaitGroup
+			for i := range buyers {
+				wg.Add(1)
+				go func() {
+					defer wg.Done()
+					_, errs[i] = uc.Execute(ctx, buyInput(event, "session-1", "A1"))
+				}()
+			}
+			wg.Wait()
+
+			succeeded := 0
+			for _, err := range errs {
+				switch {
+				case err == nil:
+					succeeded++
+				case !errors.Is(err, domain.ErrSpotAlreadyReserved):
+					t.Errorf("Execute error = %v, want %v", err, domain.ErrSpotAlreadyReserved)
+				}
+			}
+			if succeeded != 1 {
+				t.Errorf("successful checkouts = %d, want 1", succeeded)
+			}
+
+			stored, err := repo.FindEventByID(ctx, event.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(stored.Tickets) != 1 {
+				t.Errorf("tickets = %d, want 1", len(stored.Tickets))
+			}
+			if len(partner.cancels) != buyers-1 {
+				t.Errorf("partner cancellations = %d, want %d", len(partner.cancels), buyers-1)
+			}
+		})
+	}
+}
+
+func TestBuyTicketsInputValidate(t *testing.T) {
+	valid := BuyTicketsInputDTO{
+		EventID:     "event-1",
+		Spots:       []string{"A1", "A2"},
+		TicketKind:  string(domain.TicketKindFull),
+		CardHash:    "card-hash",
+		Email:       "buyer@example.com",
+		SessionID:   "session-1",
+		CouponCodes: []string{"SUMMER10"},
+	}
+	tests := []struct {
+		name   string
+		change func(input *BuyTicketsInputDTO)
+		want   []string // campos reportados
+	}{
+		{name: "valid", change: func(input *BuyTicketsInputDTO) {}},
+		{name: "everything missing", change: func(input *BuyTicketsInputDTO) { *input = BuyTicketsInputDTO{} },
+			want: []string{"event_id", "spots", "ticket_kind", "card_hash", "email", "session_id"}},
+		{name: "duplicated spot", change: func(input *BuyTicketsInputDTO) { input.Spots = []string{"A1", "A1"} }, want: []string{"spots[1]"}},
+		{name: "too many spots", change: func(input *BuyTicketsInputDTO) {
+			input.Spots = make([]string, maxSpotsPerCheckout+1)
+			for i := range input.Spots {
+				input.Spots[i] = domain.DefaultSpotName(i)
+			}
+		}, want: []string{"spots"}},
+		{name: "malformed ticket kind", change: func(input *BuyTicketsInputDTO) { input.TicketKind = "Meia Entrada" }, want: []string{"ticket_kind"}},
+		{name: "long eligibility document", change: func(input *BuyTicketsInputDTO) { input.EligibilityDocument = strings.Repeat("9", 101) }, want: []string{"eligibility_document"}},
+		{name: "coupon codes are case insensitive", change: func(input *BuyTicketsInputDTO) { input.CouponCodes = []string{"summer10", "SUMMER10"} }, want: []string{"coupon_codes[1]"}},
+		{name: "malformed and empty coupons", change: func(input *BuyTicketsInputDTO) { input.CouponCodes = []string{"X", " "} }, want: []string{"coupon_codes[0]", "coupon_codes[1]"}},
+		{name: "too many coupons", change: func(input *BuyTicketsInputDTO) { input.CouponCodes = []string{"AAA", "BBB", "CCC", "DDD"} }, want: []string{"coupon_codes"}},
+		{name: "longest idempotency key", change: func(input *BuyTicketsInputDTO) {
+			input.IdempotencyKey = strings.Repeat("k", domain.MaxIdempotencyKeyLength)
+		}},
+		{name: "idempotency key too long", change: func(input *BuyTicketsInputDTO) {
+			input.IdempotencyKey = strings.Repeat("k", domain.MaxIdempotencyKeyLength+1)
+		}, want: []string{"Idempotency-Key"}},
+		{name: "email with display name", change: func(input *BuyTicketsInputDTO) { input.Email = "Buyer <buyer@example.com>" }, want: []string{"email"}},
+		{name: "malformed email", change: func(input *BuyTicketsInputDTO) { input.Email = "buyer" }, want: []string{"email"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := valid
+			tt.change(&input)
+
+			err := input.Validate()
+			if got := validationFields(t, err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate fields = %v, want %v (error %v)", got, tt.want, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/infra/service"
+)
+
+type CompensateCheckoutsOutputDTO struct {
+	Compensated int `json:"compensated"`
+	Pending     int `json:"pending"`
+	// Failed conta as sagas que esgotaram as tentativas nesta execução e exigem intervenção manual.
+	Failed int `json:"failed"`
+}
+
+// CompensateCheckoutsUseCase retoma as sagas de compra que ficaram com a reserva
+// feita no parceiro sem os ingressos persistidos localmente e cancela essas reservas.
+type CompensateCheckoutsUseCase struct {
+	repo           domain.CheckoutSagaRepository
+	partnerFactory service.PartnerFactory
+	staleAfter     time.Duration
+	now            func() time.Time
+}
+
+// NewCompensateCheckoutsUseCase cria o caso de uso. Sagas iniciadas ou reservadas há mais
+// de staleAfter são consideradas abandonadas (por exemplo, após a queda do processo).
+func NewCompensateCheckoutsUseCase(repo domain.CheckoutSagaRepository, partnerFactory service.PartnerFactory, staleAfter time.Duration) *CompensateCheckoutsUseCase {
+	return &CompensateCheckoutsUseCase{
+		repo:           repo,
+		partnerFactory: partnerFactory,
+		staleAfter:     staleAfter,
+		now:            time.Now,
+	}
+}
+
+// Execute tenta compensar cada saga pendente. A falha de uma saga, inclusive ao obter o seu
+// parceiro, é registrada nela e não impede a compensação das demais.
+func (uc *CompensateCheckoutsUseCase) Execute(ctx context.Context) (*CompensateCheckoutsOutputDTO, error) {
+	now := uc.now()
+	sagas, err := uc.repo.FindPendingCheckoutSagas(ctx, now.Add(-uc.staleAfter))
+	if err != nil {
+		return nil, err
+	}
+
+	output := &CompensateCheckoutsOutputDTO{}
+	for _, saga := range sagas {
+		// Depois de uma falha, aguarda a espera da saga antes de tentar de novo.
+		if saga.Status == domain.CheckoutSagaCompensating && now.Before(saga.NextCompensationAt()) {
+			output.Pending++
+			continue
+		}
+
+		if saga.Status != domain.CheckoutSagaCompensating {
+			saga.StartCompensation(domain.ErrCheckoutSagaAbandoned)
+		}
+
+		partnerService, err := uc.partnerFactory.CreatePartner(saga.PartnerID)
+		if err != nil {
+			log.Printf("checkout saga %s: erro ao obter o parceiro %d: %v", saga.ID, saga.PartnerID, err)
+			saga.CompensationFailed(err)
+			if updateErr := uc.repo.UpdateCheckoutSaga(ctx, saga); updateErr != nil {
+				log.Printf("checkout saga %s: erro ao atualizar saga: %v", saga.ID, updateErr)
+			}
+		} else {
+			compensateCheckout(ctx, uc.repo, partnerService, saga, nil)
+		}
+
+		switch saga.Status {
+		case domain.CheckoutSagaCompensated:
+			output.Compensated++
+		case domain.CheckoutSagaCompensationFailed:
+			log.Printf("checkout saga %s: compensação abandonada após %d tentativas: %s", saga.ID, saga.Attempts, saga.LastError)
+			output.Failed++
+		default:
+			output.Pending++
+		}
+	}
+
+	return output, nil
+}
+
+// compensateCheckout cancela no parceiro a reserva da saga e registra o resultado.
+// cause é o erro que motivou a compensação (nil quando a saga já está em compensação).
+// Retorna true se a reserva foi cancelada; caso contrário a saga fica pendente para nova tentativa
+// (ou falha de vez, ao atingir domain.MaxCompensationAttempts).
+func compensateCheckout(ctx context.Context, repo domain.CheckoutSagaRepository, partnerService service.Partner, saga *domain.CheckoutSaga, cause error) bool {
+	if cause != nil {
+		saga.StartCompensation(cause)
+	}
+
+	err := partnerService.CancelReservation(ctx, &service.CancelReservationRequest{
+		EventID:        saga.EventID,
+		Spots:          saga.Spots,
+		ReservationIDs: saga.ReservationIDs,
+		Email:          saga.Email,
+	})
+	if err != nil {
+		saga.CompensationFailed(err)
+		log.Printf("checkout saga %s: erro ao cancelar reserva no parceiro: %v", saga.ID, err)
+	} else {
+		saga.MarkCompensated()
+	}
+
+	if updateErr := repo.UpdateCheckoutSaga(ctx, saga); updateErr != nil {
+		log.Printf("checkout saga %s: erro ao atualizar saga: %v", saga.ID, updateErr)
+	}
+	return err == nil
+}
@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/infra/repository"
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/infra/service"
+)
+
+// partnersByID cria apenas os parceiros configurados, como o DefaultPartnerFactory.
+type partnersByID map[int]service.Partner
+
+func (f partnersByID) CreatePartner(partnerID int) (service.Partner, error) {
+	partner, ok := f[partnerID]
+	if !ok {
+		return nil, fmt.Errorf("partner with ID %d not found", partnerID)
+	}
+	return partner, nil
+}
+
+func TestCompensateCheckoutsContinuesPastAMissingPartner(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMemoryEventRepository()
+	createSaga := func(partnerID int) *domain.CheckoutSaga {
+		t.Helper()
+		event := &domain.Event{ID: fmt.Sprintf("event-%d", partnerID), PartnerID: partnerID}
+		saga := domain.NewCheckoutSaga(event, []string{"A1"}, string(domain.TicketKindFull), "buyer@example.com")
+		saga.StartCompensation(errInjected)
+		if err := repo.CreateCheckoutSaga(ctx, saga); err != nil {
+			t.Fatal(err)
+		}
+		return saga
+	}
+	// A saga do parceiro removido vem primeiro e não pode impedir a compensação da outra.
+	orphan := createSaga(99)
+	createSaga(1)
+
+	partner := &fakePartner{}
+	uc := NewCompensateCheckoutsUseCase(repo, partnersByID{1: partner}, 5*time.Minute)
+	now := time.Now()
+	uc.now = func() time.Time { return now }
+
+	output, err := uc.Execute(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *output != (CompensateCheckoutsOutputDTO{Compensated: 1, Pending: 1}) {
+		t.Errorf("output = %+v, want {Compensated:1 Pending:1}", *output)
+	}
+	if len(partner.cancels) != 1 || partner.cancels[0].EventID != "event-1" {
+		t.Errorf("partner cancellations = %+v, want the saga of partner 1", partner.cancels)
+	}
+
+	pending := findPendingSaga(t, repo, orphan.ID)
+	if pending.Status != domain.CheckoutSagaCompensating || pending.Attempts != 1 {
+		t.Fatalf("orphan saga = {%s, %d attempts}, want compensating after 1 attempt", pending.Status, pending.Attempts)
+	}
+
+	// Dentro da espera a saga não é tentada de novo.
+	if output, err := uc.Execute(ctx); err != nil || *output != (CompensateCheckoutsOutputDTO{Pending: 1}) {
+		t.Fatalf("Execute during the backoff = %+v, %v, want {Pending:1}", output, err)
+	}
+	if attempts := findPendingSaga(t, repo, orphan.ID).Attempts; attempts != 1 {
+		t.Errorf("attempts during the backoff = %d, want 1", attempts)
+	}
+
+	// Passada cada espera, a saga é tentada até esgotar as tentativas e sai da fila.
+	for attempt := 2; attempt <= domain.MaxCompensationAttempts; attempt++ {
+		now = findPendingSaga(t, repo, orphan.ID).NextCompensationAt()
+		output, err := uc.Execute(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if attempt == domain.MaxCompensationAttempts && output.Failed != 1 {
+			t.Errorf("last attempt output = %+v, want Failed 1", *output)
+		}
+	}
+	sagas, err := repo.FindPendingCheckoutSagas(ctx, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sagas) != 0 {
+		t.Errorf("pending sagas after %d attempts = %d, want 0", domain.MaxCompensationAttempts, len(sagas))
+	}
+}
+
+func findPendingSaga(t *testing.T, repo domain.CheckoutSagaRepository, sagaID string) *domain.CheckoutSaga {
+	t.Helper()
+	sagas, err := repo.FindPendingCheckoutSagas(context.Background(), time.Now())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, saga := range sagas {
+		if saga.ID == sagaID {
+			return saga
+		}
+	}
+	t.Fatalf("saga %s is not pending", sagaID)
+	return nil
+}
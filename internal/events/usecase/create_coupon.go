@@ -0,0 +1,136 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+)
+
+// CreateCouponInputDTO cria um cupom para um evento (event_id) ou para todos os eventos de uma
+// organização (organization). Um cupom percentage informa discount_percent; um fixed informa
+// discount_amount, descontado de cada ingresso, na moeda currency (padrão: a do evento, ou BRL).
+// Sem valid_from o cupom vale imediatamente; sem valid_until, não expira.
+type CreateCouponInputDTO struct {
+	Code            string      `json:"code" example:"BLACKFRIDAY"`
+	DiscountType    string      `json:"discount_type" example:"percentage"`
+	DiscountPercent int         `json:"discount_percent" example:"20"`
+	DiscountAmount  json.Number `json:"discount_amount" swaggertype:"number" example:"15.00"`
+	Currency        string      `json:"currency" example:"BRL"`
+	ValidFrom       time.Time   `json:"valid_from"`
+	ValidUntil      time.Time   `json:"valid_until"`
+	EventID         string      `json:"event_id"`
+	Organization    string      `json:"organization"`
+	// MaxRedemptions e MaxRedemptionsPerEmail limitam as compras com o cupom; 0 = sem limite.
+	MaxRedemptions         int `json:"max_redemptions"`
+	MaxRedemptionsPerEmail int `json:"max_redemptions_per_email"`
+	// StacksWithCoupons permite combinar o cupom com outros cupons; StacksWithTicketKinds, usá-lo
+	// em tipos de ingresso que já têm desconto (meia-entrada).
+	StacksWithCoupons     bool `json:"stacks_with_coupons"`
+	StacksWithTicketKinds bool `json:"stacks_with_ticket_kinds"`
+}
+
+// maxCouponRedemptions limita os limites de uso informados.
+const maxCouponRedemptions = 1_000_000
+
+// Validate verifica todos os campos da entrada e retorna domain.ValidationErrors com os problemas encontrados.
+func (input CreateCouponInputDTO) Validate() error {
+	var v validator
+
+	if v.required(input.Code, "code") {
+		v.check(domain.IsValidCouponCode(domain.NormalizeCouponCode(input.Code)), "code", domain.FieldInvalidFormat, "code must have 3 to 32 letters, digits, _ or -")
+	}
+	switch domain.CouponDiscountType(input.DiscountType) {
+	case domain.CouponDiscountPercentage:
+		v.check(input.DiscountPercent >= 1 && input.DiscountPercent <= 100, "discount_percent", domain.FieldOutOfRange, "discount_percent must be between 1 and 100")
+		v.check(input.DiscountAmount == "", "discount_amount", domain.FieldInvalidValue, "discount_amount must not be sent with a percentage discount")
+	case domain.CouponDiscountFixed:
+		v.price(string(input.DiscountAmount), v.currency(input.Currency, "currency"), "discount_amount")
+		v.check(input.DiscountPercent == 0, "discount_percent", domain.FieldInvalidValue, "discount_percent must not be sent with a fixed discount")
+	default:
+		v.check(false, "discount_type", domain.FieldInvalidValue, "discount_type must be %q or %q", domain.CouponDiscountPercentage, domain.CouponDiscountFixed)
+	}
+	if !input.ValidUntil.IsZero() {
+		v.check(input.ValidUntil.After(time.Now()), "valid_until", domain.FieldOutOfRange, "valid_until must be in the future")
+		v.check(input.ValidFrom.IsZero() || input.ValidUntil.After(input.ValidFrom), "valid_until", domain.FieldOutOfRange, "valid_until must be after valid_from")
+	}
+	v.check((input.EventID == "") != (input.Organization == ""), "event_id", domain.FieldInvalidValue, "exactly one of event_id or organization is required")
+	v.maxLength(input.Organization, 255, "organization")
+	v.check(input.MaxRedemptions >= 0 && input.MaxRedemptions <= maxCouponRedemptions, "max_redemptions", domain.FieldOutOfRange, "max_redemptions must be between 0 and %d", maxCouponRedemptions)
+	v.check(input.MaxRedemptionsPerEmail >= 0 && input.MaxRedemptionsPerEmail <= maxCouponRedemptions, "max_redemptions_per_email", domain.FieldOutOfRange, "max_redemptions_per_email must be between 0 and %d", maxCouponRedemptions)
+
+	return v.err()
+}
+
+type CreateCouponUseCase struct {
+	repo domain.Repositories
+}
+
+func NewCreateCouponUseCase(repo domain.Repositories) *CreateCouponUseCase {
+	return &CreateCouponUseCase{repo: repo}
+}
+
+// Execute cria o cupom. Cupons de um evento só podem ser criados por quem gerencia o evento;
+// cupons de uma organização, apenas pelos seus organizadores.
+func (uc *CreateCouponUseCase) Execute(ctx context.Context, input CreateCouponInputDTO) (*CouponDTO, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	organization, currency := input.Organization, domain.Currency(input.Currency)
+	if currency == "" {
+		currency = domain.DefaultCurrency
+	}
+	if input.EventID != "" {
+		event, err := uc.repo.FindEventByID(ctx, input.EventID)
+		if errors.Is(err, domain.ErrEventNotFound) {
+			return nil, domain.ValidationErrors{{Field: "event_id", Code: domain.FieldInvalidValue, Message: "event_id does not reference an existing event"}}
+		}
+		if err != nil {
+			return nil, err
+		}
+		if err := domain.AuthorizeEventManagement(ctx, event.Organization, event.PartnerID); err != nil {
+			return nil, err
+		}
+		if input.Currency != "" && currency != event.Price.Currency {
+			return nil, domain.ValidationErrors{{Field: "currency", Code: domain.FieldInvalidValue, Message: "currency must be the event currency " + string(event.Price.Currency)}}
+		}
+		organization, currency = event.Organization, event.Price.Currency
+	} else if err := domain.AuthorizeEventManagement(ctx, organization, 0); err != nil {
+		return nil, err
+	}
+
+	coupon := domain.Coupon{
+		Code:                   input.Code,
+		DiscountType:           domain.CouponDiscountType(input.DiscountType),
+		Percent:                int64(input.DiscountPercent) * 100,
+		ValidFrom:              input.ValidFrom,
+		ValidUntil:             input.ValidUntil,
+		EventID:                input.EventID,
+		Organization:           organization,
+		MaxRedemptions:         input.MaxRedemptions,
+		MaxRedemptionsPerEmail: input.MaxRedemptionsPerEmail,
+		StacksWithCoupons:      input.StacksWithCoupons,
+		StacksWithTicketKinds:  input.StacksWithTicketKinds,
+	}
+	if coupon.DiscountType == domain.CouponDiscountFixed {
+		amount, err := domain.ParseMoney(string(input.DiscountAmount), currency)
+		if err != nil {
+			return nil, err
+		}
+		coupon.Amount = amount
+	}
+
+	created, err := domain.NewCoupon(coupon)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.repo.CreateCoupon(ctx, created); err != nil {
+		return nil, err
+	}
+
+	output := newCouponDTO(created, domain.CouponUsage{})
+	return &output, nil
+}
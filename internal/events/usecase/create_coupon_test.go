@@ -0,0 +1,71 @@
+package usecase
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+)
+
+func TestCreateCouponInputValidate(t *testing.T) {
+	now := time.Now()
+	newValid := func() CreateCouponInputDTO {
+		return CreateCouponInputDTO{
+			Code:            "blackfriday",
+			DiscountType:    string(domain.CouponDiscountPercentage),
+			DiscountPercent: 20,
+			ValidFrom:       now,
+			ValidUntil:      now.Add(24 * time.Hour),
+			Organization:    "acme",
+			MaxRedemptions:  100,
+		}
+	}
+	fixed := func(input *CreateCouponInputDTO) {
+		input.DiscountType, input.DiscountPercent, input.DiscountAmount, input.Currency = string(domain.CouponDiscountFixed), 0, "15.00", "USD"
+	}
+	tests := []struct {
+		name   string
+		change func(input *CreateCouponInputDTO)
+		want   []string // campos reportados
+	}{
+		{name: "percentage", change: func(input *CreateCouponInputDTO) {}},
+		{name: "fixed", change: fixed},
+		{name: "event scoped without validity", change: func(input *CreateCouponInputDTO) {
+			input.EventID, input.Organization, input.ValidFrom, input.ValidUntil = "event-1", "", time.Time{}, time.Time{}
+		}},
+		{name: "everything missing", change: func(input *CreateCouponInputDTO) { *input = CreateCouponInputDTO{} }, want: []string{"code", "discount_type", "event_id"}},
+		{name: "malformed code", change: func(input *CreateCouponInputDTO) { input.Code = "10% OFF" }, want: []string{"code"}},
+		{name: "short code", change: func(input *CreateCouponInputDTO) { input.Code = "ab" }, want: []string{"code"}},
+		{name: "percentage out of range", change: func(input *CreateCouponInputDTO) { input.DiscountPercent = 101 }, want: []string{"discount_percent"}},
+		{name: "percentage with an amount", change: func(input *CreateCouponInputDTO) { input.DiscountAmount = "10" }, want: []string{"discount_amount"}},
+		{name: "fixed with a percentage", change: func(input *CreateCouponInputDTO) { fixed(input); input.DiscountPercent = 10 }, want: []string{"discount_percent"}},
+		{name: "fixed without amount", change: func(input *CreateCouponInputDTO) { fixed(input); input.DiscountAmount = "" }, want: []string{"discount_amount"}},
+		{name: "fixed in an unsupported currency", change: func(input *CreateCouponInputDTO) { fixed(input); input.Currency = "ARS" }, want: []string{"currency"}},
+		{name: "fixed with more decimals than the currency", change: func(input *CreateCouponInputDTO) { fixed(input); input.DiscountAmount = "0.005" }, want: []string{"discount_amount"}},
+		{name: "unknown discount type", change: func(input *CreateCouponInputDTO) { input.DiscountType = "free" }, want: []string{"discount_type"}},
+		{name: "expired", change: func(input *CreateCouponInputDTO) {
+			input.ValidFrom, input.ValidUntil = now.Add(-48*time.Hour), now.Add(-24*time.Hour)
+		}, want: []string{"valid_until"}},
+		{name: "ends before it starts", change: func(input *CreateCouponInputDTO) {
+			input.ValidFrom, input.ValidUntil = now.Add(48*time.Hour), now.Add(24*time.Hour)
+		}, want: []string{"valid_until"}},
+		{name: "event and organization", change: func(input *CreateCouponInputDTO) { input.EventID = "event-1" }, want: []string{"event_id"}},
+		{name: "long organization", change: func(input *CreateCouponInputDTO) { input.Organization = strings.Repeat("o", 256) }, want: []string{"organization"}},
+		{name: "redemption limits out of range", change: func(input *CreateCouponInputDTO) {
+			input.MaxRedemptions, input.MaxRedemptionsPerEmail = maxCouponRedemptions+1, -1
+		}, want: []string{"max_redemptions", "max_redemptions_per_email"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := newValid()
+			tt.change(&input)
+
+			err := input.Validate()
+			if got := validationFields(t, err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate fields = %v, want %v (error %v)", got, tt.want, err)
+			}
+		})
+	}
+}
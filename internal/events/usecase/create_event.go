@@ -1,63 +1,269 @@
 package usecase
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
 )
 
+// CreateEventInputDTO cria um evento. Com venue_id, os spots são criados a partir do mapa do
+// local; capacity passa a ser opcional (padrão: a quantidade de assentos) e location, quando
+// vazio, recebe o nome e o endereço do local. price é o preço dos spots fora das categorias em tiers.
+// Sem ticket_kinds, o evento oferece apenas full e half (domain.DefaultTicketKindRules).
 type CreateEventInputDTO struct {
-	Name         string    `json:"name"`
-	Location     string    `json:"location"`
-	Organization string    `json:"organization"`
-	Rating       string    `json:"rating"`
-	Date         time.Time `json:"date"`
-	Capacity     int       `json:"capacity"`
-	ImageURL     string    `json:"image_url"`
-	Price        float64   `json:"price"`
-	PartnerID    int       `json:"partner_id"`
+	Name         string               `json:"name"`
+	Location     string               `json:"location"`
+	Organization string               `json:"organization"`
+	Rating       string               `json:"rating"`
+	Date         time.Time            `json:"date"`
+	Capacity     int                  `json:"capacity"`
+	ImageURL     string               `json:"image_url"`
+	Price        json.Number          `json:"price" swaggertype:"number" example:"100.00"`
+	Currency     string               `json:"currency" example:"BRL"` // ISO 4217; padrão BRL
+	PartnerID    int                  `json:"partner_id"`
+	VenueID      string               `json:"venue_id"`
+	Tiers        []PriceTierInputDTO  `json:"tiers"`
+	TicketKinds  []TicketKindInputDTO `json:"ticket_kinds"`
+}
+
+// TicketKindInputDTO define um tipo de ingresso oferecido pelo evento. document é o comprovante
+// exigido no checkout (student_id, id_card, disability_certificate ou invitation_code) e
+// companion_seats quantos acompanhantes um comprovante cobre.
+type TicketKindInputDTO struct {
+	Kind            string `json:"kind" example:"student"`
+	Name            string `json:"name" example:"Meia-entrada estudante"`
+	DiscountPercent int    `json:"discount_percent" example:"50"`
+	QuotaPercent    int    `json:"quota_percent" example:"40"` // 0 = sem cota
+	Document        string `json:"document" example:"student_id"`
+	CompanionSeats  int    `json:"companion_seats"`
+}
+
+func (dto TicketKindInputDTO) rule() domain.TicketKindRule {
+	return domain.TicketKindRule{
+		Kind:           domain.TicketKind(dto.Kind),
+		Name:           dto.Name,
+		Discount:       int64(dto.DiscountPercent) * 100,
+		QuotaPercent:   dto.QuotaPercent,
+		Document:       domain.EligibilityDocument(dto.Document),
+		CompanionSeats: dto.CompanionSeats,
+	}
+}
+
+// maxTicketKinds limita os tipos de ingresso de um evento.
+const maxTicketKinds = 10
+
+// PriceTierInputDTO define uma categoria de preço, na moeda do evento. sections (apenas com
+// venue_id) lista os nomes dos setores do local cujos spots entram na categoria; os demais spots
+// entram nela pelo campo tier de CreateSpots.
+type PriceTierInputDTO struct {
+	Name     string      `json:"name" example:"VIP"`
+	Price    json.Number `json:"price" swaggertype:"number" example:"250.00"`
+	Sections []string    `json:"sections"`
+}
+
+// maxPriceTiers limita as categorias de preço de um evento.
+const maxPriceTiers = 20
+
+// maxEventCapacity limita a capacidade de um evento, informada ou derivada do mapa do local.
+const maxEventCapacity = 100000
+
+// Validate verifica todos os campos da entrada e retorna domain.ValidationErrors com os problemas encontrados.
+func (input CreateEventInputDTO) Validate() error {
+	var v validator
+
+	if v.required(strings.TrimSpace(input.Name), "name") {
+		v.maxLength(input.Name, 255, "name")
+	}
+	v.maxLength(input.Location, 255, "location")
+	v.maxLength(input.Organization, 255, "organization")
+	v.check(domain.Rating(input.Rating).IsValid(), "rating", domain.FieldInvalidValue, "%s", domain.ErrInvalidRating.Message)
+	if input.Date.IsZero() {
+		v.check(false, "date", domain.FieldRequired, "date is required")
+	} else {
+		v.check(input.Date.After(time.Now()), "date", domain.FieldOutOfRange, "date must be in the future")
+	}
+	v.check((input.VenueID != "" && input.Capacity == 0) || (input.Capacity > 0 && input.Capacity <= maxEventCapacity), "capacity", domain.FieldOutOfRange, "capacity must be between 1 and %d", maxEventCapacity)
+	currency := v.currency(input.Currency, "currency")
+	v.price(string(input.Price), currency, "price")
+	input.validateTiers(&v, currency)
+	input.validateTicketKinds(&v)
+	if input.ImageURL != "" {
+		v.httpURL(input.ImageURL, "image_url")
+		v.maxLength(input.ImageURL, 255, "image_url")
+	}
+	v.check(input.PartnerID > 0, "partner_id", domain.FieldOutOfRange, "partner_id must be greater than zero")
+
+	return v.err()
+}
+
+func (input CreateEventInputDTO) validateTiers(v *validator, currency domain.Currency) {
+	v.check(len(input.Tiers) <= maxPriceTiers, "tiers", domain.FieldOutOfRange, "tiers must have at most %d items", maxPriceTiers)
+	names := make(map[string]bool, len(input.Tiers))
+	sections := make(map[string]bool)
+	for i, tier := range input.Tiers {
+		field := fmt.Sprintf("tiers[%d]", i)
+		if v.required(strings.TrimSpace(tier.Name), field+".name") {
+			v.maxLength(tier.Name, 50, field+".name")
+			v.check(!names[tier.Name], field+".name", domain.FieldDuplicate, "%s.name %q is duplicated", field, tier.Name)
+			names[tier.Name] = true
+		}
+		v.price(string(tier.Price), currency, field+".price")
+		v.check(len(tier.Sections) == 0 || input.VenueID != "", field+".sections", domain.FieldInvalidValue, "%s.sections requires venue_id", field)
+		for j, section := range tier.Sections {
+			sectionField := fmt.Sprintf("%s.sections[%d]", field, j)
+			v.check(!sections[section], sectionField, domain.FieldDuplicate, "section %q already belongs to a price tier", section)
+			sections[section] = true
+		}
+	}
+}
+
+func (input CreateEventInputDTO) validateTicketKinds(v *validator) {
+	v.check(len(input.TicketKinds) <= maxTicketKinds, "ticket_kinds", domain.FieldOutOfRange, "ticket_kinds must have at most %d items", maxTicketKinds)
+	kinds := make(map[string]bool, len(input.TicketKinds))
+	for i, kind := range input.TicketKinds {
+		field := fmt.Sprintf("ticket_kinds[%d]", i)
+		if v.required(kind.Kind, field+".kind") {
+			v.check(domain.TicketKind(kind.Kind).IsValid(), field+".kind", domain.FieldInvalidFormat, "%s.kind must be lowercase letters, digits or _ with at most 20 characters", field)
+			v.check(!kinds[kind.Kind], field+".kind", domain.FieldDuplicate, "%s.kind %q is duplicated", field, kind.Kind)
+			kinds[kind.Kind] = true
+		}
+		if v.required(strings.TrimSpace(kind.Name), field+".name") {
+			v.maxLength(kind.Name, 100, field+".name")
+		}
+		v.check(kind.DiscountPercent >= 0 && kind.DiscountPercent <= 100, field+".discount_percent", domain.FieldOutOfRange, "%s.discount_percent must be between 0 and 100", field)
+		v.check(kind.QuotaPercent >= 0 && kind.QuotaPercent <= 100, field+".quota_percent", domain.FieldOutOfRange, "%s.quota_percent must be between 0 and 100", field)
+		v.check(domain.EligibilityDocument(kind.Document).IsValid(), field+".document", domain.FieldInvalidValue, "%s.document must be empty or one of %q, %q, %q, %q", field,
+			domain.EligibilityDocumentStudentID, domain.EligibilityDocumentIDCard, domain.EligibilityDocumentDisability, domain.EligibilityDocumentInvitation)
+		v.check(kind.CompanionSeats >= 0 && kind.CompanionSeats <= 3, field+".companion_seats", domain.FieldOutOfRange, "%s.companion_seats must be between 0 and 3", field)
+		v.check(kind.CompanionSeats == 0 || kind.Document != "", field+".companion_seats", domain.FieldInvalidValue, "%s.companion_seats requires a document", field)
+	}
+}
+
+// currency retorna a moeda do preço, usando domain.DefaultCurrency quando não informada.
+func (input CreateEventInputDTO) currency() domain.Currency {
+	if input.Currency == "" {
+		return domain.DefaultCurrency
+	}
+	return domain.Currency(input.Currency)
 }
 
 type CreateEventOutputDTO struct {
-	ID           string    `json:"id"`
-	Name         string    `json:"name"`
-	Location     string    `json:"location"`
-	Organization string    `json:"organization"`
-	Rating       string    `json:"rating"`
-	Date         time.Time `json:"date"`
-	ImageURL     string    `json:"image_url"`
-	Capacity     int       `json:"capacity"`
-	Price        float64   `json:"price"`
-	PartnerID    int       `json:"partner_id"`
+	ID           string          `json:"id"`
+	Name         string          `json:"name"`
+	Location     string          `json:"location"`
+	Organization string          `json:"organization"`
+	Rating       string          `json:"rating"`
+	Date         time.Time       `json:"date"`
+	ImageURL     string          `json:"image_url"`
+	Capacity     int             `json:"capacity"`
+	Price        json.Number     `json:"price" swaggertype:"number" example:"100.00"`
+	Currency     string          `json:"currency" example:"BRL"`
+	PartnerID    int             `json:"partner_id"`
+	VenueID      string          `json:"venue_id,omitempty"`
+	Status       string          `json:"status" example:"sales_open"`
+	Tiers        []PriceTierDTO  `json:"tiers"`
+	TicketKinds  []TicketKindDTO `json:"ticket_kinds"`
 }
 
 type CreateEventUseCase struct {
-	repo domain.EventRepository
+	repo domain.Repositories
 }
 
-func NewCreateEventUseCase(repo domain.EventRepository) *CreateEventUseCase {
+func NewCreateEventUseCase(repo domain.Repositories) *CreateEventUseCase {
 	return &CreateEventUseCase{repo: repo}
 }
 
-func (uc *CreateEventUseCase) Execute(input CreateEventInputDTO) (CreateEventOutputDTO, error) {
+func (uc *CreateEventUseCase) Execute(ctx context.Context, input CreateEventInputDTO) (CreateEventOutputDTO, error) {
+	if err := input.Validate(); err != nil {
+		return CreateEventOutputDTO{}, err
+	}
 
-	event, err := domain.NewEvent(
-		input.Name,
-		input.Location,
-		input.Organization,
-		domain.Rating(input.Rating),
-		input.Date,
-		input.Capacity,
-		input.Price,
-		input.ImageURL,
-		input.PartnerID,
-	)
+	if err := domain.AuthorizeEventManagement(ctx, input.Organization, input.PartnerID); err != nil {
+		return CreateEventOutputDTO{}, err
+	}
 
+	price, err := domain.ParseMoney(string(input.Price), input.currency())
 	if err != nil {
 		return CreateEventOutputDTO{}, err
 	}
 
-	err = uc.repo.CreateEvent(event)
+	var event *domain.Event
+	err = uc.repo.RunInTx(ctx, func(repo domain.Repositories) error {
+		location, capacity := input.Location, input.Capacity
+		var venue *domain.Venue
+		if input.VenueID != "" {
+			venue, err = repo.FindVenueByID(ctx, input.VenueID)
+			if errors.Is(err, domain.ErrVenueNotFound) {
+				return domain.ValidationErrors{{Field: "venue_id", Code: domain.FieldInvalidValue, Message: "venue_id does not reference an existing venue"}}
+			}
+			if err != nil {
+				return err
+			}
+			if location == "" {
+				location = venue.Location()
+			}
+			if capacity == 0 {
+				capacity = venue.SeatCount()
+				// O limite vale também para a capacidade derivada do local, e não só para a informada.
+				if capacity > maxEventCapacity {
+					return domain.ValidationErrors{{Field: "venue_id", Code: domain.FieldOutOfRange, Message: fmt.Sprintf("venue has more than %d seats, the maximum capacity of an event", maxEventCapacity)}}
+				}
+			}
+		}
+
+		event, err = domain.NewEvent(
+			input.Name,
+			location,
+			input.Organization,
+			domain.Rating(input.Rating),
+			input.Date,
+			capacity,
+			price,
+			input.ImageURL,
+			input.PartnerID,
+		)
+		if err != nil {
+			return err
+		}
+		if venue != nil {
+			if err := event.AddVenueSpots(venue); err != nil {
+				return err
+			}
+		}
+		if err := input.addTiers(event, venue); err != nil {
+			return err
+		}
+		for _, kind := range input.TicketKinds {
+			if err := event.AddTicketKindRule(kind.rule()); err != nil {
+				return err
+			}
+		}
+
+		if err := repo.CreateEvent(ctx, event); err != nil {
+			return err
+		}
+		for i := range event.Tiers {
+			if err := repo.CreatePriceTier(ctx, &event.Tiers[i]); err != nil {
+				return err
+			}
+		}
+		for i := range event.TicketKinds {
+			if err := repo.CreateTicketKindRule(ctx, &event.TicketKinds[i]); err != nil {
+				return err
+			}
+		}
+		for i := range event.Spots {
+			if err := repo.CreateSpot(ctx, &event.Spots[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 	if err != nil {
 		return CreateEventOutputDTO{}, err
 	}
@@ -71,9 +277,46 @@ func (uc *CreateEventUseCase) Execute(input CreateEventInputDTO) (CreateEventOut
 		Date:         event.Date,
 		Capacity:     event.Capacity,
 		ImageURL:     event.ImageURL,
-		Price:        event.Price,
+		Price:        moneyNumber(event.Price),
+		Currency:     string(event.Price.Currency),
 		PartnerID:    event.PartnerID,
+		VenueID:      event.VenueID,
+		Status:       string(event.Status),
+		Tiers:        newPriceTierDTOs(event.Tiers),
+		TicketKinds:  newTicketKindDTOs(event.TicketKindRules()),
 	}
 
 	return output, nil
 }
+
+// addTiers cria as categorias de preço e associa a elas os spots dos setores informados.
+func (input CreateEventInputDTO) addTiers(event *domain.Event, venue *domain.Venue) error {
+	sectionIDs := make(map[string]string)
+	if venue != nil {
+		for _, section := range venue.Sections {
+			sectionIDs[section.Name] = section.ID
+		}
+	}
+
+	for i, tierInput := range input.Tiers {
+		price, err := domain.ParseMoney(string(tierInput.Price), event.Price.Currency)
+		if err != nil {
+			return err
+		}
+		tier, err := event.AddPriceTier(tierInput.Name, price)
+		if err != nil {
+			return err
+		}
+		for j, name := range tierInput.Sections {
+			sectionID, ok := sectionIDs[name]
+			if !ok {
+				field := fmt.Sprintf("tiers[%d].sections[%d]", i, j)
+				return domain.ValidationErrors{{Field: field, Code: domain.FieldInvalidValue, Message: fmt.Sprintf("venue has no section %q", name)}}
+			}
+			if err := event.AssignSectionTier(sectionID, tier.ID); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
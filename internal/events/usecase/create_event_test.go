@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/infra/repository"
+)
+
+// TestCreateEventLimitsCapacityDerivedFromVenue cobre um local criado fora de CreateVenue, cujo
+// mapa passa de maxEventCapacity: a capacidade derivada dele também precisa respeitar o limite.
+func TestCreateEventLimitsCapacityDerivedFromVenue(t *testing.T) {
+	ctx := context.Background()
+	repo := repository.NewMemoryEventRepository()
+
+	venue, err := domain.NewVenue("Estádio", "Rua A, 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	seats := maxEventCapacity/2 + 1
+	if _, err := venue.AddSection("Pista", []domain.RowPlan{{Label: "A", Seats: seats}, {Label: "B", Seats: seats}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.CreateVenue(ctx, venue); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = NewCreateEventUseCase(repo).Execute(ctx, CreateEventInputDTO{
+		Name:         "Show",
+		Organization: "acme",
+		Rating:       string(domain.RatingLivre),
+		Date:         time.Now().Add(30 * 24 * time.Hour),
+		Price:        "50.00",
+		PartnerID:    1,
+		VenueID:      venue.ID,
+	})
+
+	var validation domain.ValidationErrors
+	if !errors.As(err, &validation) || len(validation) != 1 || validation[0].Field != "venue_id" || validation[0].Code != domain.FieldOutOfRange {
+		t.Fatalf("error = %v, want a venue_id out of range validation error", err)
+	}
+	events, err := repo.ListEvents(ctx, domain.EventQuery{SortBy: domain.EventSortByDate, Limit: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 0 {
+		t.Errorf("events = %d, want the event not to be created", len(events))
+	}
+}
+
+func TestCreateEventInputValidate(t *testing.T) {
+	newValid := func() CreateEventInputDTO {
+		return CreateEventInputDTO{
+			Name:         "Show",
+			Organization: "acme",
+			Rating:       string(domain.RatingLivre),
+			Date:         time.Now().Add(30 * 24 * time.Hour),
+			Capacity:     100,
+			Price:        "50.00",
+			PartnerID:    1,
+			Tiers:        []PriceTierInputDTO{{Name: "VIP", Price: "100.00"}},
+			TicketKinds: []TicketKindInputDTO{
+				{Kind: "student", Name: "Meia-entrada estudante", DiscountPercent: 50, QuotaPercent: 40, Document: string(domain.EligibilityDocumentStudentID), CompanionSeats: 1},
+			},
+		}
+	}
+	tests := []struct {
+		name   string
+		change func(input *CreateEventInputDTO)
+		want   []string // campos reportados
+	}{
+		{name: "valid", change: func(input *CreateEventInputDTO) {}},
+		{name: "capacity derived from the venue", change: func(input *CreateEventInputDTO) {
+			input.Capacity, input.VenueID = 0, "venue-1"
+			input.Tiers[0].Sections = []string{"Pista"}
+		}},
+		{name: "everything missing", change: func(input *CreateEventInputDTO) { *input = CreateEventInputDTO{} },
+			want: []string{"name", "rating", "date", "capacity", "price", "partner_id"}},
+		{name: "blank name", change: func(input *CreateEventInputDTO) { input.Name = "   " }, want: []string{"name"}},
+		{name: "long texts", change: func(input *CreateEventInputDTO) {
+			input.Name, input.Location, input.Organization = strings.Repeat("n", 256), strings.Repeat("l", 256), strings.Repeat("o", 256)
+		}, want: []string{"name", "location", "organization"}},
+		{name: "unknown rating", change: func(input *CreateEventInputDTO) { input.Rating = "L15" }, want: []string{"rating"}},
+		{name: "past date", change: func(input *CreateEventInputDTO) { input.Date = time.Now().Add(-time.Hour) }, want: []string{"date"}},
+		{name: "capacity above the limit", change: func(input *CreateEventInputDTO) { input.Capacity = maxEventCapacity + 1 }, want: []string{"capacity"}},
+		{name: "unsupported currency", change: func(input *CreateEventInputDTO) { input.Currency = "XYZ" }, want: []string{"currency"}},
+		{name: "more decimals than the currency", change: func(input *CreateEventInputDTO) { input.Price = "10.001" }, want: []string{"price"}},
+		{name: "tier price in the event currency", change: func(input *CreateEventInputDTO) {
+			input.Currency, input.Tiers[0].Price = string(domain.CurrencyUSD), "99.999"
+		}, want: []string{"tiers[0].price"}},
+		{name: "free event", change: func(input *CreateEventInputDTO) { input.Price = "0" }, want: []string{"price"}},
+		{name: "price above the limit", change: func(input *CreateEventInputDTO) { input.Price = "10000000000.01" }, want: []string{"price"}},
+		{name: "duplicated tier", change: func(input *CreateEventInputDTO) {
+			input.Tiers = append(input.Tiers, PriceTierInputDTO{Name: "VIP", Price: "90.00"})
+		}, want: []string{"tiers[1].name"}},
+		{name: "tier sections without venue", change: func(input *CreateEventInputDTO) { input.Tiers[0].Sections = []string{"Pista"} }, want: []string{"tiers[0].sections"}},
+		{name: "section in two tiers", change: func(input *CreateEventInputDTO) {
+			input.VenueID = "venue-1"
+			input.Tiers = []PriceTierInputDTO{{Name: "VIP", Price: "100.00", Sections: []string{"Pista"}}, {Name: "Gold", Price: "80.00", Sections: []string{"Pista"}}}
+		}, want: []string{"tiers[1].sections[0]"}},
+		{name: "duplicated ticket kind", change: func(input *CreateEventInputDTO) {
+			input.TicketKinds = append(input.TicketKinds, TicketKindInputDTO{Kind: "student", Name: "Outra meia"})
+		}, want: []string{"ticket_kinds[1].kind"}},
+		{name: "invalid ticket kind", change: func(input *CreateEventInputDTO) {
+			input.TicketKinds[0] = TicketKindInputDTO{Kind: "Student!", DiscountPercent: 101, QuotaPercent: -1, Document: "passport", CompanionSeats: 4}
+		}, want: []string{"ticket_kinds[0].kind", "ticket_kinds[0].name", "ticket_kinds[0].discount_percent", "ticket_kinds[0].quota_percent", "ticket_kinds[0].document", "ticket_kinds[0].companion_seats"}},
+		{name: "companion without document", change: func(input *CreateEventInputDTO) { input.TicketKinds[0].Document = "" }, want: []string{"ticket_kinds[0].companion_seats"}},
+		{name: "relative image url", change: func(input *CreateEventInputDTO) { input.ImageURL = "/images/show.png" }, want: []string{"image_url"}},
+		{name: "missing partner", change: func(input *CreateEventInputDTO) { input.PartnerID = 0 }, want: []string{"partner_id"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := newValid()
+			tt.change(&input)
+
+			err := input.Validate()
+			if got := validationFields(t, err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate fields = %v, want %v (error %v)", got, tt.want, err)
+			}
+		})
+	}
+}
@@ -1,14 +1,81 @@
 package usecase
 
 import (
+	"context"
 	"fmt"
+	"strings"
 
 	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
 )
 
+// CreateSpotsInputDTO aceita uma quantidade de spots, gerados na planta padrão (fileiras de
+// domain.DefaultSeatsPerRow assentos, continuando após os nomes já usados), ou uma planta completa.
+// Com tier, os novos spots entram na categoria de preço de mesmo nome.
 type CreateSpotsInputDTO struct {
-	EventID       string `json:"event_id"`
-	NumberOfSpots int    `json:"number_of_spots"`
+	EventID       string         `json:"event_id"`
+	NumberOfSpots int            `json:"number_of_spots"`
+	Layout        *SpotLayoutDTO `json:"layout"`
+	Tier          string         `json:"tier" example:"VIP"`
+}
+
+// SpotLayoutDTO espelha domain.SpotLayout. Informe rows e seats_per_row, ou row_seats com a
+// quantidade de assentos de cada fileira.
+type SpotLayoutDTO struct {
+	Rows        int      `json:"rows"`
+	SeatsPerRow int      `json:"seats_per_row"`
+	RowSeats    []int    `json:"row_seats"`
+	LabelScheme string   `json:"label_scheme" example:"letters"` // letters (padrão) ou numeric
+	SkipLabels  []string `json:"skip_labels"`
+}
+
+func (dto SpotLayoutDTO) layout() domain.SpotLayout {
+	return domain.SpotLayout{
+		Rows:        dto.Rows,
+		SeatsPerRow: dto.SeatsPerRow,
+		RowSeats:    dto.RowSeats,
+		LabelScheme: domain.RowLabelScheme(dto.LabelScheme),
+		SkipLabels:  dto.SkipLabels,
+	}
+}
+
+// maxNumberOfSpots é o limite de spots criados por requisição.
+const maxNumberOfSpots = 2000
+
+// Validate verifica todos os campos da entrada e retorna domain.ValidationErrors com os problemas encontrados.
+func (input CreateSpotsInputDTO) Validate() error {
+	var v validator
+	if input.Layout == nil {
+		v.check(input.NumberOfSpots > 0 && input.NumberOfSpots <= maxNumberOfSpots, "number_of_spots", domain.FieldOutOfRange, "number_of_spots must be between 1 and %d", maxNumberOfSpots)
+		return v.err()
+	}
+
+	v.check(input.NumberOfSpots == 0, "number_of_spots", domain.FieldInvalidValue, "number_of_spots must not be sent together with layout")
+	input.Layout.validate(&v, "layout", maxNumberOfSpots)
+	return v.err()
+}
+
+// validate verifica a planta, nomeando os campos a partir de field, e limita o total a maxSpots.
+func (dto SpotLayoutDTO) validate(v *validator, field string, maxSpots int) {
+	v.check(dto.LabelScheme == "" || domain.RowLabelScheme(dto.LabelScheme).IsValid(), field+".label_scheme", domain.FieldInvalidValue, "%s.label_scheme must be %q or %q", field, domain.RowLabelLetters, domain.RowLabelNumeric)
+	if len(dto.RowSeats) > 0 {
+		v.check(dto.Rows == 0 || dto.Rows == len(dto.RowSeats), field+".rows", domain.FieldInvalidValue, "%s.rows must match the length of %s.row_seats", field, field)
+		v.check(dto.SeatsPerRow == 0, field+".seats_per_row", domain.FieldInvalidValue, "%s.seats_per_row must not be sent together with %s.row_seats", field, field)
+		for i, seats := range dto.RowSeats {
+			v.check(seats > 0, fmt.Sprintf("%s.row_seats[%d]", field, i), domain.FieldOutOfRange, "%s.row_seats[%d] must be greater than zero", field, i)
+		}
+	} else {
+		v.check(dto.Rows > 0, field+".rows", domain.FieldOutOfRange, "%s.rows must be greater than zero", field)
+		v.check(dto.SeatsPerRow > 0, field+".seats_per_row", domain.FieldOutOfRange, "%s.seats_per_row must be greater than zero", field)
+	}
+	for i, label := range dto.SkipLabels {
+		v.required(strings.TrimSpace(label), fmt.Sprintf("%s.skip_labels[%d]", field, i))
+	}
+	// Cada parcela é limitada antes da soma, que assim não transborda.
+	bounded := dto.Rows <= maxSpots && dto.SeatsPerRow <= maxSpots
+	for _, seats := range dto.RowSeats {
+		bounded = bounded && seats <= maxSpots
+	}
+	v.check(bounded && dto.layout().TotalSpots() <= maxSpots, field, domain.FieldOutOfRange, "%s must have at most %d spots", field, maxSpots)
 }
 
 type CreateSpotsOutputDTO struct {
@@ -16,49 +83,114 @@ type CreateSpotsOutputDTO struct {
 }
 
 type CreateSpotsUseCase struct {
-	repo domain.EventRepository
+	repo domain.Repositories
 }
 
-func NewCreateSpotsUseCase(repo domain.EventRepository) *CreateSpotsUseCase {
+func NewCreateSpotsUseCase(repo domain.Repositories) *CreateSpotsUseCase {
 	return &CreateSpotsUseCase{repo: repo}
 }
 
-func (uc *CreateSpotsUseCase) Execute(input CreateSpotsInputDTO) (*CreateSpotsOutputDTO, error) {
-	event, err := uc.repo.FindEventByID(input.EventID)
-	if err != nil {
+// Execute cria os spots em uma transação: a quantidade total de spots nunca passa da capacidade
+// do evento (ErrEventCapacityExceeded), e nenhum spot é criado se algum deles não couber.
+func (uc *CreateSpotsUseCase) Execute(ctx context.Context, input CreateSpotsInputDTO) (*CreateSpotsOutputDTO, error) {
+	if err := input.Validate(); err != nil {
 		return nil, err
 	}
 
-	spots := make([]domain.Spot, input.NumberOfSpots)
-	for i := 0; i < input.NumberOfSpots; i++ {
-		spotName := generateSpotName(i)
-		spot, err := domain.NewSpot(event, spotName)
+	var event *domain.Event
+	spots := make([]domain.Spot, 0, input.NumberOfSpots)
+	err := uc.repo.RunInTx(ctx, func(repo domain.Repositories) error {
+		// O bloqueio impede que criações concorrentes ultrapassem a capacidade juntas.
+		if _, err := repo.LockEventStatus(ctx, input.EventID); err != nil {
+			return err
+		}
+		var err error
+		event, err = repo.FindEventByID(ctx, input.EventID)
 		if err != nil {
-			return nil, err
+			return err
 		}
-		if err := uc.repo.CreateSpot(spot); err != nil {
-			return nil, err
+
+		if err := domain.AuthorizeEventManagement(ctx, event.Organization, event.PartnerID); err != nil {
+			return err
+		}
+
+		if event.Status == domain.EventStatusCancelled {
+			return domain.ErrEventCancelled
 		}
-		spots[i] = *spot
+
+		var tierID string
+		if input.Tier != "" {
+			tier, ok := event.PriceTierByName(input.Tier)
+			if !ok {
+				return fmt.Errorf("%w: %q", domain.ErrPriceTierNotFound, input.Tier)
+			}
+			tierID = tier.ID
+		}
+
+		names, err := spotNames(input, event)
+		if err != nil {
+			return err
+		}
+
+		for _, spotName := range names {
+			spot, err := event.AddSpot(spotName)
+			if err != nil {
+				return err
+			}
+			spot.TierID = tierID
+			if err := repo.CreateSpot(ctx, spot); err != nil {
+				return err
+			}
+			spots = append(spots, *spot)
+		}
+
+		// Novos spots reabrem as vendas de um evento esgotado.
+		if event.Status == domain.EventStatusSoldOut {
+			if _, err := repo.UpdateEventStatus(ctx, event.ID, domain.EventStatusSoldOut, domain.EventStatusSalesOpen); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	spotDTOs := make([]SpotDTO, len(spots))
 	for i, spot := range spots {
-		spotDTOs[i] = SpotDTO{
-			ID:       spot.ID,
-			Name:     spot.Name,
-			Status:   string(spot.Status),
-			TicketID: spot.TicketID,
-		}
+		spotDTOs[i] = newSpotDTO(event, &spot)
 	}
 
 	return &CreateSpotsOutputDTO{Spots: spotDTOs}, nil
 
 }
 
-func generateSpotName(index int) string {
-	// Gera um nome de spot baseado no índice. Ex: A1, A2, ..., B1, B2, etc.
-	letter := 'A' + rune(index/10)
-	number := index%10 + 1
-	return fmt.Sprintf("%c%d", letter, number)
+// spotNames gera os nomes dos novos spots. Com uma planta, nenhum nome pode existir no evento
+// (ErrSpotNameTaken); com uma quantidade, a planta padrão continua após os nomes já usados.
+func spotNames(input CreateSpotsInputDTO, event *domain.Event) ([]string, error) {
+	taken := make(map[string]bool, len(event.Spots))
+	for _, spot := range event.Spots {
+		taken[spot.Name] = true
+	}
+
+	if input.Layout != nil {
+		names, err := input.Layout.layout().SpotNames()
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			if taken[name] {
+				return nil, fmt.Errorf("%w: %s", domain.ErrSpotNameTaken, name)
+			}
+		}
+		return names, nil
+	}
+
+	names := make([]string, 0, input.NumberOfSpots)
+	for i := 0; len(names) < input.NumberOfSpots; i++ {
+		if name := domain.DefaultSpotName(i); !taken[name] {
+			names = append(names, name)
+		}
+	}
+	return names, nil
 }
@@ -0,0 +1,39 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCreateSpotsInputValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		input CreateSpotsInputDTO
+		want  []string // campos reportados
+	}{
+		{name: "number of spots", input: CreateSpotsInputDTO{NumberOfSpots: 10}},
+		{name: "largest number of spots", input: CreateSpotsInputDTO{NumberOfSpots: maxNumberOfSpots}},
+		{name: "no spots", input: CreateSpotsInputDTO{}, want: []string{"number_of_spots"}},
+		{name: "negative number of spots", input: CreateSpotsInputDTO{NumberOfSpots: -1}, want: []string{"number_of_spots"}},
+		{name: "too many spots", input: CreateSpotsInputDTO{NumberOfSpots: maxNumberOfSpots + 1}, want: []string{"number_of_spots"}},
+		{name: "grid layout", input: CreateSpotsInputDTO{Layout: &SpotLayoutDTO{Rows: 10, SeatsPerRow: 20, LabelScheme: "numeric", SkipLabels: []string{"13"}}}},
+		{name: "row seats", input: CreateSpotsInputDTO{Layout: &SpotLayoutDTO{Rows: 3, RowSeats: []int{10, 12, 14}}}},
+		{name: "layout together with number of spots", input: CreateSpotsInputDTO{NumberOfSpots: 10, Layout: &SpotLayoutDTO{Rows: 1, SeatsPerRow: 1}},
+			want: []string{"number_of_spots"}},
+		{name: "empty layout", input: CreateSpotsInputDTO{Layout: &SpotLayoutDTO{}}, want: []string{"layout.rows", "layout.seats_per_row"}},
+		{name: "unknown label scheme", input: CreateSpotsInputDTO{Layout: &SpotLayoutDTO{Rows: 1, SeatsPerRow: 1, LabelScheme: "roman"}}, want: []string{"layout.label_scheme"}},
+		{name: "row seats that do not match rows", input: CreateSpotsInputDTO{Layout: &SpotLayoutDTO{Rows: 2, SeatsPerRow: 5, RowSeats: []int{10, 0, 12}}},
+			want: []string{"layout.rows", "layout.seats_per_row", "layout.row_seats[1]"}},
+		{name: "blank skipped label", input: CreateSpotsInputDTO{Layout: &SpotLayoutDTO{Rows: 1, SeatsPerRow: 1, SkipLabels: []string{"I", " "}}}, want: []string{"layout.skip_labels[1]"}},
+		{name: "too many spots in the layout", input: CreateSpotsInputDTO{Layout: &SpotLayoutDTO{Rows: 50, SeatsPerRow: 41}}, want: []string{"layout"}},
+		{name: "layout whose product overflows", input: CreateSpotsInputDTO{Layout: &SpotLayoutDTO{Rows: 1 << 32, SeatsPerRow: 1 << 32}}, want: []string{"layout"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.input.Validate()
+			if got := validationFields(t, err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate fields = %v, want %v (error %v)", got, tt.want, err)
+			}
+		})
+	}
+}
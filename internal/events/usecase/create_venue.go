@@ -0,0 +1,127 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+)
+
+// CreateVenueInputDTO descreve um local e o seu mapa de assentos. Cada setor informa as fileiras
+// explicitamente em rows ou as gera com uma planta em layout. Os rótulos das fileiras precisam
+// ser únicos no local inteiro, pois formam os nomes dos spots (fileira A, assento 3: "A3").
+type CreateVenueInputDTO struct {
+	Name     string                 `json:"name"`
+	Address  string                 `json:"address"`
+	Sections []VenueSectionInputDTO `json:"sections"`
+}
+
+type VenueSectionInputDTO struct {
+	Name   string             `json:"name"`
+	Rows   []VenueRowInputDTO `json:"rows"`
+	Layout *SpotLayoutDTO     `json:"layout"`
+}
+
+type VenueRowInputDTO struct {
+	Label string `json:"label" example:"A"`
+	Seats int    `json:"seats"`
+}
+
+// maxVenueSeats limita a quantidade de assentos do mapa de um local.
+const maxVenueSeats = 10000
+
+// Validate verifica todos os campos da entrada e retorna domain.ValidationErrors com os problemas encontrados.
+func (input CreateVenueInputDTO) Validate() error {
+	var v validator
+
+	if v.required(strings.TrimSpace(input.Name), "name") {
+		v.maxLength(input.Name, 255, "name")
+	}
+	v.maxLength(input.Address, 255, "address")
+	v.check(len(input.Sections) > 0, "sections", domain.FieldRequired, "sections must not be empty")
+
+	// Cada parcela é limitada antes da soma, que assim não transborda.
+	seats, bounded := 0, true
+	for i, section := range input.Sections {
+		field := fmt.Sprintf("sections[%d]", i)
+		if v.required(strings.TrimSpace(section.Name), field+".name") {
+			v.maxLength(section.Name, 100, field+".name")
+		}
+
+		if section.Layout != nil {
+			v.check(len(section.Rows) == 0, field+".rows", domain.FieldInvalidValue, "%s.rows must not be sent together with %s.layout", field, field)
+			section.Layout.validate(&v, field+".layout", maxVenueSeats)
+			total := section.Layout.layout().TotalSpots()
+			bounded = bounded && total >= 0 && total <= maxVenueSeats
+			seats += total
+			continue
+		}
+
+		v.check(len(section.Rows) > 0, field+".rows", domain.FieldRequired, "%s.rows or %s.layout is required", field, field)
+		for j, row := range section.Rows {
+			rowField := fmt.Sprintf("%s.rows[%d]", field, j)
+			v.required(strings.TrimSpace(row.Label), rowField+".label")
+			v.check(row.Seats > 0 && row.Seats <= maxVenueSeats, rowField+".seats", domain.FieldOutOfRange, "%s.seats must be between 1 and %d", rowField, maxVenueSeats)
+			bounded = bounded && row.Seats <= maxVenueSeats
+			seats += max(row.Seats, 0)
+		}
+	}
+	v.check(bounded && seats <= maxVenueSeats, "sections", domain.FieldOutOfRange, "venue must have at most %d seats", maxVenueSeats)
+
+	return v.err()
+}
+
+// plan retorna as fileiras do setor, explícitas ou geradas pela planta.
+func (section VenueSectionInputDTO) plan() ([]domain.RowPlan, error) {
+	if section.Layout != nil {
+		return section.Layout.layout().Plan()
+	}
+	plan := make([]domain.RowPlan, len(section.Rows))
+	for i, row := range section.Rows {
+		plan[i] = domain.RowPlan{Label: row.Label, Seats: row.Seats}
+	}
+	return plan, nil
+}
+
+type CreateVenueUseCase struct {
+	repo domain.Repositories
+}
+
+func NewCreateVenueUseCase(repo domain.Repositories) *CreateVenueUseCase {
+	return &CreateVenueUseCase{repo: repo}
+}
+
+func (uc *CreateVenueUseCase) Execute(ctx context.Context, input CreateVenueInputDTO) (*VenueDTO, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	venue, err := domain.NewVenue(input.Name, input.Address)
+	if err != nil {
+		return nil, err
+	}
+	for _, section := range input.Sections {
+		plan, err := section.plan()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := venue.AddSection(section.Name, plan); err != nil {
+			return nil, err
+		}
+	}
+	if err := venue.Validate(); err != nil {
+		return nil, err
+	}
+
+	// O mapa é gravado em várias tabelas; a transação evita que um local incompleto fique visível.
+	err = uc.repo.RunInTx(ctx, func(repo domain.Repositories) error {
+		return repo.CreateVenue(ctx, venue)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	output := newVenueDTO(venue)
+	return &output, nil
+}
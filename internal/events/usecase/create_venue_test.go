@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCreateVenueInputValidate(t *testing.T) {
+	newValid := func() CreateVenueInputDTO {
+		return CreateVenueInputDTO{
+			Name:    "Estádio",
+			Address: "Rua A, 1",
+			Sections: []VenueSectionInputDTO{
+				{Name: "Pista", Rows: []VenueRowInputDTO{{Label: "A", Seats: 10}, {Label: "B", Seats: 12}}},
+				{Name: "Camarote", Layout: &SpotLayoutDTO{Rows: 2, SeatsPerRow: 5}},
+			},
+		}
+	}
+	tests := []struct {
+		name   string
+		change func(input *CreateVenueInputDTO)
+		want   []string // campos reportados
+	}{
+		{name: "valid", change: func(input *CreateVenueInputDTO) {}},
+		{name: "everything missing", change: func(input *CreateVenueInputDTO) { *input = CreateVenueInputDTO{} }, want: []string{"name", "sections"}},
+		{name: "long texts", change: func(input *CreateVenueInputDTO) {
+			input.Name, input.Address, input.Sections[0].Name = strings.Repeat("n", 256), strings.Repeat("a", 256), strings.Repeat("s", 101)
+		}, want: []string{"name", "address", "sections[0].name"}},
+		{name: "section without rows or layout", change: func(input *CreateVenueInputDTO) {
+			input.Sections[0] = VenueSectionInputDTO{Name: " "}
+		}, want: []string{"sections[0].name", "sections[0].rows"}},
+		{name: "rows together with layout", change: func(input *CreateVenueInputDTO) {
+			input.Sections[1].Rows = []VenueRowInputDTO{{Label: "A", Seats: 1}}
+		}, want: []string{"sections[1].rows"}},
+		{name: "invalid layout", change: func(input *CreateVenueInputDTO) { input.Sections[1].Layout.SeatsPerRow = 0 }, want: []string{"sections[1].layout.seats_per_row"}},
+		{name: "invalid rows", change: func(input *CreateVenueInputDTO) {
+			input.Sections[0].Rows = []VenueRowInputDTO{{Label: "", Seats: 0}, {Label: "B", Seats: maxVenueSeats + 1}}
+		}, want: []string{"sections[0].rows[0].label", "sections[0].rows[0].seats", "sections[0].rows[1].seats", "sections"}},
+		{name: "too many seats across sections", change: func(input *CreateVenueInputDTO) {
+			input.Sections[0].Rows = []VenueRowInputDTO{{Label: "A", Seats: maxVenueSeats}}
+		}, want: []string{"sections"}},
+		{name: "largest venue", change: func(input *CreateVenueInputDTO) {
+			input.Sections = []VenueSectionInputDTO{{Name: "Pista", Layout: &SpotLayoutDTO{Rows: 100, SeatsPerRow: maxVenueSeats / 100}}}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := newValid()
+			tt.change(&input)
+
+			err := input.Validate()
+			if got := validationFields(t, err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate fields = %v, want %v (error %v)", got, tt.want, err)
+			}
+		})
+	}
+}
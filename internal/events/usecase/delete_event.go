@@ -0,0 +1,41 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+)
+
+type DeleteEventInputDTO struct {
+	EventID string
+}
+
+type DeleteEventUseCase struct {
+	repo domain.Repositories
+}
+
+func NewDeleteEventUseCase(repo domain.Repositories) *DeleteEventUseCase {
+	return &DeleteEventUseCase{repo: repo}
+}
+
+// Execute remove o evento e os seus spots. Um evento com ingressos vendidos não pode ser
+// removido (ErrEventHasTickets): deve ser cancelado, para que os ingressos sejam reembolsados.
+func (uc *DeleteEventUseCase) Execute(ctx context.Context, input DeleteEventInputDTO) error {
+	return uc.repo.RunInTx(ctx, func(repo domain.Repositories) error {
+		// O bloqueio impede que uma compra emita ingressos durante a remoção.
+		if _, err := repo.LockEventStatus(ctx, input.EventID); err != nil {
+			return err
+		}
+		event, err := repo.FindEventByID(ctx, input.EventID)
+		if err != nil {
+			return err
+		}
+		if err := domain.AuthorizeEventManagement(ctx, event.Organization, event.PartnerID); err != nil {
+			return err
+		}
+		if event.HasTickets() {
+			return domain.ErrEventHasTickets
+		}
+		return repo.DeleteEvent(ctx, event.ID)
+	})
+}
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+)
+
+type DeleteSpotInputDTO struct {
+	EventID string
+	SpotID  string
+}
+
+type DeleteSpotUseCase struct {
+	repo domain.EventRepository
+}
+
+func NewDeleteSpotUseCase(repo domain.EventRepository) *DeleteSpotUseCase {
+	return &DeleteSpotUseCase{repo: repo}
+}
+
+// Execute remove um spot do evento. Spots vendidos (ErrSpotSold) ou segurados por uma sessão
+// (ErrSpotHeld) não podem ser removidos.
+func (uc *DeleteSpotUseCase) Execute(ctx context.Context, input DeleteSpotInputDTO) error {
+	event, err := uc.repo.FindEventByID(ctx, input.EventID)
+	if err != nil {
+		return err
+	}
+	if err := domain.AuthorizeEventManagement(ctx, event.Organization, event.PartnerID); err != nil {
+		return err
+	}
+
+	spots, err := uc.repo.FindSpotsByEventID(ctx, event.ID)
+	if err != nil {
+		return err
+	}
+	var spot *domain.Spot
+	for _, s := range spots {
+		if s.ID == input.SpotID {
+			spot = s
+			break
+		}
+	}
+	if spot == nil {
+		return domain.ErrSpotNotFound
+	}
+
+	if err := spot.CanDelete(time.Now()); err != nil {
+		return err
+	}
+	// Se o spot for segurado ou vendido depois da leitura, a versão não confere e nada é removido.
+	if err := uc.repo.DeleteSpot(ctx, spot); err != nil {
+		return err
+	}
+
+	// Remover o último spot não vendido esgota o evento, assim como a venda dele.
+	if event.IsOnSale() && event.HasTickets() {
+		unsold, err := uc.repo.CountUnsoldSpots(ctx, event.ID)
+		if err != nil {
+			return err
+		}
+		if unsold == 0 {
+			_, err = uc.repo.UpdateEventStatus(ctx, event.ID, domain.EventStatusSalesOpen, domain.EventStatusSoldOut)
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+)
+
+type EventLifecycleInputDTO struct {
+	EventID string `json:"-"`
+}
+
+type PostponeEventInputDTO struct {
+	EventID string `json:"-"`
+	// Date é a nova data do evento; opcional, pode ser definida depois ao republicar.
+	Date time.Time `json:"date"`
+}
+
+// Validate verifica a nova data, quando enviada, e retorna domain.ValidationErrors se ela não estiver no futuro.
+func (input PostponeEventInputDTO) Validate() error {
+	var v validator
+	v.check(input.Date.IsZero() || input.Date.After(time.Now()), "date", domain.FieldOutOfRange, "date must be in the future")
+	return v.err()
+}
+
+type EventLifecycleOutputDTO struct {
+	ID     string `json:"id"`
+	Status string `json:"status" example:"published"`
+	Date   string `json:"date"`
+	// RefundPendingTickets é a quantidade de ingressos marcados para reembolso pelo cancelamento.
+	RefundPendingTickets int64 `json:"refund_pending_tickets,omitempty"`
+}
+
+type PublishEventUseCase struct {
+	repo domain.Repositories
+}
+
+func NewPublishEventUseCase(repo domain.Repositories) *PublishEventUseCase {
+	return &PublishEventUseCase{repo: repo}
+}
+
+// Execute publica um evento em rascunho ou adiado.
+func (uc *PublishEventUseCase) Execute(ctx context.Context, input EventLifecycleInputDTO) (*EventLifecycleOutputDTO, error) {
+	event, err := modifyEvent(ctx, uc.repo, input.EventID, func(repo domain.EventRepository, event *domain.Event) error {
+		return event.Publish(time.Now())
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newEventLifecycleOutputDTO(event), nil
+}
+
+type OpenEventSalesUseCase struct {
+	repo domain.Repositories
+}
+
+func NewOpenEventSalesUseCase(repo domain.Repositories) *OpenEventSalesUseCase {
+	return &OpenEventSalesUseCase{repo: repo}
+}
+
+// Execute abre as vendas de um evento publicado.
+func (uc *OpenEventSalesUseCase) Execute(ctx context.Context, input EventLifecycleInputDTO) (*EventLifecycleOutputDTO, error) {
+	event, err := modifyEvent(ctx, uc.repo, input.EventID, func(repo domain.EventRepository, event *domain.Event) error {
+		return event.OpenSales()
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newEventLifecycleOutputDTO(event), nil
+}
+
+type PostponeEventUseCase struct {
+	repo domain.Repositories
+}
+
+func NewPostponeEventUseCase(repo domain.Repositories) *PostponeEventUseCase {
+	return &PostponeEventUseCase{repo: repo}
+}
+
+// Execute adia o evento, suspendendo as vendas; os ingressos emitidos continuam válidos.
+func (uc *PostponeEventUseCase) Execute(ctx context.Context, input PostponeEventInputDTO) (*EventLifecycleOutputDTO, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	event, err := modifyEvent(ctx, uc.repo, input.EventID, func(repo domain.EventRepository, event *domain.Event) error {
+		return event.Postpone(input.Date, time.Now())
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newEventLifecycleOutputDTO(event), nil
+}
+
+type CancelEventUseCase struct {
+	repo domain.Repositories
+}
+
+func NewCancelEventUseCase(repo domain.Repositories) *CancelEventUseCase {
+	return &CancelEventUseCase{repo: repo}
+}
+
+// Execute cancela o evento e, na mesma transação, marca todos os ingressos emitidos para reembolso.
+func (uc *CancelEventUseCase) Execute(ctx context.Context, input EventLifecycleInputDTO) (*EventLifecycleOutputDTO, error) {
+	var marked int64
+	event, err := modifyEvent(ctx, uc.repo, input.EventID, func(repo domain.EventRepository, event *domain.Event) error {
+		if err := event.Cancel(); err != nil {
+			return err
+		}
+		var err error
+		marked, err = repo.MarkEventTicketsForRefund(ctx, event.ID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	output := newEventLifecycleOutputDTO(event)
+	output.RefundPendingTickets = marked
+	return output, nil
+}
+
+func newEventLifecycleOutputDTO(event *domain.Event) *EventLifecycleOutputDTO {
+	return &EventLifecycleOutputDTO{
+		ID:     event.ID,
+		Status: string(event.Status),
+		Date:   event.Date.Format("2006-01-02 15:04:05"),
+	}
+}
+
+// modifyEvent bloqueia e carrega o evento, verifica a permissão do principal, aplica change e
+// persiste o evento, tudo em uma transação. O bloqueio serializa a alteração com as compras em
+// andamento, de modo que nenhum ingresso é emitido depois de o cancelamento marcar os reembolsos
+// nem com um preço diferente do gravado.
+func modifyEvent(
+	ctx context.Context,
+	repo domain.Repositories,
+	eventID string,
+	change func(repo domain.EventRepository, event *domain.Event) error,
+) (*domain.Event, error) {
+	var event *domain.Event
+	err := repo.RunInTx(ctx, func(repo domain.Repositories) error {
+		if _, err := repo.LockEventStatus(ctx, eventID); err != nil {
+			return err
+		}
+		var err error
+		event, err = repo.FindEventByID(ctx, eventID)
+		if err != nil {
+			return err
+		}
+		if err := domain.AuthorizeEventManagement(ctx, event.Organization, event.PartnerID); err != nil {
+			return err
+		}
+		if err := change(repo, event); err != nil {
+			return err
+		}
+		return repo.UpdateEvent(ctx, event)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return event, nil
+}
@@ -0,0 +1,27 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPostponeEventInputValidate(t *testing.T) {
+	tests := []struct {
+		name string
+		date time.Time
+		want []string // campos reportados
+	}{
+		{name: "without a new date"},
+		{name: "future date", date: time.Now().Add(24 * time.Hour)},
+		{name: "past date", date: time.Now().Add(-time.Hour), want: []string{"date"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := PostponeEventInputDTO{EventID: "event-1", Date: tt.date}.Validate()
+			if got := validationFields(t, err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate fields = %v, want %v (error %v)", got, tt.want, err)
+			}
+		})
+	}
+}
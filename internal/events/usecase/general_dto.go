@@ -1,23 +1,124 @@
 package usecase
 
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+)
+
 type EventDTO struct {
-	ID           string  `json:"id"`
-	Name         string  `json:"name"`
-	Location     string  `json:"location"`
-	Organization string  `json:"organization"`
-	Rating       string  `json:"rating"`
-	Date         string  `json:"date"`
-	ImageURL     string  `json:"image_url"`
-	Capacity     int     `json:"capacity"`
-	Price        float64 `json:"price"`
-	PartnerID    int     `json:"partner_id"`
+	ID           string      `json:"id"`
+	Name         string      `json:"name"`
+	Location     string      `json:"location"`
+	Organization string      `json:"organization"`
+	Rating       string      `json:"rating"`
+	Date         string      `json:"date"`
+	ImageURL     string      `json:"image_url"`
+	Capacity     int         `json:"capacity"`
+	Price        json.Number `json:"price" swaggertype:"number" example:"100.00"`
+	Currency     string      `json:"currency" example:"BRL"`
+	PartnerID    int         `json:"partner_id"`
+	VenueID      string      `json:"venue_id,omitempty"`
+	Status       string      `json:"status" example:"sales_open"`
+}
+
+func newEventDTO(event *domain.Event) EventDTO {
+	return EventDTO{
+		ID:           event.ID,
+		Name:         event.Name,
+		Location:     event.Location,
+		Organization: event.Organization,
+		Rating:       string(event.Rating),
+		Date:         event.Date.Format("2006-01-02 15:04:05"),
+		ImageURL:     event.ImageURL,
+		Capacity:     event.Capacity,
+		Price:        moneyNumber(event.Price),
+		Currency:     string(event.Price.Currency),
+		PartnerID:    event.PartnerID,
+		VenueID:      event.VenueID,
+		Status:       string(event.Status),
+	}
+}
+
+// moneyNumber representa o valor como um número JSON exato, com as casas decimais da moeda.
+func moneyNumber(m domain.Money) json.Number {
+	return json.Number(m.String())
+}
+
+type PriceTierDTO struct {
+	ID       string      `json:"id"`
+	Name     string      `json:"name" example:"VIP"`
+	Price    json.Number `json:"price" swaggertype:"number" example:"250.00"`
+	Currency string      `json:"currency" example:"BRL"`
 }
 
+func newPriceTierDTOs(tiers []domain.PriceTier) []PriceTierDTO {
+	dtos := make([]PriceTierDTO, len(tiers))
+	for i, tier := range tiers {
+		dtos[i] = PriceTierDTO{ID: tier.ID, Name: tier.Name, Price: moneyNumber(tier.Price), Currency: string(tier.Price.Currency)}
+	}
+	return dtos
+}
+
+type TicketKindDTO struct {
+	Kind            string `json:"kind" example:"student"`
+	Name            string `json:"name" example:"Meia-entrada estudante"`
+	DiscountPercent int    `json:"discount_percent" example:"50"`
+	QuotaPercent    int    `json:"quota_percent" example:"40"`
+	Document        string `json:"document,omitempty" example:"student_id"`
+	CompanionSeats  int    `json:"companion_seats"`
+}
+
+func newTicketKindDTOs(rules []domain.TicketKindRule) []TicketKindDTO {
+	dtos := make([]TicketKindDTO, len(rules))
+	for i, rule := range rules {
+		dtos[i] = TicketKindDTO{
+			Kind:            string(rule.Kind),
+			Name:            rule.Name,
+			DiscountPercent: int(rule.Discount / 100),
+			QuotaPercent:    rule.QuotaPercent,
+			Document:        string(rule.Document),
+			CompanionSeats:  rule.CompanionSeats,
+		}
+	}
+	return dtos
+}
+
+// SpotDTO traz o preço cheio do spot (o da sua categoria ou o do evento), antes do desconto do tipo de ingresso.
 type SpotDTO struct {
-	ID       string `json:"id"`
-	Name     string `json:"name"`
-	EventID  string `json:"event_id"`
-	Reserved bool   `json:"reserved"`
-	Status   string `json:"Status"`
-	TicketID string `json:"ticket_id"`
+	ID            string      `json:"id"`
+	Name          string      `json:"name"`
+	EventID       string      `json:"event_id"`
+	SectionID     string      `json:"section_id,omitempty"`
+	Tier          string      `json:"tier,omitempty" example:"VIP"`
+	Price         json.Number `json:"price" swaggertype:"number" example:"100.00"`
+	Currency      string      `json:"currency" example:"BRL"`
+	Reserved      bool        `json:"reserved"`
+	Status        string      `json:"Status"`
+	TicketID      string      `json:"ticket_id"`
+	HoldExpiresAt *time.Time  `json:"hold_expires_at,omitempty"`
+}
+
+// newSpotDTO requer Event.Tiers carregado (FindEventByID) para calcular o preço.
+func newSpotDTO(event *domain.Event, spot *domain.Spot) SpotDTO {
+	price := event.SpotPrice(spot)
+	dto := SpotDTO{
+		ID:        spot.ID,
+		Name:      spot.Name,
+		EventID:   spot.EventID,
+		SectionID: spot.SectionID,
+		Price:     moneyNumber(price),
+		Currency:  string(price.Currency),
+		Status:    string(spot.Status),
+		TicketID:  spot.TicketID,
+	}
+	if tier, ok := event.PriceTier(spot.TierID); ok {
+		dto.Tier = tier.Name
+	}
+	if spot.Status == domain.SpotStatusReserved {
+		expiresAt := spot.HoldExpiresAt
+		dto.HoldExpiresAt = &expiresAt
+	}
+	return dto
 }
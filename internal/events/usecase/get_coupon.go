@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+)
+
+type GetCouponInputDTO struct {
+	Code string `json:"code"`
+}
+
+// CouponDTO apresenta o cupom; redemptions é quantas compras já o usaram.
+type CouponDTO struct {
+	ID                     string      `json:"id"`
+	Code                   string      `json:"code" example:"BLACKFRIDAY"`
+	DiscountType           string      `json:"discount_type" example:"percentage"`
+	DiscountPercent        int         `json:"discount_percent,omitempty" example:"20"`
+	DiscountAmount         json.Number `json:"discount_amount,omitempty" swaggertype:"number" example:"15.00"`
+	Currency               string      `json:"currency,omitempty" example:"BRL"`
+	ValidFrom              time.Time   `json:"valid_from"`
+	ValidUntil             *time.Time  `json:"valid_until,omitempty"`
+	EventID                string      `json:"event_id,omitempty"`
+	Organization           string      `json:"organization"`
+	MaxRedemptions         int         `json:"max_redemptions"`
+	MaxRedemptionsPerEmail int         `json:"max_redemptions_per_email"`
+	StacksWithCoupons      bool        `json:"stacks_with_coupons"`
+	StacksWithTicketKinds  bool        `json:"stacks_with_ticket_kinds"`
+	Redemptions            int         `json:"redemptions"`
+}
+
+func newCouponDTO(coupon *domain.Coupon, usage domain.CouponUsage) CouponDTO {
+	dto := CouponDTO{
+		ID:                     coupon.ID,
+		Code:                   coupon.Code,
+		DiscountType:           string(coupon.DiscountType),
+		ValidFrom:              coupon.ValidFrom,
+		EventID:                coupon.EventID,
+		Organization:           coupon.Organization,
+		MaxRedemptions:         coupon.MaxRedemptions,
+		MaxRedemptionsPerEmail: coupon.MaxRedemptionsPerEmail,
+		StacksWithCoupons:      coupon.StacksWithCoupons,
+		StacksWithTicketKinds:  coupon.StacksWithTicketKinds,
+		Redemptions:            usage.Total,
+	}
+	if coupon.DiscountType == domain.CouponDiscountFixed {
+		dto.DiscountAmount = moneyNumber(coupon.Amount)
+		dto.Currency = string(coupon.Amount.Currency)
+	} else {
+		dto.DiscountPercent = int(coupon.Percent / 100)
+	}
+	if !coupon.ValidUntil.IsZero() {
+		validUntil := coupon.ValidUntil
+		dto.ValidUntil = &validUntil
+	}
+	return dto
+}
+
+type GetCouponUseCase struct {
+	repo domain.Repositories
+}
+
+func NewGetCouponUseCase(repo domain.Repositories) *GetCouponUseCase {
+	return &GetCouponUseCase{repo: repo}
+}
+
+// Execute retorna o cupom e o seu uso; apenas quem pode criá-lo pode consultá-lo.
+func (uc *GetCouponUseCase) Execute(ctx context.Context, input GetCouponInputDTO) (*CouponDTO, error) {
+	coupon, err := uc.repo.FindCouponByCode(ctx, domain.NormalizeCouponCode(input.Code))
+	if err != nil {
+		return nil, err
+	}
+
+	partnerID := 0
+	if coupon.EventID != "" {
+		event, err := uc.repo.FindEventByID(ctx, coupon.EventID)
+		if err != nil {
+			return nil, err
+		}
+		partnerID = event.PartnerID
+	}
+	if err := domain.AuthorizeEventManagement(ctx, coupon.Organization, partnerID); err != nil {
+		return nil, err
+	}
+
+	usage, err := uc.repo.CountCouponRedemptions(ctx, coupon.ID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	output := newCouponDTO(coupon, usage)
+	return &output, nil
+}
@@ -1,22 +1,34 @@
 package usecase
 
-import "github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+)
 
 type GetEventInputDTO struct {
 	ID string
 }
 
 type GetEventOutputDTO struct {
-	ID           string  `json:"id"`
-	Name         string  `json:"name"`
-	Location     string  `json:"location"`
-	Organization string  `json:"organization"`
-	Rating       string  `json:"rating"`
-	Date         string  `json:"date"`
-	ImageURL     string  `json:"image_url"`
-	Capacity     int     `json:"capacity"`
-	Price        float64 `json:"price"`
-	PartnerID    int     `json:"partner_id"`
+	ID           string          `json:"id"`
+	Name         string          `json:"name"`
+	Location     string          `json:"location"`
+	Organization string          `json:"organization"`
+	Rating       string          `json:"rating"`
+	Date         string          `json:"date"`
+	ImageURL     string          `json:"image_url"`
+	Capacity     int             `json:"capacity"`
+	Price        json.Number     `json:"price" swaggertype:"number" example:"100.00"`
+	Currency     string          `json:"currency" example:"BRL"`
+	PartnerID    int             `json:"partner_id"`
+	VenueID      string          `json:"venue_id,omitempty"`
+	Status       string          `json:"status" example:"sales_open"`
+	Tiers        []PriceTierDTO  `json:"tiers"`
+	TicketKinds  []TicketKindDTO `json:"ticket_kinds"`
+	// RemainingCapacity é quantos ingressos ainda podem ser vendidos: Capacity menos os ingressos vendidos.
+	RemainingCapacity int `json:"remaining_capacity"`
 }
 
 type GetEventUseCase struct {
@@ -27,8 +39,8 @@ func NewGetEventUseCase(repo domain.EventRepository) *GetEventUseCase {
 	return &GetEventUseCase{repo: repo}
 }
 
-func (uc *GetEventUseCase) Execute(input GetEventInputDTO) (*GetEventOutputDTO, error) {
-	event, err := uc.repo.FindEventByID(input.ID)
+func (uc *GetEventUseCase) Execute(ctx context.Context, input GetEventInputDTO) (*GetEventOutputDTO, error) {
+	event, err := uc.repo.FindEventByID(ctx, input.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -42,7 +54,14 @@ func (uc *GetEventUseCase) Execute(input GetEventInputDTO) (*GetEventOutputDTO,
 		ImageURL:     event.ImageURL,
 		Date:         event.Date.Format("2006-01-02 15:04:05"),
 		Capacity:     event.Capacity,
-		Price:        event.Price,
+		Price:        moneyNumber(event.Price),
+		Currency:     string(event.Price.Currency),
 		PartnerID:    event.PartnerID,
+		VenueID:      event.VenueID,
+		Status:       string(event.Status),
+
+		Tiers:             newPriceTierDTOs(event.Tiers),
+		TicketKinds:       newTicketKindDTOs(event.TicketKindRules()),
+		RemainingCapacity: event.RemainingCapacity(),
 	}, nil
 }
@@ -0,0 +1,69 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+)
+
+type GetVenueInputDTO struct {
+	ID string `json:"id"`
+}
+
+// VenueDTO apresenta o mapa do local; os assentos de cada fileira são numerados de 1 a Seats.
+type VenueDTO struct {
+	ID        string            `json:"id"`
+	Name      string            `json:"name"`
+	Address   string            `json:"address"`
+	SeatCount int               `json:"seat_count"`
+	Sections  []VenueSectionDTO `json:"sections"`
+}
+
+type VenueSectionDTO struct {
+	ID        string        `json:"id"`
+	Name      string        `json:"name"`
+	SeatCount int           `json:"seat_count"`
+	Rows      []VenueRowDTO `json:"rows"`
+}
+
+type VenueRowDTO struct {
+	Label string `json:"label" example:"A"`
+	Seats int    `json:"seats"`
+}
+
+func newVenueDTO(venue *domain.Venue) VenueDTO {
+	dto := VenueDTO{
+		ID:        venue.ID,
+		Name:      venue.Name,
+		Address:   venue.Address,
+		SeatCount: venue.SeatCount(),
+		Sections:  make([]VenueSectionDTO, len(venue.Sections)),
+	}
+	for i, section := range venue.Sections {
+		sectionDTO := VenueSectionDTO{ID: section.ID, Name: section.Name, Rows: make([]VenueRowDTO, len(section.Rows))}
+		for j, row := range section.Rows {
+			sectionDTO.Rows[j] = VenueRowDTO{Label: row.Label, Seats: len(row.Seats)}
+			sectionDTO.SeatCount += len(row.Seats)
+		}
+		dto.Sections[i] = sectionDTO
+	}
+	return dto
+}
+
+type GetVenueUseCase struct {
+	repo domain.VenueRepository
+}
+
+func NewGetVenueUseCase(repo domain.VenueRepository) *GetVenueUseCase {
+	return &GetVenueUseCase{repo: repo}
+}
+
+func (uc *GetVenueUseCase) Execute(ctx context.Context, input GetVenueInputDTO) (*VenueDTO, error) {
+	venue, err := uc.repo.FindVenueByID(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	output := newVenueDTO(venue)
+	return &output, nil
+}
@@ -0,0 +1,107 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultHoldMinutes = 10
+	maxHoldMinutes     = 30
+	// maxSessionIDLength é o tamanho de spots.hold_owner, onde a sessão é gravada quando não há principal.
+	maxSessionIDLength = 64
+)
+
+type HoldSpotsInputDTO struct {
+	EventID   string   `json:"event_id"`
+	SessionID string   `json:"session_id"`
+	Spots     []string `json:"spots"`
+	Minutes   int      `json:"minutes"` // de 1 a 30; 0 usa o padrão de 10 minutos
+}
+
+// Validate verifica todos os campos da entrada e retorna domain.ValidationErrors com os problemas encontrados.
+// Minutes zero usa defaultHoldMinutes.
+func (input HoldSpotsInputDTO) Validate() error {
+	var v validator
+	v.required(input.EventID, "event_id")
+	v.maxLength(input.SessionID, maxSessionIDLength, "session_id")
+	v.uniqueNames(input.Spots, maxSpotsPerCheckout, "spots")
+	v.check(input.Minutes >= 0 && input.Minutes <= maxHoldMinutes, "minutes", domain.FieldOutOfRange, "minutes must be between 1 and %d, or 0 for the default of %d", maxHoldMinutes, defaultHoldMinutes)
+	return v.err()
+}
+
+type HoldSpotsOutputDTO struct {
+	SessionID string    `json:"session_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Spots     []SpotDTO `json:"spots"`
+}
+
+type HoldSpotsUseCase struct {
+	repo domain.Repositories
+}
+
+func NewHoldSpotsUseCase(repo domain.Repositories) *HoldSpotsUseCase {
+	return &HoldSpotsUseCase{repo: repo}
+}
+
+// Execute segura todos os spots informados para a sessão, ou nenhum deles.
+// Quando session_id não é enviado, uma nova sessão é gerada e devolvida na resposta.
+func (uc *HoldSpotsUseCase) Execute(ctx context.Context, input HoldSpotsInputDTO) (*HoldSpotsOutputDTO, error) {
+	if err := input.Validate(); err != nil {
+		return nil, err
+	}
+
+	minutes := input.Minutes
+	if minutes == 0 {
+		minutes = defaultHoldMinutes
+	}
+
+	sessionID := input.SessionID
+	if sessionID == "" {
+		sessionID = uuid.New().String()
+	}
+
+	event, err := uc.repo.FindEventByID(ctx, input.EventID)
+	if err != nil {
+		return nil, err
+	}
+	if !event.IsOnSale() {
+		return nil, domain.ErrEventNotOnSale
+	}
+
+	// A retenção pertence à sessão do usuário autenticado, não apenas ao session_id.
+	owner := domain.SpotHoldOwner(ctx, sessionID)
+	now := time.Now()
+	expiresAt := now.Add(time.Duration(minutes) * time.Minute)
+
+	spots := make([]*domain.Spot, len(input.Spots))
+	err = uc.repo.RunInTx(ctx, func(repo domain.Repositories) error {
+		for i, name := range input.Spots {
+			spot, err := repo.FindSpotByName(ctx, event.ID, name)
+			if err != nil {
+				return err
+			}
+			if err := spot.Hold(owner, expiresAt, now); err != nil {
+				return err
+			}
+			if err := repo.HoldSpot(ctx, spot); err != nil {
+				return err
+			}
+			spots[i] = spot
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	spotDTOs := make([]SpotDTO, len(spots))
+	for i, spot := range spots {
+		spotDTOs[i] = newSpotDTO(event, spot)
+	}
+
+	return &HoldSpotsOutputDTO{SessionID: sessionID, ExpiresAt: expiresAt, Spots: spotDTOs}, nil
+}
@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/infra/repository"
+)
+
+func TestHoldSpotsInputValidate(t *testing.T) {
+	valid := HoldSpotsInputDTO{EventID: "event-1", SessionID: "session-1", Spots: []string{"A1", "A2"}, Minutes: 10}
+	tests := []struct {
+		name   string
+		change func(input *HoldSpotsInputDTO)
+		want   []string // campos reportados
+	}{
+		{name: "valid", change: func(input *HoldSpotsInputDTO) {}},
+		{name: "default minutes and generated session", change: func(input *HoldSpotsInputDTO) { input.Minutes, input.SessionID = 0, "" }},
+		{name: "missing event", change: func(input *HoldSpotsInputDTO) { input.EventID = "" }, want: []string{"event_id"}},
+		{name: "no spots", change: func(input *HoldSpotsInputDTO) { input.Spots = nil }, want: []string{"spots"}},
+		{name: "duplicated spot", change: func(input *HoldSpotsInputDTO) { input.Spots = []string{"A1", "A2", "A1"} }, want: []string{"spots[2]"}},
+		{name: "empty spot name", change: func(input *HoldSpotsInputDTO) { input.Spots = []string{"A1", ""} }, want: []string{"spots[1]"}},
+		{name: "too many spots", change: func(input *HoldSpotsInputDTO) {
+			input.Spots = make([]string, maxSpotsPerCheckout+1)
+			for i := range input.Spots {
+				input.Spots[i] = domain.DefaultSpotName(i)
+			}
+		}, want: []string{"spots"}},
+		{name: "shortest hold", change: func(input *HoldSpotsInputDTO) { input.Minutes = 1 }},
+		{name: "longest hold", change: func(input *HoldSpotsInputDTO) { input.Minutes = maxHoldMinutes }},
+		{name: "negative minutes", change: func(input *HoldSpotsInputDTO) { input.Minutes = -1 }, want: []string{"minutes"}},
+		{name: "too many minutes", change: func(input *HoldSpotsInputDTO) { input.Minutes = maxHoldMinutes + 1 }, want: []string{"minutes"}},
+		{name: "longest session", change: func(input *HoldSpotsInputDTO) { input.SessionID = strings.Repeat("s", maxSessionIDLength) }},
+		{name: "session longer than the column", change: func(input *HoldSpotsInputDTO) { input.SessionID = strings.Repeat("s", maxSessionIDLength+1) }, want: []string{"session_id"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := valid
+			input.Spots = append([]string(nil), valid.Spots...)
+			tt.change(&input)
+
+			err := input.Validate()
+			if got := validationFields(t, err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate fields = %v, want %v (error %v)", got, tt.want, err)
+			}
+		})
+	}
+}
+
+func TestHoldSpotsRejectsInvalidInputBeforeTouchingSpots(t *testing.T) {
+	repo := repository.NewMemoryEventRepository()
+	event := newOnSaleEvent(t, repo, "A1")
+
+	_, err := NewHoldSpotsUseCase(repo).Execute(context.Background(), HoldSpotsInputDTO{EventID: event.ID, Spots: []string{"A1", "A1"}})
+	var validation domain.ValidationErrors
+	if !errors.As(err, &validation) {
+		t.Fatalf("Execute error = %v, want domain.ValidationErrors", err)
+	}
+	spot, err := repo.FindSpotByName(context.Background(), event.ID, "A1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if spot.HoldOwner != "" {
+		t.Errorf("spot A1 held by %q after an invalid request", spot.HoldOwner)
+	}
+}
+
+func TestHoldSpotsZeroMinutesUsesTheDefaultDuration(t *testing.T) {
+	repo := repository.NewMemoryEventRepository()
+	event := newOnSaleEvent(t, repo, "A1")
+
+	before := time.Now()
+	output, err := NewHoldSpotsUseCase(repo).Execute(context.Background(), HoldSpotsInputDTO{EventID: event.ID, Spots: []string{"A1"}, Minutes: 0})
+	if err != nil {
+		t.Fatalf("Execute error = %v", err)
+	}
+	want := before.Add(defaultHoldMinutes * time.Minute)
+	if output.ExpiresAt.Before(want) || output.ExpiresAt.After(want.Add(time.Minute)) {
+		t.Errorf("ExpiresAt = %v, want about %v", output.ExpiresAt, want)
+	}
+}
+
+// validationFields retorna os campos reportados por err, que deve ser nil ou domain.ValidationErrors.
+func validationFields(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+	var validation domain.ValidationErrors
+	if !errors.As(err, &validation) {
+		t.Fatalf("error = %v, want domain.ValidationErrors", err)
+	}
+	fields := make([]string, len(validation))
+	for i, fieldErr := range validation {
+		fields[i] = fieldErr.Field
+	}
+	return fields
+}
@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+)
+
+const (
+	// idempotencyWaitTimeout é quanto uma repetição concorrente aguarda a requisição original
+	// terminar antes de receber domain.ErrIdempotencyKeyInProgress.
+	idempotencyWaitTimeout  = 10 * time.Second
+	idempotencyPollInterval = 200 * time.Millisecond
+)
+
+// runIdempotent executa fn no máximo uma vez por chave e principal. Uma repetição com a mesma
+// requisição recebe a resposta original (replayed = true); com uma requisição diferente recebe
+// domain.ErrIdempotencyKeyMismatch. Se fn falhar a chave é liberada para uma nova tentativa.
+func runIdempotent[T any](ctx context.Context, repo domain.IdempotencyRepository, key string, request any, fn func() (*T, error)) (output *T, replayed bool, err error) {
+	fingerprint, err := requestFingerprint(request)
+	if err != nil {
+		return nil, false, err
+	}
+
+	record, err := domain.NewIdempotencyKey(key, fingerprint)
+	if err != nil {
+		return nil, false, err
+	}
+	key = scopedIdempotencyKey(ctx, key)
+	record.Key = key
+
+	deadline := time.Now().Add(idempotencyWaitTimeout)
+	for {
+		err := repo.CreateIdempotencyKey(ctx, record)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, domain.ErrIdempotencyKeyExists) {
+			return nil, false, err
+		}
+
+		existing, err := repo.FindIdempotencyKey(ctx, key)
+		if errors.Is(err, domain.ErrIdempotencyKeyNotFound) {
+			continue // a execução original falhou e liberou a chave
+		}
+		if err != nil {
+			return nil, false, err
+		}
+
+		if existing.Fingerprint != fingerprint {
+			return nil, false, domain.ErrIdempotencyKeyMismatch
+		}
+		if existing.Status == domain.IdempotencyKeyCompleted {
+			var stored T
+			if err := json.Unmarshal(existing.Response, &stored); err != nil {
+				return nil, false, err
+			}
+			return &stored, true, nil
+		}
+
+		// A requisição original ainda está em execução: aguarda até o prazo.
+		if time.Now().After(deadline) {
+			return nil, false, domain.ErrIdempotencyKeyInProgress
+		}
+		select {
+		case <-ctx.Done():
+			return nil, false, ctx.Err()
+		case <-time.After(idempotencyPollInterval):
+		}
+	}
+
+	// O registro do resultado não deve ser interrompido se o cliente desconectar.
+	storeCtx := context.WithoutCancel(ctx)
+
+	output, err = fn()
+	if err != nil {
+		if deleteErr := repo.DeleteIdempotencyKey(storeCtx, key); deleteErr != nil {
+			log.Printf("idempotency key %q: erro ao liberar chave: %v", key, deleteErr)
+		}
+		return nil, false, err
+	}
+
+	response, err := json.Marshal(output)
+	if err != nil {
+		return nil, false, err
+	}
+	record.Complete(response)
+	if err := repo.CompleteIdempotencyKey(storeCtx, record); err != nil {
+		// A operação já foi concluída: a falha afeta apenas as repetições futuras.
+		log.Printf("idempotency key %q: erro ao armazenar resposta: %v", key, err)
+	}
+	return output, false, nil
+}
+
+// scopedIdempotencyKey restringe a chave ao principal autenticado: quem usar a mesma chave que
+// outro usuário não recebe a resposta dele nem bloqueia as suas compras. A chave armazenada é o
+// SHA-256 do subject e da chave, que sempre cabe na coluna idempotency_key.
+// Sem principal (autenticação desativada), a chave é usada como enviada.
+func scopedIdempotencyKey(ctx context.Context, key string) string {
+	principal, ok := domain.PrincipalFromContext(ctx)
+	if !ok {
+		return key
+	}
+	sum := sha256.Sum256([]byte(principal.Subject + "\x00" + key))
+	return hex.EncodeToString(sum[:])
+}
+
+// requestFingerprint identifica o conteúdo da requisição para detectar uma chave reutilizada com outro corpo.
+func requestFingerprint(request any) (string, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,42 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/infra/repository"
+)
+
+type idempotentOutput struct {
+	Subject string `json:"subject"`
+}
+
+func TestRunIdempotentScopesKeysByPrincipal(t *testing.T) {
+	repo := repository.NewMemoryEventRepository()
+	request := map[string]string{"event_id": "1"}
+	run := func(ctx context.Context, subject string) (*idempotentOutput, bool) {
+		t.Helper()
+		output, replayed, err := runIdempotent(ctx, repo, "key-1", request, func() (*idempotentOutput, error) {
+			return &idempotentOutput{Subject: subject}, nil
+		})
+		if err != nil {
+			t.Fatalf("%s: %v", subject, err)
+		}
+		return output, replayed
+	}
+	alice := domain.ContextWithPrincipal(context.Background(), &domain.Principal{Subject: "alice"})
+	bob := domain.ContextWithPrincipal(context.Background(), &domain.Principal{Subject: "bob"})
+
+	if _, replayed := run(alice, "alice"); replayed {
+		t.Fatal("first request by alice was replayed")
+	}
+	if output, replayed := run(alice, "alice again"); !replayed || output.Subject != "alice" {
+		t.Errorf("repeat by alice = %+v (replayed %v), want alice's original response", output, replayed)
+	}
+
+	// Bob usa a mesma chave e o mesmo corpo: executa a própria requisição, sem ver a resposta de alice.
+	if output, replayed := run(bob, "bob"); replayed || output.Subject != "bob" {
+		t.Errorf("request by bob = %+v (replayed %v), want a new execution", output, replayed)
+	}
+}
@@ -1,9 +1,125 @@
 package usecase
 
-import "github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+)
+
+const (
+	defaultEventsPageSize = 20
+	maxEventsPageSize     = 100
+)
+
+// ListEventsInputDTO espelha os parâmetros de consulta de GET /events; todos são opcionais.
+// As datas aceitam RFC 3339 ou AAAA-MM-DD (date_to inclui o dia inteiro). Sort é um de
+// date, price ou name, com o prefixo "-" para ordem decrescente (padrão: date). Valores em moedas
+// diferentes não são comparáveis, então a faixa de preço e a ordenação por preço exigem Currency,
+// que também filtra os eventos por ela.
+// Status é uma lista separada por vírgulas; rascunhos nunca são listados.
+type ListEventsInputDTO struct {
+	DateFrom        string `json:"date_from"`
+	DateTo          string `json:"date_to"`
+	Location        string `json:"location"`
+	Organization    string `json:"organization"`
+	Rating          string `json:"rating"`
+	PartnerID       string `json:"partner_id"`
+	Currency        string `json:"currency"`
+	Status          string `json:"status"`
+	PriceMin        string `json:"price_min"`
+	PriceMax        string `json:"price_max"`
+	HasAvailability string `json:"has_availability"`
+	Sort            string `json:"sort"`
+	Limit           string `json:"limit"`
+	Cursor          string `json:"cursor"`
+}
+
+// Query valida a entrada e a converte na consulta do repositório.
+func (input ListEventsInputDTO) Query(now time.Time) (domain.EventQuery, error) {
+	var v validator
+	q := domain.EventQuery{
+		Location:     input.Location,
+		Organization: input.Organization,
+		Rating:       domain.Rating(input.Rating),
+		Now:          now,
+		SortBy:       domain.EventSortByDate,
+		Limit:        defaultEventsPageSize,
+	}
+
+	q.DateFrom = v.queryDate(input.DateFrom, "date_from", false)
+	q.DateTo = v.queryDate(input.DateTo, "date_to", true)
+	v.check(q.DateFrom.IsZero() || q.DateTo.IsZero() || !q.DateTo.Before(q.DateFrom), "date_to", domain.FieldOutOfRange, "date_to must not be before date_from")
+
+	v.maxLength(input.Location, 255, "location")
+	v.maxLength(input.Organization, 255, "organization")
+	v.check(input.Rating == "" || q.Rating.IsValid(), "rating", domain.FieldInvalidValue, "rating must be one of L, L10, L12, L14, L16 or L18")
+
+	q.Statuses = publicEventStatuses
+	if input.Status != "" {
+		q.Statuses = nil
+		valid := true
+		for _, value := range strings.Split(input.Status, ",") {
+			status := domain.EventStatus(strings.TrimSpace(value))
+			valid = valid && status.IsValid() && status != domain.EventStatusDraft
+			q.Statuses = append(q.Statuses, status)
+		}
+		v.check(valid, "status", domain.FieldInvalidValue, "status must be a comma-separated list of published, sales_open, sold_out, cancelled or postponed")
+	}
+
+	q.PartnerID = v.queryInt(input.PartnerID, "partner_id", 1, 0)
+	if input.PriceMin != "" || input.PriceMax != "" || strings.TrimPrefix(input.Sort, "-") == string(domain.EventSortByPrice) {
+		v.check(input.Currency != "", "currency", domain.FieldRequired, "currency is required to filter or sort by price")
+	}
+	if input.Currency != "" {
+		q.Currency = v.currency(input.Currency, "currency")
+	}
+	q.PriceMin = v.queryPrice(input.PriceMin, q.Currency, "price_min")
+	q.PriceMax = v.queryPrice(input.PriceMax, q.Currency, "price_max")
+	v.check(q.PriceMin == 0 || q.PriceMax == 0 || q.PriceMax >= q.PriceMin, "price_max", domain.FieldOutOfRange, "price_max must not be less than price_min")
+
+	if input.HasAvailability != "" {
+		hasAvailability, err := strconv.ParseBool(input.HasAvailability)
+		v.check(err == nil, "has_availability", domain.FieldInvalidFormat, "has_availability must be true or false")
+		q.HasAvailability = hasAvailability
+	}
+
+	if input.Sort != "" {
+		q.SortBy = domain.EventSortField(strings.TrimPrefix(input.Sort, "-"))
+		q.SortDesc = strings.HasPrefix(input.Sort, "-")
+		v.check(q.SortBy.IsValid(), "sort", domain.FieldInvalidValue, "sort must be one of date, price or name, optionally prefixed with -")
+	}
+
+	if input.Limit != "" {
+		q.Limit = v.queryInt(input.Limit, "limit", 1, maxEventsPageSize)
+	}
+
+	if input.Cursor != "" && q.SortBy.IsValid() {
+		after, err := decodeEventsCursor(input.Cursor, q)
+		v.check(err == nil, "cursor", domain.FieldInvalidValue, "cursor is invalid or does not match sort")
+		q.After = after
+	}
+
+	return q, v.err()
+}
+
+// publicEventStatuses são os estados listados quando o filtro status não é informado.
+var publicEventStatuses = []domain.EventStatus{
+	domain.EventStatusPublished,
+	domain.EventStatusSalesOpen,
+	domain.EventStatusSoldOut,
+	domain.EventStatusCancelled,
+	domain.EventStatusPostponed,
+}
 
 type ListEventsOutputDTO struct {
 	Events []EventDTO `json:"events"`
+	// NextCursor deve ser enviado como cursor para obter a próxima página; vazio na última página.
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 type ListEventsUseCase struct {
@@ -14,27 +130,89 @@ func NewListEventsUseCase(repo domain.EventRepository) *ListEventsUseCase {
 	return &ListEventsUseCase{repo: repo}
 }
 
-func (uc *ListEventsUseCase) Execute() (*ListEventsOutputDTO, error) {
-	events, err := uc.repo.ListEvents()
+func (uc *ListEventsUseCase) Execute(ctx context.Context, input ListEventsInputDTO) (*ListEventsOutputDTO, error) {
+	query, err := input.Query(time.Now())
 	if err != nil {
 		return nil, err
 	}
 
-	eventDTOs := make([]EventDTO, len(events))
-	for i, event := range events {
-		eventDTOs[i] = EventDTO{
-			ID:           event.ID,
-			Name:         event.Name,
-			Location:     event.Location,
-			Organization: event.Organization,
-			Rating:       string(event.Rating),
-			Date:         event.Date.Format("2006-01-02 15:04:05"),
-			ImageURL:     event.ImageURL,
-			Capacity:     event.Capacity,
-			Price:        event.Price,
-			PartnerID:    event.PartnerID,
-		}
+	// Um evento a mais indica se existe uma próxima página.
+	pageSize := query.Limit
+	query.Limit++
+	events, err := uc.repo.ListEvents(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	output := &ListEventsOutputDTO{}
+	if len(events) > pageSize {
+		events = events[:pageSize]
+		output.NextCursor = encodeEventsCursor(events[pageSize-1].Cursor(), query)
+	}
+
+	output.Events = make([]EventDTO, len(events))
+	for i := range events {
+		output.Events[i] = newEventDTO(&events[i])
+	}
+
+	return output, nil
+}
+
+// eventsCursor é o conteúdo do cursor opaco: a ordenação em que foi gerado e a posição do
+// último evento da página. Value guarda apenas o campo da ordenação; Currency, a moeda do preço
+// quando a ordenação é por preço.
+type eventsCursor struct {
+	Sort     domain.EventSortField `json:"s"`
+	Desc     bool                  `json:"d,omitempty"`
+	Currency domain.Currency       `json:"c,omitempty"`
+	ID       string                `json:"id"`
+	Value    string                `json:"v"`
+}
+
+func encodeEventsCursor(position domain.EventCursor, q domain.EventQuery) string {
+	cursor := eventsCursor{Sort: q.SortBy, Desc: q.SortDesc, ID: position.ID}
+	switch q.SortBy {
+	case domain.EventSortByDate:
+		cursor.Value = position.Date.Format(time.RFC3339Nano)
+	case domain.EventSortByPrice:
+		cursor.Currency = q.Currency
+		cursor.Value = strconv.FormatInt(position.Price, 10)
+	case domain.EventSortByName:
+		cursor.Value = position.Name
 	}
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
 
-	return &ListEventsOutputDTO{Events: eventDTOs}, nil
+// decodeEventsCursor rejeita cursores gerados para outra ordenação (ou, por preço, para outra moeda),
+// já que a posição não faria sentido.
+func decodeEventsCursor(value string, q domain.EventQuery) (*domain.EventCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+	var cursor eventsCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return nil, err
+	}
+	if cursor.Sort != q.SortBy || cursor.Desc != q.SortDesc || cursor.ID == "" {
+		return nil, domain.ErrInvalidInput
+	}
+	if q.SortBy == domain.EventSortByPrice && cursor.Currency != q.Currency {
+		return nil, domain.ErrInvalidInput
+	}
+
+	position := &domain.EventCursor{ID: cursor.ID}
+	switch q.SortBy {
+	case domain.EventSortByDate:
+		position.Date, err = time.Parse(time.RFC3339Nano, cursor.Value)
+	case domain.EventSortByPrice:
+		position.Price, err = strconv.ParseInt(cursor.Value, 10, 64)
+	case domain.EventSortByName:
+		position.Name = cursor.Value
+	}
+	if err != nil {
+		return nil, err
+	}
+	return position, nil
 }
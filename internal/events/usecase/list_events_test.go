@@ -0,0 +1,78 @@
+package usecase
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+)
+
+func TestListEventsInputQuery(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	nameCursor := encodeEventsCursor(domain.EventCursor{ID: "event-1", Name: "Show"}, domain.EventQuery{SortBy: domain.EventSortByName})
+	priceCursor := encodeEventsCursor(domain.EventCursor{ID: "event-1", Price: 5000}, domain.EventQuery{SortBy: domain.EventSortByPrice, Currency: domain.CurrencyBRL})
+	tests := []struct {
+		name  string
+		input ListEventsInputDTO
+		want  []string // campos reportados
+	}{
+		{name: "no parameters", input: ListEventsInputDTO{}},
+		{name: "every parameter", input: ListEventsInputDTO{
+			DateFrom: "2024-07-01", DateTo: "2024-07-31T23:00:00Z", Location: "Centro", Organization: "acme", Rating: "L18",
+			PartnerID: "2", Currency: "USD", Status: "sales_open, sold_out", PriceMin: "10", PriceMax: "99.90",
+			HasAvailability: "true", Sort: "name", Limit: "100", Cursor: nameCursor,
+		}},
+		{name: "malformed dates", input: ListEventsInputDTO{DateFrom: "01/07/2024", DateTo: "tomorrow"}, want: []string{"date_from", "date_to"}},
+		{name: "dates out of order", input: ListEventsInputDTO{DateFrom: "2024-07-02", DateTo: "2024-07-01"}, want: []string{"date_to"}},
+		{name: "same day", input: ListEventsInputDTO{DateFrom: "2024-07-01", DateTo: "2024-07-01"}},
+		{name: "unknown rating", input: ListEventsInputDTO{Rating: "PG"}, want: []string{"rating"}},
+		{name: "drafts are never listed", input: ListEventsInputDTO{Status: "published,draft"}, want: []string{"status"}},
+		{name: "unknown status", input: ListEventsInputDTO{Status: "open"}, want: []string{"status"}},
+		{name: "partner id", input: ListEventsInputDTO{PartnerID: "0"}, want: []string{"partner_id"}},
+		{name: "malformed partner id", input: ListEventsInputDTO{PartnerID: "one"}, want: []string{"partner_id"}},
+		{name: "unsupported currency", input: ListEventsInputDTO{Currency: "ARS", PriceMin: "10"}, want: []string{"currency"}},
+		{name: "currency without prices", input: ListEventsInputDTO{Currency: "EUR"}},
+		{name: "price range without currency", input: ListEventsInputDTO{PriceMin: "10", PriceMax: "20"}, want: []string{"currency"}},
+		{name: "price sort without currency", input: ListEventsInputDTO{Sort: "price"}, want: []string{"currency"}},
+		{name: "descending price sort without currency", input: ListEventsInputDTO{Sort: "-price"}, want: []string{"currency"}},
+		{name: "price sort", input: ListEventsInputDTO{Sort: "price", Currency: "BRL", Cursor: priceCursor}},
+		{name: "price cursor of another currency", input: ListEventsInputDTO{Sort: "price", Currency: "USD", Cursor: priceCursor}, want: []string{"cursor"}},
+		{name: "price range out of order", input: ListEventsInputDTO{Currency: "BRL", PriceMin: "50", PriceMax: "10"}, want: []string{"price_max"}},
+		{name: "malformed prices", input: ListEventsInputDTO{Currency: "BRL", PriceMin: "-1", PriceMax: "1.234"}, want: []string{"price_min", "price_max"}},
+		{name: "malformed availability", input: ListEventsInputDTO{HasAvailability: "yes"}, want: []string{"has_availability"}},
+		{name: "unknown sort", input: ListEventsInputDTO{Sort: "-rating"}, want: []string{"sort"}},
+		{name: "limit out of range", input: ListEventsInputDTO{Limit: "101"}, want: []string{"limit"}},
+		{name: "zero limit", input: ListEventsInputDTO{Limit: "0"}, want: []string{"limit"}},
+		{name: "malformed cursor", input: ListEventsInputDTO{Cursor: "not a cursor"}, want: []string{"cursor"}},
+		{name: "cursor of another sort", input: ListEventsInputDTO{Sort: "-name", Cursor: nameCursor}, want: []string{"cursor"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.input.Query(now)
+			if got := validationFields(t, err); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Query fields = %v, want %v (error %v)", got, tt.want, err)
+			}
+		})
+	}
+}
+
+func TestListEventsInputQueryDefaults(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	q, err := ListEventsInputDTO{DateTo: "2024-07-01", Currency: "USD", PriceMin: "10.50"}.Query(now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := domain.EventQuery{
+		DateTo:   time.Date(2024, 7, 1, 23, 59, 59, 0, time.UTC),
+		Currency: domain.CurrencyUSD,
+		PriceMin: 1050,
+		Statuses: publicEventStatuses,
+		Now:      now,
+		SortBy:   domain.EventSortByDate,
+		Limit:    defaultEventsPageSize,
+	}
+	if !reflect.DeepEqual(q, want) {
+		t.Errorf("Query =\n%+v\nwant\n%+v", q, want)
+	}
+}
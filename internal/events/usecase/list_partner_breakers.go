@@ -0,0 +1,46 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/infra/service"
+)
+
+type ListPartnerBreakersOutputDTO struct {
+	Breakers []PartnerBreakerDTO `json:"breakers"`
+}
+
+type PartnerBreakerDTO struct {
+	PartnerID           int        `json:"partner_id"`
+	State               string     `json:"state"`
+	ConsecutiveFailures int        `json:"consecutive_failures"`
+	OpenedAt            *time.Time `json:"opened_at,omitempty"`
+	RetryAt             *time.Time `json:"retry_at,omitempty"`
+}
+
+// ListPartnerBreakersUseCase expõe o estado do circuit breaker de cada parceiro.
+type ListPartnerBreakersUseCase struct {
+	reporter service.BreakerReporter
+}
+
+func NewListPartnerBreakersUseCase(reporter service.BreakerReporter) *ListPartnerBreakersUseCase {
+	return &ListPartnerBreakersUseCase{reporter: reporter}
+}
+
+func (uc *ListPartnerBreakersUseCase) Execute(ctx context.Context) (*ListPartnerBreakersOutputDTO, error) {
+	snapshots := uc.reporter.Breakers()
+
+	breakers := make([]PartnerBreakerDTO, len(snapshots))
+	for i, snapshot := range snapshots {
+		breakers[i] = PartnerBreakerDTO{
+			PartnerID:           snapshot.PartnerID,
+			State:               string(snapshot.State),
+			ConsecutiveFailures: snapshot.ConsecutiveFailures,
+			OpenedAt:            snapshot.OpenedAt,
+			RetryAt:             snapshot.RetryAt,
+		}
+	}
+
+	return &ListPartnerBreakersOutputDTO{Breakers: breakers}, nil
+}
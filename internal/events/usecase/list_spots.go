@@ -1,6 +1,10 @@
 package usecase
 
-import "github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+import (
+	"context"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+)
 
 type ListSpotsInputDTO struct {
 	EventID string `json:"events"`
@@ -19,39 +23,21 @@ func NewListSpotsUseCase(repo domain.EventRepository) *ListSpotsUseCase {
 	return &ListSpotsUseCase{repo: repo}
 }
 
-func (uc *ListSpotsUseCase) Execute(input ListSpotsInputDTO) (*ListSpotsOutputDTO, error) {
-	event, err := uc.repo.FindEventByID(input.EventID)
+func (uc *ListSpotsUseCase) Execute(ctx context.Context, input ListSpotsInputDTO) (*ListSpotsOutputDTO, error) {
+	event, err := uc.repo.FindEventByID(ctx, input.EventID)
 	if err != nil {
 		return nil, err
 	}
 
-	spots, err := uc.repo.FindSpotsByEventID(input.EventID)
+	spots, err := uc.repo.FindSpotsByEventID(ctx, input.EventID)
 	if err != nil {
 		return nil, err
 	}
 
 	spotDTOs := make([]SpotDTO, len(spots))
 	for i, spot := range spots {
-		spotDTOs[i] = SpotDTO{
-			ID:       spot.ID,
-			Name:     spot.Name,
-			Status:   string(spot.Status),
-			TicketID: spot.TicketID,
-		}
-	}
-
-	eventDTO := EventDTO{
-		ID:           event.ID,
-		Name:         event.Name,
-		Location:     event.Location,
-		Organization: event.Organization,
-		Rating:       string(event.Rating),
-		Date:         event.Date.Format("2006-01-02 15:04:05"),
-		ImageURL:     event.ImageURL,
-		Capacity:     event.Capacity,
-		Price:        event.Price,
-		PartnerID:    event.PartnerID,
+		spotDTOs[i] = newSpotDTO(event, spot)
 	}
 
-	return &ListSpotsOutputDTO{Event: eventDTO, Spots: spotDTOs}, nil
+	return &ListSpotsOutputDTO{Event: newEventDTO(event), Spots: spotDTOs}, nil
 }
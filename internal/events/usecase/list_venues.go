@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+)
+
+type VenueSummaryDTO struct {
+	ID      string `json:"id"`
+	Name    string `json:"name"`
+	Address string `json:"address"`
+}
+
+type ListVenuesOutputDTO struct {
+	Venues []VenueSummaryDTO `json:"venues"`
+}
+
+type ListVenuesUseCase struct {
+	repo domain.VenueRepository
+}
+
+func NewListVenuesUseCase(repo domain.VenueRepository) *ListVenuesUseCase {
+	return &ListVenuesUseCase{repo: repo}
+}
+
+func (uc *ListVenuesUseCase) Execute(ctx context.Context) (*ListVenuesOutputDTO, error) {
+	venues, err := uc.repo.ListVenues(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	venueDTOs := make([]VenueSummaryDTO, len(venues))
+	for i, venue := range venues {
+		venueDTOs[i] = VenueSummaryDTO{ID: venue.ID, Name: venue.Name, Address: venue.Address}
+	}
+	return &ListVenuesOutputDTO{Venues: venueDTOs}, nil
+}
@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+)
+
+type PurgeIdempotencyKeysOutputDTO struct {
+	Deleted int64 `json:"deleted"`
+}
+
+// PurgeIdempotencyKeysUseCase remove as chaves de idempotência expiradas.
+type PurgeIdempotencyKeysUseCase struct {
+	repo domain.IdempotencyRepository
+}
+
+func NewPurgeIdempotencyKeysUseCase(repo domain.IdempotencyRepository) *PurgeIdempotencyKeysUseCase {
+	return &PurgeIdempotencyKeysUseCase{repo: repo}
+}
+
+func (uc *PurgeIdempotencyKeysUseCase) Execute(ctx context.Context) (*PurgeIdempotencyKeysOutputDTO, error) {
+	deleted, err := uc.repo.DeleteExpiredIdempotencyKeys(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &PurgeIdempotencyKeysOutputDTO{Deleted: deleted}, nil
+}
@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+)
+
+type ReleaseExpiredHoldsOutputDTO struct {
+	Released int64 `json:"released"`
+}
+
+// ReleaseExpiredHoldsUseCase devolve para disponível os spots cuja retenção expirou.
+type ReleaseExpiredHoldsUseCase struct {
+	repo domain.EventRepository
+}
+
+func NewReleaseExpiredHoldsUseCase(repo domain.EventRepository) *ReleaseExpiredHoldsUseCase {
+	return &ReleaseExpiredHoldsUseCase{repo: repo}
+}
+
+func (uc *ReleaseExpiredHoldsUseCase) Execute(ctx context.Context) (*ReleaseExpiredHoldsOutputDTO, error) {
+	released, err := uc.repo.ReleaseExpiredHolds(ctx, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &ReleaseExpiredHoldsOutputDTO{Released: released}, nil
+}
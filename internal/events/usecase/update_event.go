@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+)
+
+// UpdateEventInputDTO é o corpo de PATCH /events/{eventID}: apenas os campos enviados são alterados.
+// A organização e o parceiro não podem ser alterados, já que definem quem administra o evento.
+type UpdateEventInputDTO struct {
+	EventID  string       `json:"-"`
+	Name     *string      `json:"name"`
+	Location *string      `json:"location"`
+	Rating   *string      `json:"rating"`
+	Date     *time.Time   `json:"date"`
+	Capacity *int         `json:"capacity"`
+	ImageURL *string      `json:"image_url"`
+	Price    *json.Number `json:"price" swaggertype:"number" example:"100.00"`
+	// Currency só pode ser alterada junto com Price.
+	Currency *string `json:"currency" example:"BRL"`
+}
+
+// Validate verifica os campos enviados e retorna domain.ValidationErrors com os problemas encontrados.
+// currency é a moeda atual do evento, usada para validar Price quando Currency não é enviada.
+func (input UpdateEventInputDTO) Validate(currency domain.Currency) error {
+	var v validator
+
+	if input.Name != nil && v.required(strings.TrimSpace(*input.Name), "name") {
+		v.maxLength(*input.Name, 255, "name")
+	}
+	if input.Location != nil {
+		v.maxLength(*input.Location, 255, "location")
+	}
+	if input.Rating != nil {
+		v.check(domain.Rating(*input.Rating).IsValid(), "rating", domain.FieldInvalidValue, "%s", domain.ErrInvalidRating.Message)
+	}
+	if input.Date != nil {
+		v.check(input.Date.After(time.Now()), "date", domain.FieldOutOfRange, "date must be in the future")
+	}
+	if input.Capacity != nil {
+		v.check(*input.Capacity > 0 && *input.Capacity <= maxEventCapacity, "capacity", domain.FieldOutOfRange, "capacity must be between 1 and %d", maxEventCapacity)
+	}
+	if input.ImageURL != nil && *input.ImageURL != "" {
+		v.httpURL(*input.ImageURL, "image_url")
+		v.maxLength(*input.ImageURL, 255, "image_url")
+	}
+	if input.Currency != nil {
+		currency = v.currency(*input.Currency, "currency")
+		v.check(input.Price != nil, "price", domain.FieldRequired, "price is required when currency changes")
+	}
+	if input.Price != nil {
+		v.price(string(*input.Price), currency, "price")
+	}
+
+	return v.err()
+}
+
+type UpdateEventUseCase struct {
+	repo domain.Repositories
+}
+
+func NewUpdateEventUseCase(repo domain.Repositories) *UpdateEventUseCase {
+	return &UpdateEventUseCase{repo: repo}
+}
+
+// Execute aplica as alterações enviadas. O preço não muda depois que algum ingresso foi vendido,
+// e uma nova data revalida o evento inteiro (Event.Validate).
+func (uc *UpdateEventUseCase) Execute(ctx context.Context, input UpdateEventInputDTO) (*EventDTO, error) {
+	event, err := modifyEvent(ctx, uc.repo, input.EventID, func(repo domain.EventRepository, event *domain.Event) error {
+		if event.Status == domain.EventStatusCancelled {
+			return domain.ErrEventCancelled
+		}
+		if err := input.Validate(event.Price.Currency); err != nil {
+			return err
+		}
+
+		if input.Name != nil {
+			event.Name = *input.Name
+		}
+		if input.Location != nil {
+			event.Location = *input.Location
+		}
+		if input.Rating != nil {
+			event.Rating = domain.Rating(*input.Rating)
+		}
+		if input.Capacity != nil {
+			if err := event.ChangeCapacity(*input.Capacity); err != nil {
+				return err
+			}
+		}
+		if input.ImageURL != nil {
+			event.ImageURL = *input.ImageURL
+		}
+		if input.Price != nil {
+			currency := event.Price.Currency
+			if input.Currency != nil {
+				currency = domain.Currency(*input.Currency)
+			}
+			price, err := domain.ParseMoney(string(*input.Price), currency)
+			if err != nil {
+				return err
+			}
+			if err := event.ChangePrice(price); err != nil {
+				return err
+			}
+		}
+		if input.Date != nil {
+			event.Date = *input.Date
+			return event.Validate()
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	output := newEventDTO(event)
+	return &output, nil
+}
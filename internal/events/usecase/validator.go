@@ -0,0 +1,151 @@
+package usecase
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/Eddiesantle/golang-inbound-selling/internal/events/domain"
+)
+
+// validator acumula os erros de campo de uma entrada.
+type validator struct {
+	errs domain.ValidationErrors
+}
+
+// check registra o erro do campo quando ok é falso.
+func (v *validator) check(ok bool, field, code, format string, args ...any) {
+	if !ok {
+		v.errs = append(v.errs, domain.FieldError{Field: field, Code: code, Message: fmt.Sprintf(format, args...)})
+	}
+}
+
+func (v *validator) required(value, field string) bool {
+	v.check(value != "", field, domain.FieldRequired, "%s is required", field)
+	return value != ""
+}
+
+func (v *validator) maxLength(value string, max int, field string) {
+	v.check(len(value) <= max, field, domain.FieldOutOfRange, "%s must have at most %d characters", field, max)
+}
+
+func (v *validator) email(value, field string) {
+	if !v.required(value, field) {
+		return
+	}
+	address, err := mail.ParseAddress(value)
+	v.check(err == nil && address.Address == value, field, domain.FieldInvalidFormat, "%s must be a valid address", field)
+}
+
+func (v *validator) httpURL(value, field string) {
+	u, err := url.Parse(value)
+	v.check(err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "", field, domain.FieldInvalidFormat, "%s must be an absolute http(s) URL", field)
+}
+
+// uniqueNames verifica uma lista de nomes não vazia, sem itens vazios ou repetidos.
+func (v *validator) uniqueNames(values []string, max int, field string) {
+	if len(values) == 0 {
+		v.check(false, field, domain.FieldRequired, "%s must not be empty", field)
+		return
+	}
+	v.check(len(values) <= max, field, domain.FieldOutOfRange, "%s must have at most %d items", field, max)
+
+	seen := make(map[string]bool, len(values))
+	for i, value := range values {
+		item := fmt.Sprintf("%s[%d]", field, i)
+		v.check(value != "", item, domain.FieldRequired, "%s is required", item)
+		if value != "" && seen[value] {
+			v.check(false, item, domain.FieldDuplicate, "%s %q is duplicated", field, value)
+		}
+		seen[value] = true
+	}
+}
+
+// queryInt converte um parâmetro inteiro opcional, exigindo value >= min e, se max > 0, value <= max.
+func (v *validator) queryInt(value, field string, min, max int) int {
+	if value == "" {
+		return 0
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		v.check(false, field, domain.FieldInvalidFormat, "%s must be an integer", field)
+		return 0
+	}
+	if n < min || (max > 0 && n > max) {
+		if max > 0 {
+			v.check(false, field, domain.FieldOutOfRange, "%s must be between %d and %d", field, min, max)
+		} else {
+			v.check(false, field, domain.FieldOutOfRange, "%s must be at least %d", field, min)
+		}
+		return 0
+	}
+	return n
+}
+
+// maxPriceAmount limita os preços informados (em unidades menores), mantendo os cálculos
+// de desconto longe do limite de int64.
+const maxPriceAmount = 1_000_000_000_000
+
+// currency converte a moeda informada, usando domain.DefaultCurrency quando vazia.
+func (v *validator) currency(value, field string) domain.Currency {
+	if value == "" {
+		return domain.DefaultCurrency
+	}
+	currency := domain.Currency(value)
+	v.check(currency.IsValid(), field, domain.FieldInvalidValue, "%s %q is not supported", field, value)
+	return currency
+}
+
+// price converte um valor decimal positivo na moeda informada. Valores com mais casas decimais
+// do que a moeda permite são rejeitados, nunca arredondados.
+func (v *validator) price(value string, currency domain.Currency, field string) domain.Money {
+	if !v.required(value, field) || !currency.IsValid() {
+		return domain.Money{}
+	}
+	price, err := domain.ParseMoney(value, currency)
+	if err != nil {
+		v.check(false, field, domain.FieldInvalidFormat, "%s must be a decimal amount with at most %d decimal places", field, currency.Exponent())
+		return domain.Money{}
+	}
+	v.check(price.IsPositive(), field, domain.FieldOutOfRange, "%s must be greater than zero", field)
+	v.check(price.Amount <= maxPriceAmount, field, domain.FieldOutOfRange, "%s must be at most %s", field, domain.NewMoney(maxPriceAmount, currency))
+	return price
+}
+
+// queryPrice converte um parâmetro de preço opcional, retornando-o em unidades menores.
+func (v *validator) queryPrice(value string, currency domain.Currency, field string) int64 {
+	if value == "" {
+		return 0
+	}
+	return max(v.price(value, currency, field).Amount, 0)
+}
+
+// queryDate converte um parâmetro de data opcional em RFC 3339 ou AAAA-MM-DD.
+// Com endOfDay, uma data sem horário representa o último segundo do dia.
+func (v *validator) queryDate(value, field string, endOfDay bool) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t
+	}
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		v.check(false, field, domain.FieldInvalidFormat, "%s must be a date (YYYY-MM-DD) or an RFC 3339 timestamp", field)
+		return time.Time{}
+	}
+	if endOfDay {
+		t = t.Add(24*time.Hour - time.Second)
+	}
+	return t
+}
+
+// err retorna os erros acumulados, ou nil se a entrada for válida.
+func (v *validator) err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs
+}